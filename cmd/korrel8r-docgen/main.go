@@ -71,8 +71,23 @@ func run() error {
 	tools := result.Tools
 	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
 
+	resources, err := cs.ListResources(ctx, &mcp.ListResourcesParams{})
+	if err != nil {
+		return fmt.Errorf("listing resources: %w", err)
+	}
+	templates, err := cs.ListResourceTemplates(ctx, &mcp.ListResourceTemplatesParams{})
+	if err != nil {
+		return fmt.Errorf("listing resource templates: %w", err)
+	}
+	prompts, err := cs.ListPrompts(ctx, &mcp.ListPromptsParams{})
+	if err != nil {
+		return fmt.Errorf("listing prompts: %w", err)
+	}
+	sort.Slice(prompts.Prompts, func(i, j int) bool { return prompts.Prompts[i].Name < prompts.Prompts[j].Name })
+
 	w := os.Stdout
-	fmt.Fprintln(w, "Korrel8r provides an [MCP](https://modelcontextprotocol.io/) server with the following tools.")
+	fmt.Fprintln(w, "Korrel8r provides an [MCP](https://modelcontextprotocol.io/) server with the following tools,")
+	fmt.Fprintln(w, "as well as [resources](#resources) and [prompts](#prompts).")
 	fmt.Fprintln(w)
 
 	// Table of contents
@@ -93,6 +108,35 @@ func run() error {
 			writeSchemaTable(w, "Output", t.OutputSchema)
 		}
 	}
+
+	fmt.Fprintln(w, "## Resources")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| URI | MIME type | Description |")
+	fmt.Fprintln(w, "|-----|-----------|-------------|")
+	for _, r := range resources.Resources {
+		fmt.Fprintf(w, "| `%s` | %s | %s |\n", r.URI, r.MIMEType, r.Description)
+	}
+	for _, r := range templates.ResourceTemplates {
+		fmt.Fprintf(w, "| `%s` | %s | %s |\n", r.URITemplate, r.MIMEType, r.Description)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "## Prompts")
+	fmt.Fprintln(w)
+	for _, p := range prompts.Prompts {
+		fmt.Fprintf(w, "### %s\n\n", p.Name)
+		fmt.Fprintf(w, "%s\n\n", p.Description)
+		fmt.Fprintln(w, "| Argument | Required | Description |")
+		fmt.Fprintln(w, "|----------|----------|-------------|")
+		for _, a := range p.Arguments {
+			req := ""
+			if a.Required {
+				req = "yes"
+			}
+			fmt.Fprintf(w, "| `%s` | %s | %s |\n", a.Name, req, a.Description)
+		}
+		fmt.Fprintln(w)
+	}
 	return nil
 }
 
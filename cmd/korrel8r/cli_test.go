@@ -3,7 +3,9 @@
 package main_test
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -23,12 +25,15 @@ func TestMain_list(t *testing.T) {
 	require.NoError(t, test.ExecError(err))
 	want := `
 alert     Prometheus/AlertManager alerts.
+audit     Kubernetes API server audit events.
+event     Kubernetes events.
 incident  cluster health incidents.
 k8s       Kubernetes resources.
 log       application, infrastructure, and audit logs.
 metric    Prometheus metrics.
 mock      Mock domain.
 netflow   network flow data.
+profile   continuous profiles.
 trace     OpenTelemetry traces.
 `
 	assert.Equal(t, strings.TrimSpace(want), strings.TrimSpace(string(out)))
@@ -37,7 +42,7 @@ trace     OpenTelemetry traces.
 func TestMain_list_domain(t *testing.T) {
 	out, err := cliCommand(t, "list", "metric").Output()
 	require.NoError(t, test.ExecError(err))
-	want := "metric"
+	want := "metric\nsamples"
 	assert.Equal(t, want, strings.TrimSpace(string(out)))
 }
 
@@ -47,6 +52,43 @@ func TestMain_get(t *testing.T) {
 	assert.Equal(t, "\"hello\"\n", string(out))
 }
 
+func TestMain_neighbors_output(t *testing.T) {
+	for _, x := range []struct {
+		output, want string
+	}{
+		{"dot", `"mock:foo" [label="mock:foo\ncount: 2"];`},
+		{"mermaid", "flowchart LR\n"},
+		{"graphml", `<node id="mock:bar">`},
+		{"cytoscape", `"id": "mock:foo"`},
+	} {
+		t.Run(x.output, func(t *testing.T) {
+			out, err := cliCommand(t, "neighbors", "-q", "mock:foo:hello", "-o", x.output).Output()
+			require.NoError(t, test.ExecError(err))
+			assert.Contains(t, string(out), x.want)
+		})
+	}
+	_, err := cliCommand(t, "get", "-o", "dot", "mock:foo:hello").Output()
+	assert.Error(t, err, "graph output format for objects")
+}
+
+func TestMain_diff(t *testing.T) {
+	dir := t.TempDir()
+	neighbors := func(file, output, depth string) string {
+		out, err := cliCommand(t, "neighbors", "-q", "mock:foo:hello", "-d", depth, "-o", output).Output()
+		require.NoError(t, test.ExecError(err))
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.WriteFile(path, out, 0o600))
+		return path
+	}
+	before, after := neighbors("before.json", "json", "0"), neighbors("after.yaml", "yaml", "1")
+	out, err := cliCommand(t, "diff", before, after, "-o", "json").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.JSONEq(t, `{
+  "addedEdges": [{"start": "mock:foo", "goal": "mock:bar"}],
+  "addedNodes": [{"class": "mock:bar", "count": 1, "queries": [{"query": "mock:bar:y", "count": 1}]}]
+}`, string(out))
+}
+
 func TestMain_rules(t *testing.T) {
 	for _, x := range []struct {
 		args []string
@@ -78,6 +120,8 @@ func TestMain_stores(t *testing.T) {
 	require.NoError(t, test.ExecError(err))
 	want := `{
   "alert": null,
+  "audit": null,
+  "event": null,
   "incident": null,
   "k8s": null,
   "log": null,
@@ -89,6 +133,7 @@ func TestMain_stores(t *testing.T) {
     }
   ],
   "netflow": null,
+  "profile": null,
   "trace": null
 }`
 	assert.Equal(t, strings.TrimSpace(want), strings.TrimSpace(string(out)))
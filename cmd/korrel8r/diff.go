@@ -0,0 +1,35 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package main
+
+import (
+	"os"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/must"
+	"github.com/korrel8r/korrel8r/internal/pkg/yaml"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/rest"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff BEFORE AFTER",
+	Short: "Compare two result graphs.",
+	Long: `Compare two result graphs stored in the files BEFORE and AFTER.
+The files contain JSON or YAML graphs, as printed by the goals, neighbors or reverse commands.
+Prints added and removed nodes and edges, and nodes with changed counts for results, queries or statuses.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		newPrinter(os.Stdout).Print(rest.DiffGraphs(readGraph(args[0]), readGraph(args[1])))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+func readGraph(path string) *api.Graph {
+	var g api.Graph
+	must.Must(yaml.Unmarshal(must.Must1(os.ReadFile(path)), &g))
+	return &g
+}
@@ -27,9 +27,11 @@ var (
 		Rules:   new(false),
 		Errors:  new(false),
 		Results: new(false),
+		Explain: new(false),
 	}
 	// Constraint values
-	since, until, timeout time.Duration
+	since, until, timeout            time.Duration
+	maxQueries, maxObjects, maxBytes int
 )
 
 func startFlags(cmd *cobra.Command) {
@@ -39,6 +41,7 @@ func startFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(graphOptions.Rules, "rules", false, "Include rule names in returned graph")
 	cmd.Flags().BoolVar(graphOptions.Results, "results", false, "Include complete query results in graph")
 	cmd.Flags().BoolVar(graphOptions.Errors, "errors", false, "Include non-fatal errors in graph")
+	cmd.Flags().BoolVar(graphOptions.Explain, "explain", false, "Include the provenance of each result object in graph")
 }
 
 func constraintFlags(cmd *cobra.Command) {
@@ -48,6 +51,12 @@ func constraintFlags(cmd *cobra.Command) {
 	cmd.Flags().DurationVar(&until, "until", 0, "Only get results until this long ago.")
 }
 
+func budgetFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&maxQueries, "max-queries", 0, "Budget for total store queries in a search.")
+	cmd.Flags().IntVar(&maxObjects, "max-objects", 0, "Budget for total objects retrieved in a search.")
+	cmd.Flags().IntVar(&maxBytes, "max-bytes", 0, "Budget for total bytes of JSON objects retrieved in a search.")
+}
+
 var (
 	objectsCmd = &cobra.Command{
 		Use:     "objects QUERY",
@@ -82,7 +91,7 @@ var (
 			defer cancel()
 			g, err := traverse.Neighbors(ctx, e, start(e), depth)
 			must.Must(err)
-			newPrinter(os.Stdout).Print(rest.NewGraph(g, &graphOptions))
+			printGraph(os.Stdout, rest.NewGraph(g, &graphOptions))
 		},
 	}
 	depth int
@@ -92,6 +101,7 @@ func init() {
 	rootCmd.AddCommand(neighborsCmd)
 	startFlags(neighborsCmd)
 	constraintFlags(neighborsCmd)
+	budgetFlags(neighborsCmd)
 	neighborsCmd.Flags().IntVarP(&depth, "depth", "d", 3, "Depth of neighborhood search.")
 }
 
@@ -108,17 +118,58 @@ var (
 			}
 			ctx, cancel := e.WithTimeout(context.Background(), timeout)
 			defer cancel()
-			g, err := traverse.Goals(ctx, e, start(e), goals)
+			start := start(e)
+			start.ShortestPaths = shortestPaths
+			g, err := traverse.Goals(ctx, e, start, goals)
 			must.Must(err)
-			newPrinter(os.Stdout).Print(rest.NewGraph(g, &graphOptions))
+			printGraph(os.Stdout, rest.NewGraph(g, &graphOptions))
 		},
 	}
+	shortestPaths int
 )
 
 func init() {
 	rootCmd.AddCommand(goalsCmd)
 	startFlags(goalsCmd)
 	constraintFlags(goalsCmd)
+	budgetFlags(goalsCmd)
+	goalsCmd.Flags().IntVarP(&shortestPaths, "shortest", "k", 0, "Only follow the K lowest-cost rule paths to each goal, 0 means follow all short paths.")
+}
+
+var (
+	reverseCmd = &cobra.Command{
+		Use:   "reverse START [START...]",
+		Short: "Find objects of START classes that lead to goal objects.",
+		Long: `Find objects of START classes that lead to goal objects by following rules forward.
+Goal objects are specified with --query, or --class and --object.
+Candidate start objects are found by searching backwards from the goal, or by executing --candidate queries.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			e := newEngine()
+			var starts []korrel8r.Class
+			for _, s := range args {
+				starts = append(starts, must.Must1(e.Class(s)))
+			}
+			var candidateQueries []korrel8r.Query
+			for _, q := range candidates {
+				candidateQueries = append(candidateQueries, must.Must1(e.Query(q)))
+			}
+			ctx, cancel := e.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			g, err := traverse.Reverse(ctx, e, start(e), starts, candidateQueries)
+			must.Must(err)
+			printGraph(os.Stdout, rest.NewGraph(g, &graphOptions))
+		},
+	}
+	candidates []string
+)
+
+func init() {
+	rootCmd.AddCommand(reverseCmd)
+	startFlags(reverseCmd)
+	constraintFlags(reverseCmd)
+	budgetFlags(reverseCmd)
+	reverseCmd.Flags().StringArrayVar(&candidates, "candidate", nil, "Query for candidate start objects, can be multiple.")
 }
 
 func constraint() *korrel8r.Constraint {
@@ -133,6 +184,15 @@ func constraint() *korrel8r.Constraint {
 	if until > 0 {
 		c.End = new(now.Add(-until))
 	}
+	if maxQueries > 0 {
+		c.MaxQueries = new(maxQueries)
+	}
+	if maxObjects > 0 {
+		c.MaxObjects = new(maxObjects)
+	}
+	if maxBytes > 0 {
+		c.MaxBytes = new(maxBytes)
+	}
 	return c
 }
 
@@ -146,7 +206,7 @@ func start(e *engine.Engine) traverse.Start {
 	default:
 		must.Must(fmt.Errorf("must provide a class or at least one query"))
 	}
-	start := traverse.Start{Class: c, Constraint: constraint()}
+	start := traverse.Start{Class: c, Constraint: constraint(), Explain: *graphOptions.Explain}
 	for _, q := range queries {
 		start.Queries = append(start.Queries, must.Must1(e.Query(q)))
 	}
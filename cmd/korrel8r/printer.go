@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/enumflag"
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/must"
 	"github.com/korrel8r/korrel8r/internal/pkg/yaml"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/export"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 )
 
@@ -59,7 +62,24 @@ type yamlPrinter struct {
 func (p *yamlPrinter) Print(v any) { b, _ := yaml.Marshal(noNull(v)); _, _ = p.Write(b) }
 func (p *yamlPrinter) Close()      { p.Print(p.appender) }
 
-var outputFlag = enumflag.New("yaml", []string{"json", "json-pretty", "ndjson", "yaml"})
+var outputFlag = enumflag.New("yaml", append([]string{"json", "json-pretty", "ndjson", "yaml"}, graphFormats()...))
+
+// graphFormats are output formats that are only valid for graphs, see [printGraph].
+func graphFormats() (formats []string) {
+	for _, f := range export.Formats {
+		formats = append(formats, string(f))
+	}
+	return formats
+}
+
+// printGraph prints a result graph, using a graph export format if selected.
+func printGraph(w io.Writer, g *api.Graph) {
+	if f := export.Format(outputFlag.String()); slices.Contains(export.Formats, f) {
+		must.Must(export.Write(w, f, g))
+		return
+	}
+	newPrinter(w).Print(g)
+}
 
 func newPrinter(w io.Writer) printer {
 	switch outputFlag.String() {
@@ -78,6 +98,9 @@ func newPrinter(w io.Writer) printer {
 		return &yamlPrinter{Writer: w}
 
 	default:
+		if slices.Contains(graphFormats(), outputFlag.String()) {
+			must.Must(fmt.Errorf("output type %v is only valid for graphs", outputFlag))
+		}
 		must.Must(fmt.Errorf("invalid output type: %v", *outputFlag))
 		return nil
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/mcp"
 	"github.com/korrel8r/korrel8r/pkg/rest"
+	"github.com/korrel8r/korrel8r/pkg/searches"
 	"github.com/korrel8r/korrel8r/pkg/session"
 	"github.com/korrel8r/korrel8r/pkg/tokenreview"
 	"github.com/spf13/cobra"
@@ -94,14 +95,18 @@ var webCmd = &cobra.Command{
 		router := gin.New()
 		router.Use(gin.Recovery(), session.Middleware(sessions))
 
+		savedSearches := searches.NewMemoryStore()
+		if *savedSearchesFlag != "" {
+			savedSearches = must.Must1(searches.NewFileStore(*savedSearchesFlag))
+		}
 		if *restFlag {
-			must.Must1(rest.New(sessions, router))
+			must.Must1(rest.New(sessions, router)).Searches = savedSearches
 			log.V(0).Info("REST endpoint", "path", api.BasePath)
 		}
 		if *mcpFlag {
 			mcpRouter := gin.New()
 			mcpRouter.Use(gin.Recovery(), session.Middleware(sessions))
-			must.Must1(rest.New(sessions, mcpRouter))
+			must.Must1(rest.New(sessions, mcpRouter)).Searches = savedSearches
 			client := mcp.NewClientForHandler(mcpRouter)
 			mcpSrv := mcp.NewServer(client, build.Version, logging.Log())
 			mcpSrv.AddReceivingMiddleware(mcpmetrics.Metrics)
@@ -134,6 +139,7 @@ var (
 	specFlag                *string
 	mcpFlag                 *bool
 	restFlag                *bool
+	savedSearchesFlag       *string
 	unsafeSharedSessionFlag *bool
 	tlsMinVersionFlag       *string
 	tlsCipherSuitesFlag     *[]string
@@ -148,6 +154,7 @@ func init() {
 	keyFlag = webCmd.Flags().String("key", "", "Private key (PEM format) for https")
 	mcpFlag = webCmd.Flags().Bool("mcp", true, "Enable MCP streamable HTTP protocol on "+mcp.StreamablePath)
 	restFlag = webCmd.Flags().Bool("rest", true, "Enable HTTP REST server on "+api.BasePath)
+	savedSearchesFlag = webCmd.Flags().String("saved-searches", "", "JSON file to keep global saved searches. If not set, global saved searches are kept in memory.")
 	unsafeSharedSessionFlag = webCmd.Flags().Bool("unsafe-shared-session", false, "Allow unauthenticated requests to share a single session (UNSAFE: disables per-user isolation)")
 	specFlag = webCmd.Flags().String("spec", "", "Write OpenAPI specification to a file, '-' for stdout.")
 	tlsCipherSuitesFlag = webCmd.Flags().StringSlice("tls-cipher-suites", nil, "Comma-separated list of TLS cipher suites for https (IANA or OpenSSL names)")
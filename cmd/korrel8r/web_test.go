@@ -81,12 +81,15 @@ func assertDo(t *testing.T, h *http.Client, want, method, url, body string) {
 
 const domains = `[
 {"description":"Prometheus/AlertManager alerts.","name":"alert"},
+{"description":"Kubernetes API server audit events.","name":"audit"},
+{"description":"Kubernetes events.","name":"event"},
 {"description":"cluster health incidents.","name":"incident"},
 {"description":"Kubernetes resources.","name":"k8s"},
 {"description":"application, infrastructure, and audit logs.","name":"log"},
 {"description":"Prometheus metrics.","name":"metric"},
 {"description":"Mock domain.","name":"mock", "stores":[{"domain":"mock", "mockData":"testdata/mock_store.yaml"}]},
 {"description":"network flow data.","name":"netflow"},
+{"description":"continuous profiles.","name":"profile"},
 {"description":"OpenTelemetry traces.","name":"trace"}
 ]`
 
@@ -0,0 +1,75 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/domains/audit"
+)
+
+func newAudit(verb, resource, apiGroup, namespace, name string, code int) *audit.Event {
+	return &audit.Event{
+		AuditID:        "a",
+		Stage:          "ResponseComplete",
+		Verb:           verb,
+		User:           audit.UserInfo{Username: "alice"},
+		ObjectRef:      &audit.ObjectReference{Resource: resource, APIGroup: apiGroup, Namespace: namespace, Name: name},
+		ResponseStatus: &audit.Status{Code: code},
+	}
+}
+
+func TestAuditRules(t *testing.T) {
+	for _, x := range []ruleTest{
+		{
+			rule:  "K8sToAudit",
+			start: newK8s("Pod", "ns", "pod1", nil),
+			want:  []string{`audit:event:{"verbs":["create","update","patch","delete"],"objectRef":{"resource":"pods","namespace":"ns","name":"pod1"}}`},
+		},
+		{
+			rule:  "K8sToAudit",
+			start: newK8s("Deployment.apps", "ns", "d1", nil),
+			want:  []string{`audit:event:{"verbs":["create","update","patch","delete"],"objectRef":{"resource":"deployments","namespace":"ns","name":"d1"}}`},
+		},
+		{
+			rule:  "AuditToK8s",
+			start: newAudit("delete", "pods", "", "ns", "pod1", 200),
+			want:  []string{`k8s:Pod.v1:{"namespace":"ns","name":"pod1"}`},
+		},
+		{
+			rule:  "AuditToK8s",
+			start: newAudit("patch", "deployments", "apps", "ns", "d1", 200),
+			want:  []string{`k8s:Deployment.v1.apps:{"namespace":"ns","name":"d1"}`},
+		},
+	} {
+		x.Run(t)
+	}
+}
+
+func TestAuditStatusRules(t *testing.T) {
+	for _, x := range []statusRuleTest{
+		{
+			rule:   "AuditResponseStatus",
+			domain: audit.Domain,
+			class:  "event",
+			start:  newAudit("delete", "pods", "", "ns", "pod1", 403),
+			want:   []string{"Warning"},
+		},
+		{
+			rule:   "AuditResponseStatus",
+			domain: audit.Domain,
+			class:  "event",
+			start:  newAudit("delete", "pods", "", "ns", "pod1", 500),
+			want:   []string{"Error"},
+		},
+		{
+			rule:   "AuditResponseStatus",
+			domain: audit.Domain,
+			class:  "event",
+			start:  newAudit("delete", "pods", "", "ns", "pod1", 200),
+			want:   nil,
+		},
+	} {
+		x.Run(t)
+	}
+}
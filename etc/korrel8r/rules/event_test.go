@@ -0,0 +1,89 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/domains/alert"
+	"github.com/korrel8r/korrel8r/pkg/domains/event"
+	"github.com/korrel8r/korrel8r/pkg/domains/log"
+)
+
+func newEvent(kind, namespace, name string) event.Object {
+	return event.Object{
+		"kind":       "Event",
+		"apiVersion": "v1",
+		"metadata":   map[string]any{"namespace": namespace, "name": name + ".1"},
+		"involvedObject": map[string]any{
+			"kind":       kind,
+			"apiVersion": "v1",
+			"namespace":  namespace,
+			"name":       name,
+		},
+		"type": "Warning",
+	}
+}
+
+func TestEventRules(t *testing.T) {
+	for _, x := range []ruleTest{
+		{
+			rule:  "K8sToEvent",
+			start: newK8s("Pod", "ns", "pod1", nil),
+			want:  []string{`event:event:{"involvedObject":{"kind":"Pod","namespace":"ns","name":"pod1"}}`},
+		},
+		{
+			rule:  "EventToK8s",
+			start: newEvent("Pod", "ns", "pod1"),
+			want:  []string{`k8s:Pod.v1:{"namespace":"ns","name":"pod1"}`},
+		},
+		{
+			rule:  "AlertToEvent",
+			start: &alert.Object{Labels: map[string]string{"namespace": "ns", "pod": "pod1"}},
+			want:  []string{`event:event:{"involvedObject":{"kind":"Pod","namespace":"ns","name":"pod1"}}`},
+		},
+		{
+			rule:  "AlertToEvent",
+			start: &alert.Object{Labels: map[string]string{"namespace": "ns"}},
+			want:  []string{`event:event:{"namespace":"ns","type":"Warning"}`},
+		},
+		{
+			rule:  "EventToAlert",
+			start: newEvent("Pod", "ns", "pod1"),
+			want:  []string{`alert:alert:{"namespace":"ns","pod":"pod1"}`},
+		},
+		{
+			rule:  "EventToLogs",
+			start: newEvent("Pod", "ns", "pod1"),
+			want:  []string{`log:application:{"namespace":"ns","name":"pod1"}`},
+		},
+		{
+			rule:  "LogToEvent",
+			start: log.Object{"kubernetes_namespace_name": "ns", "kubernetes_pod_name": "pod1", "message": "hello"},
+			want:  []string{`event:event:{"involvedObject":{"kind":"Pod","namespace":"ns","name":"pod1"}}`},
+		},
+	} {
+		x.Run(t)
+	}
+}
+
+func TestEventStatusRules(t *testing.T) {
+	for _, x := range []statusRuleTest{
+		{
+			rule:   "EventWarning",
+			domain: event.Domain,
+			class:  "event",
+			start:  newEvent("Pod", "ns", "pod1"),
+			want:   []string{"Warning"},
+		},
+		{
+			rule:   "EventWarning",
+			domain: event.Domain,
+			class:  "event",
+			start:  event.Object{"type": "Normal"},
+			want:   nil,
+		},
+	} {
+		x.Run(t)
+	}
+}
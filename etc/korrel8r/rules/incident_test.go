@@ -26,7 +26,53 @@ func TestIncident(t *testing.T) {
 				}},
 			want: []string{`alert:alert:[{"deployment":"bar","namespace":"foo"},{"deployment":"barbaz","namespace":"foobaz"}]`},
 		},
+		{
+			rule:  "IncidentToNamespace",
+			start: incidentWithSources(),
+			want:  []string{`k8s:Namespace.v1:{"name":"demo"}`, `k8s:Namespace.v1:{"name":"openshift-etcd"}`},
+		},
+		{
+			rule:  "IncidentToK8s",
+			start: incidentWithSources(),
+			want: []string{
+				`k8s:Pod.v1:{"namespace":"openshift-etcd","name":"etcd-0"}`,
+				`k8s:Deployment.v1.apps:{"namespace":"demo","name":"web"}`,
+				`k8s:Node.v1:{"name":"worker-0"}`,
+			},
+		},
+		{
+			rule:  "K8sToIncident",
+			start: newK8s("Pod", "demo", "web-1", nil),
+			want:  []string{`incident:incident:{"sourceLabels":{"namespace":"demo","pod":"web-1"}}`},
+		},
+		{
+			rule:  "K8sToIncident",
+			start: newK8s("Deployment.apps", "demo", "web", nil),
+			want:  []string{`incident:incident:{"sourceLabels":{"deployment":"web","namespace":"demo"}}`},
+		},
+		{
+			rule:  "K8sToIncident",
+			start: newK8s("Namespace", "", "demo", nil),
+			want:  []string{`incident:incident:{"sourceLabels":{"namespace":"demo"}}`},
+		},
+		{
+			rule:  "K8sToIncident",
+			start: newK8s("Node", "", "worker-0", nil),
+			want:  []string{`incident:incident:{"sourceLabels":{"node":"worker-0"}}`},
+		},
 	} {
 		x.Run(t)
 	}
 }
+
+func incidentWithSources() *incident.Object {
+	return &incident.Object{
+		Id: "incident-id",
+		Sources: []incident.Source{
+			{Type: incident.SourceAlert, Labels: map[string]string{"alertname": "EtcdDown", "namespace": "openshift-etcd", "pod": "etcd-0"}},
+			{Type: incident.SourceAlert, Labels: map[string]string{"alertname": "Down", "namespace": "demo", "deployment": "web"}},
+			{Type: incident.SourceAlert, Labels: map[string]string{"alertname": "NodeDown", "node": "worker-0"}},
+			{Type: incident.SourceClusterOperatorCondition, Labels: map[string]string{"name": "etcd"}},
+		},
+	}
+}
@@ -61,3 +61,44 @@ func Test_NetflowFromK8S(t *testing.T) {
 		x.Run(t)
 	}
 }
+
+func Test_NetflowSummary(t *testing.T) {
+	summary := &netflow.Summary{
+		Src: netflow.Endpoint{Namespace: "foo", OwnerName: "bar", OwnerType: "Deployment"},
+		Dst: netflow.Endpoint{Namespace: "baz", OwnerName: "db", OwnerType: "StatefulSet"},
+	}
+	for _, x := range []ruleTest{
+		{
+			rule:  "NetflowSummaryToSrcK8s",
+			start: summary,
+			want:  []string{`k8s:Deployment.v1.apps:{"namespace":"foo","name":"bar"}`},
+		},
+		{
+			rule:  "NetflowSummaryToDstK8s",
+			start: summary,
+			want:  []string{`k8s:StatefulSet.v1.apps:{"namespace":"baz","name":"db"}`},
+		},
+		{
+			rule:  "K8sSrcToNetflowSummary",
+			start: newK8s("Pod", "bar", "foo", nil),
+			want:  []string{`netflow:summary:{SrcK8S_Type="Pod", SrcK8S_Namespace="bar"} | json | SrcK8S_Name="foo"`},
+		},
+		{
+			rule:  "K8sSrcOwnerToNetflowSummary",
+			start: newK8s("Deployment.apps", "bar", "foo", nil),
+			want:  []string{`netflow:summary:{SrcK8S_Namespace="bar", SrcK8S_OwnerName="foo"}`},
+		},
+		{
+			rule:  "K8sDstToNetflowSummary",
+			start: newK8s("Pod", "bar", "foo", nil),
+			want:  []string{`netflow:summary:{DstK8S_Type="Pod", DstK8S_Namespace="bar"} | json | DstK8S_Name="foo"`},
+		},
+		{
+			rule:  "K8sDstOwnerToNetflowSummary",
+			start: newK8s("Deployment.apps", "bar", "foo", nil),
+			want:  []string{`netflow:summary:{DstK8S_Namespace="bar", DstK8S_OwnerName="foo"}`},
+		},
+	} {
+		x.Run(t)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/domains/profile"
+	"github.com/korrel8r/korrel8r/pkg/domains/trace"
+)
+
+func TestProfileRules(t *testing.T) {
+	for _, x := range []ruleTest{
+		{
+			rule:  "PodToProfile",
+			start: newK8s("Pod", "ns", "pod1", nil),
+			want:  []string{`profile:profile:{namespace="ns", pod="pod1"}`},
+		},
+		{
+			rule: "SpanToProfile",
+			start: &trace.Span{
+				Context:    trace.SpanContext{TraceID: "0123456789abcdef0123456789abcdef", SpanID: "0123456789abcdef"},
+				Attributes: map[string]any{"service.name": "web"},
+			},
+			want: []string{`profile:profile:{service_name="web", span_id="0123456789abcdef"}`},
+		},
+		{
+			rule:  "SpanToProfile",
+			start: &trace.Span{Context: trace.SpanContext{SpanID: "0123456789abcdef"}},
+			want:  []string{`profile:profile:{span_id="0123456789abcdef"}`},
+		},
+		{
+			rule:  "ProfileToPod",
+			start: &profile.Series{ProfileType: "process_cpu:cpu:nanoseconds:cpu:nanoseconds", Labels: map[string]string{"namespace": "ns", "pod": "pod1"}},
+			want:  []string{`k8s:Pod.v1:{"namespace":"ns","name":"pod1"}`},
+		},
+	} {
+		x.Run(t)
+	}
+}
@@ -48,6 +48,11 @@ func Test_TraceFromPod(t *testing.T) {
 			start: newK8s("Pod", "bar", "foo", nil),
 			want:  `trace:span:{resource.k8s.namespace.name="bar"&&resource.k8s.pod.name="foo"}`,
 		},
+		{
+			rule:  "PodToTraceTree",
+			start: newK8s("Pod", "bar", "foo", nil),
+			want:  `trace:trace:{resource.k8s.namespace.name="bar"&&resource.k8s.pod.name="foo"}`,
+		},
 	} {
 		t.Run(x.rule, func(t *testing.T) {
 			tested(x.rule)
@@ -58,3 +63,31 @@ func Test_TraceFromPod(t *testing.T) {
 		})
 	}
 }
+
+func Test_TraceSpan(t *testing.T) {
+	e := setup()
+	for _, x := range []struct {
+		rule  string
+		start any
+		want  string
+	}{
+		{
+			rule:  "SpanToTrace",
+			start: &trace.Span{Context: trace.SpanContext{TraceID: "232323", SpanID: "3d48369744164bd0"}},
+			want:  `trace:trace:232323`,
+		},
+		{
+			rule:  "TraceToSpan",
+			start: trace.NewTrace([]*trace.Span{{Context: trace.SpanContext{TraceID: "232323", SpanID: "3d48369744164bd0"}}}),
+			want:  `trace:span:232323`,
+		},
+	} {
+		t.Run(x.rule, func(t *testing.T) {
+			tested(x.rule)
+			got, err := e.Rule(x.rule).Apply(x.start)
+			if assert.NoError(t, err) && assert.Len(t, got, 1) {
+				assert.Equal(t, x.want, got[0].String())
+			}
+		})
+	}
+}
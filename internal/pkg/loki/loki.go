@@ -92,12 +92,31 @@ func (c *Client) GetStack(ctx context.Context, logQL, tenant string, constraint
 	return c.get(ctx, u, collect)
 }
 
+// Sample is a single value from a metric query, with the labels of its series.
+type Sample struct {
+	Labels Labels
+	Value  float64
+}
+
+// Query uses the plain Loki API to evaluate a LogQL metric query at a single point in time.
+func (c *Client) Query(ctx context.Context, logQL string, at time.Time) ([]Sample, error) {
+	return c.query(ctx, vectorURL(logQL, at))
+}
+
+// QueryStack uses the LokiStack tenant API to evaluate a LogQL metric query at a single point in time.
+func (c *Client) QueryStack(ctx context.Context, logQL, tenant string, at time.Time) ([]Sample, error) {
+	u := vectorURL(logQL, at)
+	u.Path = path.Join(lokiStackPath, tenant, u.Path)
+	return c.query(ctx, u)
+}
+
 const ( // Query URL keywords
 	query = "query"
 	limit = "limit"
 
 	lokiStackPath  = "/api/logs/v1/"
 	queryRangePath = "/loki/api/v1/query_range"
+	queryPath      = "/loki/api/v1/query"
 )
 
 func queryURL(logQL string, c *korrel8r.Constraint) *url.URL {
@@ -117,6 +136,15 @@ func queryURL(logQL string, c *korrel8r.Constraint) *url.URL {
 	return u
 }
 
+func vectorURL(logQL string, at time.Time) *url.URL {
+	v := url.Values{}
+	v.Add(query, logQL)
+	if !at.IsZero() {
+		v.Add("time", formatTime(at))
+	}
+	return &url.URL{Path: queryPath, RawQuery: v.Encode()}
+}
+
 func formatTime(t time.Time) string { return strconv.FormatInt(t.UTC().UnixNano(), 10) }
 
 func (c *Client) get(ctx context.Context, u *url.URL, collect CollectFunc) error {
@@ -136,6 +164,37 @@ func (c *Client) get(ctx context.Context, u *url.URL, collect CollectFunc) error
 	return nil
 }
 
+func (c *Client) query(ctx context.Context, u *url.URL) ([]Sample, error) {
+	u = c.BaseURL.ResolveReference(u)
+	log.V(5).Info("Loki GET", "url", u)
+	qr := vectorResponse{}
+	if err := impl.Get(ctx, u, c.Client, &qr); err != nil {
+		return nil, err
+	}
+	if qr.Status != "success" {
+		return nil, fmt.Errorf("expected 'status: success' in %v", qr)
+	}
+	if qr.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("expected 'resultType: vector' in %v", qr)
+	}
+	samples := make([]Sample, 0, len(qr.Data.Result))
+	for _, r := range qr.Data.Result {
+		if len(r.Value) < 2 {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(r.Value[1], &s); err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, Sample{Labels: r.Metric, Value: v})
+	}
+	return samples, nil
+}
+
 // Visit each log record in the streams in timestamp order.
 // NOTE: assumes query direction is default "backward" (newest first)
 func collectSorted(streams []stream, collect CollectFunc) {
@@ -174,3 +233,14 @@ type stream struct {
 	Stream map[string]string `json:"stream"` // Labels for the stream
 	Values []Log             `json:"values"` // [ timestamp, line ] pairs
 }
+
+type vectorResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"` // Labels for the series
+			Value  []json.RawMessage `json:"value"`  // [ timestamp, "value" ]
+		} `json:"result"`
+	} `json:"data"`
+}
@@ -357,3 +357,30 @@ func containsString(s, substr string) bool {
 					return false
 				}())))
 }
+
+func TestClient_Query(t *testing.T) {
+	var gotPath string
+	var gotQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+{"metric":{"app":"a"},"value":[1672574400,"42"]},
+{"metric":{"app":"b"},"value":[1672574400,"1.5"]}]}}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	client := New(server.Client(), baseURL)
+	at := time.Unix(1672574400, 0)
+
+	samples, err := client.Query(context.Background(), `count_over_time({app="a"}[1h])`, at)
+	assert.NoError(t, err)
+	assert.Equal(t, []Sample{{Labels: Labels{"app": "a"}, Value: 42}, {Labels: Labels{"app": "b"}, Value: 1.5}}, samples)
+	assert.Equal(t, queryPath, gotPath)
+	assert.Equal(t, `count_over_time({app="a"}[1h])`, gotQuery.Get("query"))
+	assert.Equal(t, formatTime(at), gotQuery.Get("time"))
+
+	_, err = client.QueryStack(context.Background(), `count_over_time({app="a"}[1h])`, "network", at)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/logs/v1/network/loki/api/v1/query", gotPath)
+}
@@ -87,6 +87,7 @@ type Rule struct {
 	name        string
 	start, goal []korrel8r.Class
 	apply       ApplyFunc
+	priority    int
 }
 
 // NewRule creates a rule: apply can be an [ApplyFunc], [korrel8r.Query] or nil.
@@ -111,6 +112,10 @@ func (r *Rule) Start() []korrel8r.Class { return r.start }
 func (r *Rule) Goal() []korrel8r.Class  { return r.goal }
 func (r *Rule) Name() string            { return r.name }
 func (r *Rule) String() string          { return r.Name() }
+func (r *Rule) Priority() int           { return r.priority }
+
+// WithPriority sets the rule priority, returns r.
+func (r *Rule) WithPriority(priority int) *Rule { r.priority = priority; return r }
 
 func (r *Rule) Apply(start korrel8r.Object) ([]korrel8r.Query, error) { return r.apply(start) }
 
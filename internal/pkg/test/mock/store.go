@@ -56,6 +56,9 @@ func NewStore(d korrel8r.Domain, c ...korrel8r.Class) *Store {
 	}
 }
 
+// StoreKeyDelay in a mock store configuration sets [Store.Delay].
+const StoreKeyDelay = "mockDelay"
+
 // NewStoreConfig loads a store from the file indicated by cfg in StoreKeyMock
 func NewStoreConfig(d korrel8r.Domain, cfg any) (*Store, error) {
 	s := NewStore(d)
@@ -66,6 +69,11 @@ func NewStoreConfig(d korrel8r.Domain, cfg any) (*Store, error) {
 	if err != nil {
 		return nil, err
 	}
+	if delay := cs[StoreKeyDelay]; delay != "" {
+		if s.Delay, err = time.ParseDuration(delay); err != nil {
+			return nil, fmt.Errorf("invalid %v=%q: %w", StoreKeyDelay, delay, err)
+		}
+	}
 	file := cs[config.StoreKeyMock]
 	if file == "" {
 		return s, nil // Not a mock store configuration
@@ -85,7 +93,13 @@ func NewStoreConfig(d korrel8r.Domain, cfg any) (*Store, error) {
 }
 
 func (s *Store) Get(ctx context.Context, q korrel8r.Query, constraint *korrel8r.Constraint, r korrel8r.Appender) error {
-	time.Sleep(s.Delay) // Artificial delay.
+	if s.Delay > 0 { // Artificial delay.
+		select {
+		case <-time.After(s.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 	for _, f := range s.lookup {
 		result, err := f(q)
 		if err != nil {
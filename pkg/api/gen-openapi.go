@@ -19,6 +19,75 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// Defines values for GraphEventType.
+const (
+	GraphEventTypeError GraphEventType = "error"
+	GraphEventTypeGraph GraphEventType = "graph"
+	GraphEventTypeLine  GraphEventType = "line"
+	GraphEventTypeNode  GraphEventType = "node"
+	GraphEventTypeQuery GraphEventType = "query"
+)
+
+// Valid indicates whether the value is a known member of the GraphEventType enum.
+func (e GraphEventType) Valid() bool {
+	switch e {
+	case GraphEventTypeError:
+		return true
+	case GraphEventTypeGraph:
+		return true
+	case GraphEventTypeLine:
+		return true
+	case GraphEventTypeNode:
+		return true
+	case GraphEventTypeQuery:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for JobStatus.
+const (
+	JobStatusCanceled  JobStatus = "canceled"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusRunning   JobStatus = "running"
+)
+
+// Valid indicates whether the value is a known member of the JobStatus enum.
+func (e JobStatus) Valid() bool {
+	switch e {
+	case JobStatusCanceled:
+		return true
+	case JobStatusCompleted:
+		return true
+	case JobStatusFailed:
+		return true
+	case JobStatusRunning:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SavedSearchScope.
+const (
+	SavedSearchScopeGlobal  SavedSearchScope = "global"
+	SavedSearchScopeSession SavedSearchScope = "session"
+)
+
+// Valid indicates whether the value is a known member of the SavedSearchScope enum.
+func (e SavedSearchScope) Valid() bool {
+	switch e {
+	case SavedSearchScopeGlobal:
+		return true
+	case SavedSearchScopeSession:
+		return true
+	default:
+		return false
+	}
+}
+
 // Class Full name of a class of data, format is DOMAIN:CLASS. DOMAIN: name of a domain (e.g. k8s, log, metric, alert, trace, netflow). CLASS: name within the domain.
 type Class = string
 
@@ -42,6 +111,15 @@ type Constraint struct {
 	// Limit Limit total number of objects per query. Default: 100.
 	Limit *int `json:"limit,omitempty" jsonschema:"Limit total number of objects per query. Default: 100."`
 
+	// MaxBytes Budget for the total size in bytes of JSON objects retrieved in a correlation search. Default: no limit.
+	MaxBytes *int `json:"maxBytes,omitempty" jsonschema:"Budget for the total size in bytes of JSON objects retrieved in a correlation search. Default: no limit."`
+
+	// MaxObjects Budget for the total number of objects retrieved in a correlation search. Default: no limit.
+	MaxObjects *int `json:"maxObjects,omitempty" jsonschema:"Budget for the total number of objects retrieved in a correlation search. Default: no limit."`
+
+	// MaxQueries Budget for the total number of store queries in a correlation search. Default: no limit.
+	MaxQueries *int `json:"maxQueries,omitempty" jsonschema:"Budget for the total number of store queries in a correlation search. Default: no limit."`
+
 	// QueryLimit Limit total number of queries per class during traversal. Default: 10.
 	QueryLimit *int `json:"queryLimit,omitempty" jsonschema:"Limit total number of queries per class during traversal. Default: 10."`
 
@@ -49,6 +127,42 @@ type Constraint struct {
 	Start *time.Time `json:"start,omitempty" jsonschema:"Ignore objects with timestamps before this start time. Default: 1 hour before end."`
 }
 
+// Delta Changes in the results of a node compared to a previous graph.
+type Delta struct {
+	// Added Number of new objects, omitted if the previous graph has no results.
+	Added *int `json:"added,omitempty" jsonschema:"Number of new objects, omitted if the previous graph has no results."`
+
+	// AddedResult New objects, only if results are requested.
+	AddedResult []Object `json:"addedResult,omitempty"`
+
+	// PreviousCount Number of results for this class in the previous graph.
+	PreviousCount int `json:"previousCount" jsonschema:"Number of results for this class in the previous graph."`
+
+	// Removed Number of vanished objects, omitted if the previous graph has no results.
+	Removed *int `json:"removed,omitempty" jsonschema:"Number of vanished objects, omitted if the previous graph has no results."`
+
+	// RemovedResult Vanished objects, only if results are requested.
+	RemovedResult []Object `json:"removedResult,omitempty"`
+}
+
+// Diff Two correlation graphs to compare. Each graph is a previous result graph, or a search to run.
+type Diff struct {
+	// After Graph to compare with the reference graph, for example the graph now.
+	After DiffSource `json:"after" jsonschema:"Graph to compare with the reference graph, for example the graph now."`
+
+	// Before Reference graph, for example the graph from an hour ago.
+	Before DiffSource `json:"before" jsonschema:"Reference graph, for example the graph from an hour ago."`
+}
+
+// DiffSource A graph to compare. Set exactly one of 'graph' (a previous result graph) or 'search' (a search to run).
+type DiffSource struct {
+	// Graph Previous result graph.
+	Graph *Graph `json:"graph,omitempty" jsonschema:"Previous result graph."`
+
+	// Search Search to run to get the graph.
+	Search *Search `json:"search,omitempty" jsonschema:"Search to run to get the graph, set exactly one of 'goals' or 'neighbors'."`
+}
+
 // Domain Domain configuration information.
 type Domain struct {
 	// Description Brief description of the domain.
@@ -90,6 +204,9 @@ type Goals struct {
 	// Goals Goal classes in DOMAIN:CLASS format, e.g. log:application, alert:alert
 	Goals []Class `json:"goals" jsonschema:"Goal classes in DOMAIN:CLASS format, e.g. log:application, alert:alert."`
 
+	// ShortestPaths If greater than 0, only follow this number of lowest-cost rule paths to each goal class. Rules that can lead to many classes have a higher cost. Reduces the number of queries for a large rule set. Default: follow all paths that are no more than 1 step longer than the shortest path.
+	ShortestPaths int `json:"shortestPaths,omitempty" jsonschema:"If greater than 0, only follow this number of lowest-cost rule paths to each goal class. Reduces the number of queries. Default: follow all short paths."`
+
 	// Start Starting point for the search.
 	Start Start `json:"start" jsonschema:"Starting point for the search."`
 }
@@ -101,14 +218,92 @@ type Graph struct {
 
 	// Nodes List of graph nodes.
 	Nodes []Node `json:"nodes,omitempty" jsonschema:"List of graph nodes."`
+
+	// Usage Budget used by the search, present if the search constraint sets a budget.
+	Usage *Usage `json:"usage,omitempty" jsonschema:"Budget used by the search, present if the search constraint sets a budget."`
+}
+
+// GraphDiff Differences between two correlation graphs. Nodes are identified by class, edges by start and goal class.
+type GraphDiff struct {
+	// AddedEdges Edges that are only in the after graph.
+	AddedEdges []Edge `json:"addedEdges,omitempty" jsonschema:"Edges that are only in the after graph."`
+
+	// AddedNodes Nodes that are only in the after graph.
+	AddedNodes []Node `json:"addedNodes,omitempty" jsonschema:"Nodes that are only in the after graph."`
+
+	// ChangedNodes Nodes in both graphs with different counts for the node, its queries or its statuses.
+	ChangedNodes []NodeChange `json:"changedNodes,omitempty" jsonschema:"Nodes in both graphs with different counts for the node, its queries or its statuses."`
+
+	// RemovedEdges Edges that are only in the before graph.
+	RemovedEdges []Edge `json:"removedEdges,omitempty" jsonschema:"Edges that are only in the before graph."`
+
+	// RemovedNodes Nodes that are only in the before graph.
+	RemovedNodes []Node `json:"removedNodes,omitempty" jsonschema:"Nodes that are only in the before graph."`
+}
+
+// GraphEvent Incremental progress event from a streaming correlation search.
+type GraphEvent struct {
+	// Class Class name for the event, in DOMAIN:CLASS format.
+	Class Class `json:"class,omitempty"`
+
+	// Count Number of objects, for node and query events.
+	Count int `json:"count,omitempty"`
+
+	// Error Error message, for error events.
+	Error string `json:"error,omitempty"`
+
+	// Graph Final result graph, for graph events.
+	Graph *Graph `json:"graph,omitempty"`
+
+	// Query Query for the event.
+	Query Query `json:"query,omitempty"`
+
+	// Rule Name of the rule followed, for line events.
+	Rule string `json:"rule,omitempty"`
+
+	// Start Class name of the start of the line, for line events.
+	Start Class `json:"start,omitempty"`
+
+	// Type Type of event. node: new objects were added to the results for 'class', 'count' is the number of new objects. line: a rule was followed from 'start' to 'class', generating 'query'. query: 'query' was executed, 'count' is the number of objects returned. error: executing 'query' failed, or the search failed if there is no query. graph: the search is complete, 'graph' contains the result.
+	Type GraphEventType `json:"type"`
 }
 
+// GraphEventType Type of event. node: new objects were added to the results for 'class', 'count' is the number of new objects. line: a rule was followed from 'start' to 'class', generating 'query'. query: 'query' was executed, 'count' is the number of objects returned. error: executing 'query' failed, or the search failed if there is no query. graph: the search is complete, 'graph' contains the result.
+type GraphEventType string
+
 // Help Domain help documentation including query syntax and examples.
 type Help struct {
 	// Documentation Full documentation text for one or more domains.
 	Documentation string `json:"documentation"`
 }
 
+// Job Asynchronous correlation search job.
+type Job struct {
+	// Created Time the job was created.
+	Created time.Time `json:"created"`
+
+	// Error Error message, for failed jobs.
+	Error string `json:"error,omitempty"`
+
+	// Finished Time the job finished, if it is not running.
+	Finished *time.Time `json:"finished,omitempty"`
+
+	// Graph Partial graph for a running job, without result objects. Complete result graph for a completed job.
+	Graph *Graph `json:"graph,omitempty"`
+
+	// Id Job identifier, unique within the session.
+	Id string `json:"id"`
+
+	// Status Status of the job. running: the search is in progress. completed: the search is complete, 'graph' contains the result. failed: the search failed, 'error' contains the reason. canceled: the search was canceled or the session expired.
+	Status JobStatus `json:"status"`
+}
+
+// JobStatus Status of the job. running: the search is in progress. completed: the search is complete, 'graph' contains the result. failed: the search failed, 'error' contains the reason. canceled: the search was canceled or the session expired.
+type JobStatus string
+
+// Jobs List of asynchronous search jobs.
+type Jobs = []Job
+
 // Neighbors Parameters for a neighborhood correlation search. Finds all objects reachable from the start by following correlation rules up to the maximum depth.
 type Neighbors struct {
 	// Depth Maximum number of correlation steps to follow from the start. Depth 1 returns direct correlations only.
@@ -126,6 +321,12 @@ type Node struct {
 	// Count Number of results for this class, after de-duplication.
 	Count *int `json:"count,omitempty" jsonschema:"Number of results for this class, after de-duplication."`
 
+	// Delta Changes compared to the previous graph, only in refreshed graphs.
+	Delta *Delta `json:"delta,omitempty" jsonschema:"Changes compared to the previous graph, only in refreshed graphs."`
+
+	// Provenance Provenance of each result object, only if the explain option is set.
+	Provenance []Provenance `json:"provenance,omitempty" jsonschema:"Provenance of each result object, only if the explain option is set."`
+
 	// Queries Queries yielding results for this class.
 	Queries []QueryCount `json:"queries,omitempty" jsonschema:"Queries yielding results for this class."`
 
@@ -133,6 +334,24 @@ type Node struct {
 	Result []Object `json:"result,omitempty" jsonschema:"Serialized result contents, may be large."`
 }
 
+// NodeChange Changed counts for a class that is in both graphs.
+type NodeChange struct {
+	// After Number of results in the after graph.
+	After int `json:"after" jsonschema:"Number of results in the after graph."`
+
+	// Before Number of results in the before graph.
+	Before int `json:"before" jsonschema:"Number of results in the before graph."`
+
+	// Class Full class name.
+	Class string `json:"class" jsonschema:"Full class name in DOMAIN:CLASS format."`
+
+	// Queries Queries with different result counts, a count of 0 means the query is not in that graph.
+	Queries []QueryChange `json:"queries,omitempty" jsonschema:"Queries with different result counts, a count of 0 means the query is not in that graph."`
+
+	// Statuses Statuses with different counts, totaled over the queries of the node.
+	Statuses []StatusChange `json:"statuses,omitempty" jsonschema:"Statuses with different counts, totaled over the queries of the node."`
+}
+
 // Nodes List of result nodes.
 type Nodes = []Node
 
@@ -142,9 +361,45 @@ type Object = json.RawMessage
 // Objects List of data objects serialized as JSON.
 type Objects = []Object
 
+// Provenance How a result object was found: a chain of steps from a start object, through rules and queries, to the object.
+type Provenance struct {
+	// Object The result object.
+	Object Object `json:"object"`
+
+	// Steps Steps from the start object to the result object, the last step is the result object.
+	Steps []ProvenanceStep `json:"steps"`
+}
+
+// ProvenanceStep A step in a provenance chain.
+type ProvenanceStep struct {
+	// Class Class of the object found by this step.
+	Class Class `json:"class"`
+
+	// Object Object found by this step.
+	Object Object `json:"object"`
+
+	// Query Query that returned the object. Omitted for start objects that were not found by a query.
+	Query Query `json:"query,omitempty"`
+
+	// Rule Rule applied to the object of the previous step. Omitted for the first step.
+	Rule string `json:"rule,omitempty"`
+}
+
 // Query Query for data objects, format is DOMAIN:CLASS:SELECTOR. DOMAIN: name of a domain (e.g. k8s, log, metric, alert, trace, netflow). CLASS: name of a class in the domain (e.g. Pod, application, metric, alert, span, network). SELECTOR: domain-specific query string.
 type Query = string
 
+// QueryChange Changed result count for a query.
+type QueryChange struct {
+	// After Number of results in the after graph.
+	After int `json:"after" jsonschema:"Number of results in the after graph."`
+
+	// Before Number of results in the before graph.
+	Before int `json:"before" jsonschema:"Number of results in the before graph."`
+
+	// Query Query for correlation data.
+	Query Query `json:"query" jsonschema:"Query for correlation data in DOMAIN:CLASS:SELECTOR format."`
+}
+
 // QueryCount Query with number of results.
 type QueryCount struct {
 	// Count Number of results, omitted if the query was not executed.
@@ -157,6 +412,27 @@ type QueryCount struct {
 	Statuses []StatusCount `json:"statuses,omitempty"`
 }
 
+// Refresh Parameters to re-run the queries of a previous correlation graph.
+type Refresh struct {
+	// Constraint New constraint for the queries, usually with a new time window.
+	Constraint *Constraint `json:"constraint,omitempty" jsonschema:"New constraint for the queries, usually with a new time window."`
+
+	// Graph Previous result graph. Include results to get object-level changes.
+	Graph Graph `json:"graph" jsonschema:"Previous result graph. Include results to get object-level changes."`
+}
+
+// Reverse Parameters for a reverse correlation search. Finds objects of the start classes that lead to the goal objects.
+type Reverse struct {
+	// Candidates Queries for candidate start objects. Default: find candidates by searching backwards from the goal objects to the start classes.
+	Candidates []Query `json:"candidates,omitempty" jsonschema:"Queries for candidate start objects. Default: search backwards from the goal objects."`
+
+	// Goal Goal objects to explain, as queries or serialized objects.
+	Goal Start `json:"goal" jsonschema:"Goal objects to explain, as queries or serialized objects."`
+
+	// Starts Classes of start objects to look for, in DOMAIN:CLASS format.
+	Starts []Class `json:"starts" jsonschema:"Classes of start objects to look for, in DOMAIN:CLASS format."`
+}
+
 // Rule Rule is a correlation rule with a list of queries and results counts found during navigation.
 type Rule struct {
 	// Name Name is an optional descriptive name.
@@ -166,6 +442,60 @@ type Rule struct {
 	Queries []QueryCount `json:"queries,omitempty" jsonschema:"Queries generated while following this rule."`
 }
 
+// RuleDefinition Definition of a correlation rule, as loaded from the configuration.
+type RuleDefinition struct {
+	// Goal Classes of objects the rule can find.
+	Goal []Class `json:"goal" jsonschema:"Classes of objects the rule can find."`
+
+	// Name Name of the rule.
+	Name string `json:"name" jsonschema:"Name of the rule."`
+
+	// Start Classes of objects the rule can start from.
+	Start []Class `json:"start" jsonschema:"Classes of objects the rule can start from."`
+
+	// Template Template that generates a goal query from a start object, if the rule is template-based.
+	Template string `json:"template,omitempty" jsonschema:"Template that generates a goal query from a start object, if the rule is template-based."`
+}
+
+// RuleDefinitions List of correlation rules.
+type RuleDefinitions = []RuleDefinition
+
+// SavedSearch A named correlation search that can be saved and run again later.
+type SavedSearch struct {
+	// Description Optional description of the search.
+	Description string `json:"description,omitempty" jsonschema:"Optional description of the search."`
+
+	// Link Permalink URL path to run the search, set by the server.
+	Link string `json:"link,omitempty" jsonschema:"Permalink URL path to run the search, set by the server."`
+
+	// Name Name of the saved search, unique within its scope.
+	Name string `json:"name" jsonschema:"Name of the saved search, unique within its scope."`
+
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `json:"options,omitempty" jsonschema:"Options controlling the form of the returned graph."`
+
+	// Owner User that saved a global search, set by the server. Only the owner can replace or delete a global search.
+	Owner string `json:"owner,omitempty" jsonschema:"User that saved a global search, set by the server."`
+
+	// Scope Where the search is saved. session: visible only in the session that saved it, removed when the session expires. global: visible to all sessions. Default: session.
+	Scope SavedSearchScope `json:"scope,omitempty" jsonschema:"Where the search is saved: session (default) is visible only in this session, global is visible to all sessions."`
+
+	// Search Correlation search parameters.
+	Search Search `json:"search" jsonschema:"Correlation search parameters, set exactly one of goals or neighbors."`
+
+	// Updated Time the search was last saved, set by the server.
+	Updated *time.Time `json:"updated,omitempty" jsonschema:"Time the search was last saved, set by the server."`
+
+	// Window Duration of the search time window, e.g. 30m or 2h. Each run searches the window ending at the current time, replacing the start and end of the saved constraint. Default: the duration between the saved start and end if both are set, otherwise no start or end.
+	Window string `json:"window,omitempty" jsonschema:"Duration of the search time window, e.g. 30m or 2h. Each run searches the window ending now."`
+}
+
+// SavedSearchScope Where the search is saved. session: visible only in the session that saved it, removed when the session expires. global: visible to all sessions. Default: session.
+type SavedSearchScope string
+
+// SavedSearches List of saved searches.
+type SavedSearches = []SavedSearch
+
 // Search Correlation search parameters. Set exactly one of 'goals' (targeted search to specific classes) or 'neighbors' (open-ended exploration to a depth).
 type Search struct {
 	// Goals Parameters for a goal-directed correlation search.
@@ -190,6 +520,18 @@ type Start struct {
 	Queries []Query `json:"queries,omitempty" jsonschema:"Queries for starting objects in DOMAIN:CLASS:SELECTOR format."`
 }
 
+// StatusChange Changed count for a status.
+type StatusChange struct {
+	// After Number of instances in the after graph.
+	After int `json:"after" jsonschema:"Number of instances in the after graph."`
+
+	// Before Number of instances in the before graph.
+	Before int `json:"before" jsonschema:"Number of instances in the before graph."`
+
+	// Status Status for correlation data.
+	Status string `json:"status" jsonschema:"Status for correlation data."`
+}
+
 // StatusCount Status with number of instances found.
 type StatusCount struct {
 	// Count Number of instances found, omitted if none.
@@ -202,11 +544,29 @@ type StatusCount struct {
 // Store Store is a map string keys and values used to connect to a store.
 type Store map[string]string
 
+// Usage Resources used by a correlation search with a budget (maxQueries, maxObjects or maxBytes). Queries never exceed maxQueries. Objects and bytes are checked before each query, so the totals can exceed the budget by the results of the last queries.
+type Usage struct {
+	// Bytes Size in bytes of JSON objects retrieved, only counted if maxBytes is set.
+	Bytes int `json:"bytes"`
+
+	// Exhausted True if the budget was used up and some queries were not executed, the graph contains the partial results.
+	Exhausted bool `json:"exhausted"`
+
+	// Objects Number of objects retrieved.
+	Objects int `json:"objects"`
+
+	// Queries Number of store queries executed.
+	Queries int `json:"queries"`
+}
+
 // GraphOptions Options controlling the form of the returned graph.
 type GraphOptions struct {
 	// Errors If true include non-fatal error messages.
 	Errors *bool `json:"errors,omitempty" jsonschema:"If true include non-fatal error messages."`
 
+	// Explain If true include the provenance of each result object: the chain of start object, rules and queries that found it.
+	Explain *bool `json:"explain,omitempty" jsonschema:"If true include the provenance of each result object: the chain of start object, rules and queries that found it."`
+
 	// Results If true include full JSON results with each Query.
 	Results *bool `json:"results,omitempty" jsonschema:"If true include full JSON results with each Query."`
 
@@ -214,30 +574,72 @@ type GraphOptions struct {
 	Rules *bool `json:"rules,omitempty" jsonschema:"If true include rule names in graph edges."`
 }
 
+// FlushCacheParams defines parameters for FlushCache.
+type FlushCacheParams struct {
+	// Domain Only flush results for this domain. Flush all domains if omitted.
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
 // SetConfigParams defines parameters for SetConfig.
 type SetConfigParams struct {
 	// Verbose Verbose level for logging.
 	Verbose *int `form:"verbose,omitempty" json:"verbose,omitempty"`
 }
 
+// GraphDiffParams defines parameters for GraphDiff.
+type GraphDiffParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
+}
+
 // GraphGoalsParams defines parameters for GraphGoals.
 type GraphGoalsParams struct {
 	// Options Options controlling the form of the returned graph.
 	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
 }
 
+// GraphGoalsStreamParams defines parameters for GraphGoalsStream.
+type GraphGoalsStreamParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
+}
+
 // GraphNeighborsParams defines parameters for GraphNeighbors.
 type GraphNeighborsParams struct {
 	// Options Options controlling the form of the returned graph.
 	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
 }
 
+// GraphNeighborsStreamParams defines parameters for GraphNeighborsStream.
+type GraphNeighborsStreamParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
+}
+
 // GraphNeighboursParams defines parameters for GraphNeighbours.
 type GraphNeighboursParams struct {
 	// Options Options controlling the form of the returned graph.
 	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
 }
 
+// GraphRefreshParams defines parameters for GraphRefresh.
+type GraphRefreshParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
+}
+
+// GraphReverseParams defines parameters for GraphReverse.
+type GraphReverseParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
+}
+
+// CreateGoalsJobParams defines parameters for CreateGoalsJob.
+type CreateGoalsJobParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
+}
+
 // ObjectsParams defines parameters for Objects.
 type ObjectsParams struct {
 	// Query Query string.
@@ -247,105 +649,219 @@ type ObjectsParams struct {
 	Constraint *Constraint `form:"constraint,omitempty" json:"constraint,omitempty"`
 }
 
+// RunSearchParams defines parameters for RunSearch.
+type RunSearchParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
+}
+
 // SetConsoleJSONRequestBody defines body for SetConsole for application/json ContentType.
 type SetConsoleJSONRequestBody = Console
 
 // ShowInConsoleJSONRequestBody defines body for ShowInConsole for application/json ContentType.
 type ShowInConsoleJSONRequestBody = Console
 
+// GraphDiffJSONRequestBody defines body for GraphDiff for application/json ContentType.
+type GraphDiffJSONRequestBody = Diff
+
 // GraphGoalsJSONRequestBody defines body for GraphGoals for application/json ContentType.
 type GraphGoalsJSONRequestBody = Goals
 
+// GraphGoalsStreamJSONRequestBody defines body for GraphGoalsStream for application/json ContentType.
+type GraphGoalsStreamJSONRequestBody = Goals
+
 // GraphNeighborsJSONRequestBody defines body for GraphNeighbors for application/json ContentType.
 type GraphNeighborsJSONRequestBody = Neighbors
 
+// GraphNeighborsStreamJSONRequestBody defines body for GraphNeighborsStream for application/json ContentType.
+type GraphNeighborsStreamJSONRequestBody = Neighbors
+
 // GraphNeighboursJSONRequestBody defines body for GraphNeighbours for application/json ContentType.
 type GraphNeighboursJSONRequestBody = Neighbors
 
+// GraphRefreshJSONRequestBody defines body for GraphRefresh for application/json ContentType.
+type GraphRefreshJSONRequestBody = Refresh
+
+// GraphReverseJSONRequestBody defines body for GraphReverse for application/json ContentType.
+type GraphReverseJSONRequestBody = Reverse
+
+// CreateGoalsJobJSONRequestBody defines body for CreateGoalsJob for application/json ContentType.
+type CreateGoalsJobJSONRequestBody = Goals
+
 // ListGoalsJSONRequestBody defines body for ListGoals for application/json ContentType.
 type ListGoalsJSONRequestBody = Goals
 
+// SaveSearchJSONRequestBody defines body for SaveSearch for application/json ContentType.
+type SaveSearchJSONRequestBody = SavedSearch
+
 // Base64 encoded, compressed with deflate, json marshaled OpenAPI spec.
 // Stored as a slice of fixed-width chunks rather than one concatenated
 // const string: with thousands of chunks the chained `+` fold is several
 // times slower for the Go compiler than parsing a slice literal.
 var swaggerSpec = []string{
-	"7Dxrcxs3kn8FNXdVsuqGpORsXVL85shary9+aE3nPpzl2wJnmiRWIDABMKS4Lv33q25gHhyC9OgZX+Iv",
-	"iUXONLob/X7wS5LpZaEVKGeT8Zek4IYvwYGhv14ZXizeF05oRX/nYDMj6O9knIQvWKaVM1pKoebMLYDN",
-	"tFkyPaN/G3ClUZCzOYIaJmkC14XUOSRjZ0pIE4GQfivBbJI0UXwJyTjR4cQ0sdkClvyhji6MLsA4AUQM",
-	"GKNNhKzXM4aoMaEyWebAlFaDGXdcMnqDLcFaPgeLEN2mQISnWkvgKkmT64HmhRhkOoc5qAFcO8MHjs/p",
-	"nH9arSqKbnHMzU2aGLCldD2wnZVSsv+avH/HwitsLdyCAc8W7O/I5gdGu8d5hH8poQf2+BhDKbBMKH9x",
-	"DPKHZ/aBc24Q3XCUnv4TMpfcpIl1G4mfoIARQR40nXQmuY3Q9lfkDJ6BAslZhk/hP3PueEqSyh0Tlr18",
-	"//bF63fjszcvJpNh9VfrxVwvuVDsGQznQ3b1k02Z1POULcEZkaWMSzAuZc7wDFKmwM2kXh8PGcELcPBK",
-	"hCKt8NCGl4pUkS8LJOtTcvWTHV/oPEnpXy+hkHqzBOWGvChQEaWej3lRSJFxIi9N/Plj/78kTQiPMf0X",
-	"NdnjMVbg1tpcJZ/TpODOgUHOfPrf8ef/GNN/m2u1zgg1p1ud6wF+OLBXohh4Y8DloNBCOTDecNyknu0x",
-	"oXojrEPWeY77W55pU7NyuE26J3sCZiUySNKkIR6xFg6WkTPOatjsGXJXl666qMLATFwf71DWiBU3hm9u",
-	"Q6lWViOyXSwmjjuo7F1pwRxZL8wi4xKNI77GcmELyTdBgt4XoCYLMXNsDdPqmWMvENsG0gI32QL/xaV8",
-	"P0vGn74k/25gloyTfxs1bmMUVGE08c/ffE47aH5cAHNGl1MJdqG1Q2tdcAWyQs0Gg+0NCNEj0LgbA5Lk",
-	"jXlchrdS+gc8FjV+JWDdnxlk+/bwgsSkuh0Eexih3yqz3Z90Op2E3sXOS9Hqte3OeHL+5vzs4/sPwS7t",
-	"M4Moic5woVxEJarvPBX+Jfw3d2wtpGTT2v7meL7nbUVtxD+rPOIw5kqbBjj5GieWYB1fFpbxmQPjuQYq",
-	"p2+G7CXMeCndmCm97hi+5PnJ6Y+Dkx8Hz08/nv44/uH5+PlPw9Mf/nL6/IfT/0nSxHMjGSc5dzBAcFGD",
-	"1dsN3Rd7EkQpliLwn75KxqcnJ+mOEVwKx5zGgEKVyykYlKfq5AJMEKsG/unJiedOoA9t0BzMrQi826lE",
-	"FX3xJkJaP8rwdQH+DG/489JQWGj4CozlcuvQR6L0tlj4UMJx424t6lOY4dckLQShKy+nbKFLUz0HKvc0",
-	"P61I3wHLPabnJXnXXTb5z9G8zcS8NN5uC+XJFFrtGpat97vgfjYCZqz1WeVem9jhrgGLz266B74Lgd6h",
-	"M0hItIkFOxP6vCYf8trqN8DqGOag90ZAdw5SKEX5rRQGcoypiNTPe2/xQND2C3nfn0xA3zKu8jZ1ng+9",
-	"qQpSc/fY6zyfRy7tpTCQOcgpaWAhtPaezAdgKZsZvWQTL/KavdIYkPmIdVci55rL/qGFzzd2Q4tWTBrk",
-	"CeEypXO4XfRwANBO5NAKGPZmeROgq6Vv2UxLqdeQMy41Ze3o7fI59L7SD6W8s5zehgs9sb4hmEtEvXCb",
-	"Wm5qo/7Qd+qt6ENcagPp0K12NNvTlXqRjWn4uWdDVwboY18o8M+2rFzrZWO0ibxMFZGgX5lWjguFXpWr",
-	"7WLJniLPPoCttzr2tkO0hxKjFvU6IvEXdRktZJ7IrkFeWY1IisH+KlRuWcHdwnrb4a+nDqO1V8LKhkTy",
-	"tXkcl7bp2XPPKaPUsJPkh9qCz+n3Fwy2CwGfe2pxEPjHV+OHIb8TqfVMiOnxXaWmzykbRaJqh32XHPcr",
-	"oHbU18tIRUlUotF9RaSIamReBfE8ElG+J0fv6GA+P1Sm6VT5ekkPeeUnEJ4ojshTtJpfpYke6k3TO53/",
-	"DjQFHOMx999AFnsj7gXIguU6K5egXBV2Y3qP4kHZHLMb5fg1hXDBdESCny0Qe8qo28c4uPairhUwtOOY",
-	"OoR48e4BekdTttGKaco7EPPFNNpD2LH/Kjy70PqQ+edS1hbfAM8WfCrB61rjsaebEI4go9uwfKhSFugs",
-	"fN3nWizLJcuhcIuYx6AvdrF/G95r8totjB0U5I88Eh3sMLUr3IKdhv6LZd7rtUFYppXc3DsFfyI0/yCW",
-	"39/1IctPBmg3P9X5ngwnBGK2Llh6UbdCzSV4l7ur7dmBZklTsB/eqybRAXYwZcl0GStmvqtlqk2crw8j",
-	"4DQU63IY5GUdMgzvJ9B3PbQqn8XqG1QKFmDZRoAk0xyH3dtNUWn5jLj2BM6qN/ZNkzSWfxrBpfgX5O0c",
-	"AqlK2ZJv2BSY5OYWKej7WmcePwnti/qOwntN26foB4KXcNDTRS83aRI4igY2z4V/6KJlODzfOnEIdzx4",
-	"S2YbPnFLaeZuftnCJhkTm4cf+PqtTwKTGokDnMmbE+2eI59Afm5S39+J67pv+7Qx3ddsrps+j9R1brW9",
-	"t5rPAeiFzlO2lW91gNuCK4K91ubqeMgqdMcBzsAWkImZyKpokzxFiHNizeZ7t5xbtm8P76n+rbp2POIG",
-	"e7qdlOmlcA5yJnzRxpO65pYp7RhcQ1Y6yB/a8fQ+tu7c3L8z2ghvO4xDQb5r77MLp0/XkwIkV9p4rd1/",
-	"w2a6VDmroFYGoXZJdcO2Z+Edod7Lo3ZNv7+RmOmn4ukOYfgpWge+k054geZMBgtYNbgwpatcMclyxZTQ",
-	"71J8JeZ7+i8H+iCIg2IVkU0bZgUPEBb2O6FXMDUHBYajfqwXAjO0Oh8jAUDOfeMBVS8Kbnr3dSb1rEh3",
-	"KKCb6rJmxHDIJoDGhGdObnwyP2NHVKI6Ys8cxjWIYHjPaVYb/FDPO8bs/6jKrfElXSD5Koec0aBh6Ac6",
-	"jS4NM6Djg5XTfhbMF313Ldjtq763kt87gKdSVbtM0Y/AprLRg8ivlTbuR+NXoccrV5N4Tz2SNEdrmOy1",
-	"Y6UtuZSbSujA1sbPaTYH1xQTEGAda01LxzKu6lHDVphYOQpu2RqkZNyOhLIOeN4yrTH5rJPmh2gm6dkO",
-	"1kP2ISg5Wy9AsRJzeHYUvj1CggujVyJKzpC9bvkF0wz5pMihDVuW1lFhawrNjBUlbZfqMSzcXir31QGi",
-	"xN+buFBdaM9L9by95p3IFd57yOoWjLznWcgAvS+lmmx1tyK5VHUrlh0RQ0kKRQ7KidmmhRBD2RmyFxLj",
-	"fU6O3Gl2FO7syN/pRpeMSwM837AFx0cagrwYfmt5f3/2+AzrEHP6xTRoDGMqc5n4dGtMB40tSMicNpdJ",
-	"rT8fUeqFl5Klto5lernUiq35pvHaG8Yb8MSXfUPI4y+XFGjYgmdwmYwvq2mwyyT139CHy82g0PllctO7",
-	"5xgyj6cLsvax9C6jl+00YU9m0s0+0blwlVUJyx2y0A6ErbRQaQWRvLPOoPZiuS/FO9yDD0CjwSeNLu2t",
-	"HX3ZnaaKzFD5/GfJi1BHYFew8XnOissSLCst5CjOmVaKVEuTRGsDkVEGxB6uySTJlzqLMKMedPrVgmGv",
-	"SpFDkialkck4WThX2PFodBWeGc6FW5TTodD1RyMkQ6iZ9veoHPfls7BCc2E06X91yg7oADHTywZk9Y9d",
-	"DtXIVvcGlumpBbPiUyGF2zAr5orLOjHUpcl8v52zX8opGAWObFVpHRiKrwIfrU+iqT2Ti9kMDChXT389",
-	"k3puq8qQDaUhGwpPNm3Drk89/lqHzGk2LYXMMYSmJigVPiRlQ41TaGg2QARzZgGTF4dRkLUID0WZVl1K",
-	"vMSQK5dK/FYC+9vHjxfsRekW2oh/+eMXwHOk/mxrWDFbcDVHYqoRbRR1SImVJF8Vq6gnRUGI1R7bAkyF",
-	"i/cIYMMQpi4dJrqx85ldIJC6UxOsaw2IzLIUGSgLLZF6UfBsAez58ORWwjSaSj0d4W2O3rw+O383OScL",
-	"LBzNX9dM/nA++cheXLxO0mQFxnqxW51yWSz4KdnoCuCg+f5keHo6/E+Eh3kfL0QyTn4YngxPfQVwQWo3",
-	"8tODtN1WRqzdW52jd/J+DfLOJKkFh9bbhqKl1HO2AjPVVrjNMdMo36ZUNI1k/QqJ5x+aH4LwOvezZP7O",
-	"CbFmxe5TF5n/JtjAJKxAknhJPZ8LNadyQmRXziMDW7tyoe/rh6eXQvk/TnZNNcaXBmyhVah3PT85qewJ",
-	"hJi1KdCO0MfRYkh90sEZDRoEI0vYWd77xdfZyuWSmw0GmqQA+xjPHXKYxobRzpK//ZTUI6HJZwQ2ypod",
-	"mTlEbvlDaPNijJKVhozMlr7hgWg4OJsavbY0wC3wqZXg7OLXj6w6YsjOFZ+iHeFzJBjNSS5spldoAjA4",
-	"rrZx0KVIra9oVM3F5OIVyQUh/og3UR2x5y7S5C8nf3m4a6dZuchRSncYzldcSORkRxxegYtfUef+iabP",
-	"N2lcrSeV5WxfsUabSNdGOTomBEz4K357dsGc1hJT+3/UV/1xAfRNkAVK9+oEwvJl4wqexUztMcbr+CiZ",
-	"Nw9kv32o5YDs+M863zyFCHRKLiS8Rc6DKWxvkA2TdkgWeiJPbz/OOnttFba2zDKwdlZKufFSffL4Ui3U",
-	"ikuRt6qaHWl+y69gn+BTDBkEMirc6PeqfCOIxWCq880gWP/wWdI2gSNYVdvcUUv4K7HLBxLOiPkcDORo",
-	"+TwfmanCCArJarWwC73+h1BPrhrhts89UV+VNwfXznNgYJ0BvnwQHZlMzpkHx9YLMOCDPjrmqApgBci8",
-	"mYnhlKYPQOH15ayS2TD7fKk6UoIHELjqGJpmisl5uJeDYrPPJl6UdkEFzxjgamqsi4q/6fCMsCwHKVYk",
-	"M+GFjr8MwSrkZFZfnTeeM0hm3IHS9BV554Cdd6uEYF33p1QxZj8Xev1a/W4m9GwvQy0t8m3x6Ruxot4M",
-	"IILu27GcTx+K1NLaVUi8OL5HRSobeG+T7dPc0Rf//5tR1uy0H4xim4Zsd8mdjG/oWORb2/7bGvNGWOcH",
-	"eas9+q9kJrt7asgNQqOarp9ps7Vce/WTrbIWzMaapMW/v6MH7RymWwx6zEyl4sCB+PiPrQ/hPpV2vs4X",
-	"Ccjx2rdkLvKjCpUuhPGDloTfRqKjG4C+1xZbdSRP3EfO7WOmWNURf1oRqocGe4pQu/7bmtyPSBCV6Oyo",
-	"7tEX2sbSvabJ4Pf2ttanUgw2qzaeNpEupi+38daS1ZA1sunLhO3NMynDrpYEnrcWYtrH+hIxQWzivp0S",
-	"AIJ+FdZyOhY4dhPNI6Otn2fyFvLhw54w7BCJiX27r7Wr1t1Re9JAx+8tfde/uP6dUQ27M+zgpfrAsmE1",
-	"krAV54QGwC0jnaDFW8Moj6vJNO5Tx0RbcyTVlEdXwfWspdhUzfdAtGESrN/47Wj8FnJ79bsZqPkmdbw1",
-	"79NHz5Gn9UV+1/JvUMu3pN3LNjc0nxn1UGIFKmyJPbiql11dLwxk3DUx/59G+8vv6v9d/f/46r8IS8MH",
-	"073Z7lYvZZNSxlKDtLVZ3Cp4pFtrxun2nnFEGWmd+RGlk+D3aXhiNkTr03xKfXrixcEkCJ+uy0R35m7d",
-	"8Sc4D8jVl1VB5w4lpHnFjP+v5aND1/7tFHDa8tYqqB8s3mC2/jtn3lXBoL0Z3/5RojD9uOC+ozsFvzcP",
-	"eSwh31cgqtLvP3P+7JdCv7vWr7jWSh7rX8Sy9ehYGAjcGnF9AH/aGqGOGv1zv4zXWPe6gEpD0JujMJOH",
-	"GrVVg4tsJvg5UfZSg1/0K8DQT2pztdnyy9wPo8X0qVqi/Yov+Ht7ZXPPbFP1534z32Pa9ya97w+Vtobn",
-	"e/x4eWvkP71FZ7Ga+O/+1PRjOq7qqr4rfVzpG82iO07r3wppzEDrB83isRsCBLOqlMDPTY54IUb1cCPe",
-	"cXhvz2wiqLlQ3QG52EDicEsOw4DcrgZ45fM9d7m7MD7squAuhFdhfXC3nmi3cKhM3i6En43I58Cm4NYA",
-	"inH26tfXdVf22YfzycdjHxgo9uJ1mNl69vbs4nhb1agj//nm/wIAAP//",
+	"7T1pc9tGln8Fxd0qWTUUZTlTO1lW7QdH1nicsS3HUma2NvZuQLJFIgYBBodkxqX/vu/qA0CDBClS1iT6",
+	"MBMLBPp4/frdx5feOJ0v0kQlRd4bfuktwiycq0Jl9NfLLFzMzhdFlCb090Tl4yyiv3vDnvwQjNOkyNI4",
+	"jpJpUMxUcJVm8yC9on9nqiizRE2CKQ416PV76vMiTieqNyyyUvV7EY70a6myJfyWwNzwZyoz9nv5eKbm",
+	"4a6mXmTpQmVFpGgzKsvSzLOtV/A5LC2IknFcTlSQpMnRVViEcUBfBHOV5+FU5ThisVzggkdpGqswgQef",
+	"j9JwER2NYYdTlRypz0UWHhXhlOb5JYc1y442mOb2lqEWIqzWrRZ3Dvu8VkmYjBXCQoXjGQAjL+MiSEe/",
+	"qHExpLfGMxgQX8iLMNM/9YOsjFUehMkkwFMBWMHLYQGQLeFRVAw+JLvd994XTPDj4Tqc9lUZx8H3F+dv",
+	"ZQV5cBMVM17TD4imOz72DvPR+nGT61ePrwV4i3J4xIgfqMnukXXFPLe4XJmKj6gHD/JiGeMTvKD49/fp",
+	"6NWL5n7gcRBNgBhFV5HK7BW+mamEcOAXeOEmhIufqbBQE9wWUZBFWMwsAYG3enjmv5ZRpiaa1FhiIsvL",
+	"iwwIB8H3QoXZePaWPq8vCp8i2oVBHl7DYnJ6t2Vq+s8mc9/qHwnqp3GYe875r4gliVnHGN/Cf06AYPSJ",
+	"6gHGR3nw4vzN81dvh6evn19cDPRfzoeTdI536IkaTAfBp2/zfhCn0z7QGljNuB+EMZDHPhx1OFb9IFHF",
+	"VZzeHA4CGk/GQfSM+DB4NKYI6nM4X+AR/9SDcYfv0gk8xH+9UEDvl3M400G4WCBRhymH8M84Goe0vX6P",
+	"5x/yf+BvWseQ/h8hy+sYwn9v0uxT72MfYQ48CiHz0/8OP/5pSP9vUVyAixg+TY/w4VH+KVocMWMJ46NF",
+	"GiUwAJ8OnACB3XfBXkd5gaBjiDPGA7gNKAfVrfO2L1R2HY0RDezmcdVRoeaeOU7N2METhG5aFvqgFpm6",
+	"ij4fNnZmr1iYZeFyk53CrU5jD5ZfFHChNO8sc5Ud5Hyx4ZhiZLT4WTCJcmBES8Gg84VKLmbRVRHcqJF+",
+	"55ARosps+c7gv8I4Pr8CWH3p/TtsDib+t2MrghzLVTjm+9i7BahVl3kJqwOWX46AHs7StEDOvwgTFeul",
+	"5cL8mZjSfiIUFLJMxYRvzv3tTgB3OC3e+OtI3XQHBvGBFlgQmujTwWFXL+hXzcK6b51mJ6QvfPP1kQO4",
+	"dGd4cfb67PTy/L3QpTaWgJgIkwJ6eq6E/o13wR8JY7+JgBaODC+a4PwMW71bj6yXTDzMc5qkmR2c+G4R",
+	"wQ0v4D6DRHEF94ahBp/TL0BS1VUIUwAtTG9qhK/37OnJX46e/uXo2cnlyV+G3zwbPvt2cPLNn0+efXPy",
+	"P/AiQwPeA6KtjnA4L8HqzJLvunpCxDiaRwJ/+qk3PHn6tN8ggvBSUKQonCblfAQDAz7pmQHMglZ2fBik",
+	"IigiDZoCFdpkg9vNSruah5+/WxY+ev5dCVJKYbCZR8+j3xCdghF+g5OQPKZnypArqWtGtNB3pV24BgTR",
+	"O2/+vtapwXXOg3QEWPM4Hg6QdrQ2DZgfWKfYGDB5gbdTayQPByhbr4sAQjfutYdmdCMZelq8vCxRTcqM",
+	"dPcMJOssD+PKbd4TCdl0FbRz0jk35iEjdYU/ExlmrbVGiE8CEPcy/R5Qat7z/fKKLVbZwtNfqLjwmGtO",
+	"Z2EyZXSrCSUhIBjokyj0hKAuwVnBI5B6r6O0zNssN+Fkojz8/K054QTEINlsP0gBBwq8/Fdia3AHD2ag",
+	"TAKOO5LDHfBtJytAwNIO39MTzz4rYyfxEgfWMAUoBqh8wtmKeqyVjlUS5rk5wS3VCr2n07T0yXMWMHqd",
+	"TJ9QRKYbKJjRPPmdnMZmk7K9aJ5er0ay6zCJ8hkc69fFtLsuw9ltG8L9oznFQ8C6W9fM8lMNBT/6qFN0",
+	"ddXc3eVNWmGBBKYcKZFQpUFwhtY4Bh8gj0OgxEpJPwFU0C4gqgh8npWJTxUmyby78oeLvgDqO1YeDZAM",
+	"9M5ShbQTjb1SmUKLqiwOcV90FXqBt0OKwCaIt5sZ8eyYm+wIEO+7TX6VpfMgTJidhdN0s71vPcltHVdl",
+	"731BhjZkle02UPa5zOTi6AUIfbCWcQE3EyCHpOGA3joInrRg7CFi7AEjLL1VwV2vHYe+635mhC2e43rn",
+	"W85mh9EyBMlquzI2XbjwwP+gZG0OerP1rh6rD7D3nF8axvkBnVKioulslGb5QavsRSbDJrLwc7TZXEXT",
+	"MmMiFyUsYsK/m/JV5fuG3gOy81XgPNM2Q2sQ3dYKm6w0v6+agwR0uFG5z66Jz832gYtp1cgO1olb0UC7",
+	"Yla01Y+tp7jCEv13YlXfZrJ89nw5u2M4dN6VYM32kt8ZqJsepIONjlEIQa9QVe43xBPJ5AWrG2nwMkUr",
+	"M5vhmxiJF6H7fWYnSvM6O4Z2wScclzSQze7yioEa5lDHCtrqxkPajWIqeTKv0jhObwB0YZySWxtNeADF",
+	"zkf6HobZ9kA3o2idVn1LY85x6YtiafDGKNS7PlPWYHdxqHakVadau9m8rz6jrO+GnzEY6jhAj12LnkPl",
+	"nI8xNsDzMYUMyP3CoAi402jRACmkEk3QEgXRNqDzVdNl6m6aR/HtFu+1B+PfmTgTcachuI4mmmr4TFJ/",
+	"jZJJHqC3NWfa4YYBkLw+dWmIT3jxr8UlPS3n3A/I31XzXIrDlB2V7V7QqnfzY8dbLAi//2u8m+2L5DVL",
+	"swKUwHd4St5whSk57pEBA24+FU2SiQcTDWunQ3qSF7AFYHwUcMBnDwdN0RH2tAfBe6JB5B4aw7ixCsmU",
+	"NA+TpdnaLLxWgGgzkKPQ8AejwndqUo4VO5maBkLGzDjMphLxADKaYxWTVQP90ivD+VEnAv16zhY1WMwJ",
+	"IKpaBEgW9b6JuAio6FvG1jlc2nk57w2fem0DOz73/R3GKqD64UfA4CFr5taOEjy97hHg8Tn5ahFKRvLb",
+	"xgO8ZqgGH2Bio3fiJY1al/Lp9EzLcT5WJ1s82DVijiE47aJjLR6oExki8e4eqJB3jQhTZL9r90Qvdd7T",
+	"W4w/vPc9yRpxTyVy1e7Y/SO93sRucfWUOXDM0dJBxz7q+zmMoW2AotiPjcsdSRlaskY0xGAbD9MupvVr",
+	"snQD/MY6fMo2GHRbFDcK48K8BrxBgMfMpkkTUkYLJjLVZxzDv1mOQDXKoWI+wx0a5c/8V4weWwbA9lEm",
+	"9OyJNxaDB3Xrui7b+CTe+m8jg3pn27+vC9p12bj9MfmwVgMAffNpMdMmZDKNTgRjUS4vE+OEUFqvKHIj",
+	"b8AP+CfgY1HmG9IzdrHdH9B2vVXHDbH5FRPH5L/cHauu2wHB5tdsKxA8gHtWB0ErPzi7Vj7P4qtkDDCD",
+	"n4ByA7kGiRaUeIXvasEJWI8K5yhJeUQoD5kf6xDcnRkm9DWgZbWbErrb3MbrvKzGUYdTk4ddx8cveRVt",
+	"/sdu83cxGoh3hB41ptzYNLwjv8NfIxi8bodMMy11yjJ1oMvdIzSrsZM0/ibnjIrWaoM4qWLa9sZ7iaNE",
+	"7QDie7bPyR+4WO+yuy6Tt9fw6cJTSiUhiNMNGLoxGcEN8KmAJBpUYd2gFFzLAdGAgz78A2/aAXp9q9qs",
+	"M9aAlj4ESkOHgdkJxhhKJOiAdnyAE5mBgWAqdMUAVTogXDsY8PUc6r9pIPVZjcsCT7Z1JU6UG+VKDPjO",
+	"DeVbZ4bgKoxiHKuiuspTEdlRWKb4AImtpIsxdF+nqGY0chVwcNrDKCZHN+ZYomMTtGf8RFochv4DpHp9",
+	"k+/FdETf7o/rrIz0q0+T/puKF62Orxn8GEzScUlcQrxfGDqMoGGSmC/hl89EI8WC5/FBVIZoSdGoTlMA",
+	"SyR8In9exjYhcdtsfzVrQKkuywed79ORx4UMex7PsjRBF2qTMWKijZc5ct6N58ZFc9WWodMhmm0jpiIY",
+	"CzPdicJdRRzWsmYz+rU+XpGo4PuBRrAETezd97cr1zmaoYCJSawBmShlLbjcfqBTSCoZdEClTuXWVtif",
+	"DKBv9ESfO6w3mqzLz+oHZRLBDXJzgkCYz8Wp7HHRorTvTz0pc80ScAV6R3XKQykxLOQN7KK3o0+CRsMm",
+	"LYTvCBkb34Ug1A7QwjxW9S8J5eUHS2AJFkBTFnhbqxRRdtjr98w+EJVoAfhQxlpPFaNJz4C2by5oCyFY",
+	"YUkLXYpgqUB3NRTpzPZ+5Lc6wKGDs0gHQ8zSdJWvCC3Llj2GoMGPUFpCnmwFkZG2eNc1BPZrlgstIMzD",
+	"z2idDyZqUXgVB/qhufo38p1l2ZUVF2pBZnSxhldXh9ZyGDQ4EfaeB+wic4fISZ+6c6z0PS3zd2Ld57Ne",
+	"Zd0n3dqrwfvDIQypcQVR0F5hUYC0bJRs11U9sohNWRzcKXi8NtjK+IbxduG/fTG3TdTRpDT+xT2H/7ZN",
+	"ivuY6Dj2jqGJ9LpH+ZG4dze8vRmS2zcGEZgC1jrTNQvyDQMY7jzbLUVy61x8DyFek6dvA4NJ3eWaBQGT",
+	"fGTMObsaOnETO9d9WKh2sjOdIePNF5JEomAZqZhUDz9WdoYPmRY41vke4NN59bbYgi/MKQPJNfoNMM4J",
+	"VcFd9YG7LjGpk5zt9xRAvlmsU9elN1gF0+g2FiF2+5aUmYlrSdd5/2RHjeom+EF7oPk6Yuz31OyU9LZ5",
+	"VWwQeMdF1u3c+1hl0yz/0PjsWipT88cYnC0JY0P+F279KajWoSg4bAwR/ZZgERYbehSYKt2bL2pv2701",
+	"6qpqVVhVi9urzxmHqAdeq8xMFSmj4urgxI5xxzjZ/cF0N5trIYP9LqkPb1eHfMjp3l/MB3wmnIajACJ+",
+	"6Z1DbRnINftjWIQiPoB8YPhHmFOUZzO801kN1rBBW8P78OYNW756ZhErIDOxM+YtU95POuC7FULk3zDm",
+	"qypdidG8TCZoRnfqPKHSadx4btGnYpal5XTWLP7U11KvBNF69PTUnmUn6V5Dw1t/o7KPAVu5YNU+mmE2",
+	"43hBePsVF4SzRxQq4GQpfjDKm+9sIUzjMnaVxGDwlrfsu8q1iT2ZTLy5hLLqjAxOKDDYr2tWyJUcAZcL",
+	"o3AmyoJWCzrMneHK+cppdupuJD5mSmc5dyE4l8xQlCZr0dNUWUVR7KqzytAtGHMXnyUG6AYUO2z1UoF8",
+	"WktTJaBUlkqV/aJMbsLOPCeaI8kZ+9D3B30ubW5dl+K2FeIyBXH2VJHLKQlWKcwlg75LJ/2gErZdGzxf",
+	"hAmNjdW1YGy93KGMc5Qv1Di6isbaW0ZQF8rqK8R153JcrhTZqhm5Qp7oRwZZHxWhPSlCO4+LcI3MeJm2",
+	"rU1VH6djVSqXHmivdAcJ1TG+tBAHEp6TOmA9LK2jxbSR1s938SZk9UUHCuwaEzpP+7tDjk76HzNKPapm",
+	"psYmZujRJoreXUx6fpT2YfB7Nv6udLZhrrI6onTlqprn5JM3Aq+9UQOVUm8dhTf7TRNFsASKE1WuhQSj",
+	"A5R5CbPILQwpWgfd8vB3Mtm04sFd57rdoe/fm/MevNJVWYV6S3I5H/ZRrK5VHHD4cr6LHPvN5msmxtSC",
+	"fVycxPJLqoMDOOM3V/h+9V2sBJ3pVDCSdnWSmEnf1ZESPgQGDTPC6I4VJjciNPq9qnjtJj1FmK5thqP8",
+	"A1o4GtVH4fjTTZhNHD3RXZlebWUzvNzuxrn7NMt1A4nEGqzZvFykjRLB2xzHL2tAFX9OHw0lToS6Y0Ex",
+	"i9g4s3K7aYyXvK1eLBPiRgpsnKafEOytUcabFet9cMmqd9q7P0mvZyDtpUnt6iyVAqpHjGg2EItJTp8z",
+	"mqg0vTQ+HRQepPZcEl5H05Z6HCvqYuAatB8SkM28ca124JHoNkMnf4TEu1Ip78iELEe6UgBC7oF7Pjvt",
+	"4LZznQ/EIKCAURL5w0ntb6Lf1/CMiEichhMdZUwV8t0aL+1VNFrJibUGSWQ5JlMjx+p8Nl+DEKxY9W2n",
+	"qjIa++54UyqjragZuW7pTNjwUP/1wO6unXJ5FDAauDWeGFv5RdxucrtyKQUheqbX9h85qQ9oFZeBjkZh",
+	"XlGAtzjHvS3KTxj666uFVOnEqgr19dDFjerFOKRoe6/PBbZHuDDFt+qWftyyL1zT1m0YKWmxQNwS9M5w",
+	"inZMBGTmj7hcUajqvMGybK0qGyK4lYlyE5TqsgyuxJ188mg+CiQX/Cn48f1rKpBgKog5addYO8xkYmeg",
+	"Gg2oBUU4OU/ipV7y3ne69Vq7UWm3+UY98pxySceAGrsj4x2nw7Wnu+2QtMorXOnItDkabrYU2ttN4rOd",
+	"/5hz2ZBCX9hgGqcjrFHeeswBIiM7fnBMuvAZ6BvhmJJjJoqSE2oDfRVE3mJzzO8RJZqw+ielVVVTFGjg",
+	"gc4QGAbXUR5hcLqbGqvTB5yVRNjviFOEbU+capoBaNa8WjsoFlXGIiv8XlX15myNSmaCPESuRAM1UxD2",
+	"APNWIJlFBk+k3vgh/tYEGEVh0pt9fV7Oe3UY7LZQ5GmTqdlOahtG8a4aylsmkirN4BUyNSKlyshisiZX",
+	"y8ld4UADBHgLO/EmOa2/mp0lr83XhHtkI6tHfdKFLiuc1jXMSk2rb57OEXTPZlLlF9kVvywVjPhtLHpO",
+	"BdW4Zue4zCgmCsfrCxXTFNWWE8HGGxVuYs3Izh0kh61erqlpYjlQZTgQMynmE9PoAST9IMWkzZsop6JT",
+	"IpJmtpC87U5yMrsPaWdfcJeqwS1CNF9Ln/jsiKSrQspcZr+B5OwKvHcQm1sk5tVExV/wlwvGPikwFrkw",
+	"W0LqZ5z4Yjc+rJWVDZ4A84KzTdCiQE0a5SSpJD/luxyuLKrX0bVBr3vTGjcsCLiZJ2Pz4UkqdZPSum3Q",
+	"5rF12OS6RLa77XHt6P5yGxd+s4UnRcrfO+RVYXxignTK2rudwse5HtDEz4xK1gN1m8GmZRytXjcKGDlA",
+	"OgJiij4cp7jcvst5aLOzs2qsd8cUiSWyMqeUe/mVsv4xsC3ybgeA5ZiIM9vUiqL/lsG8BPoUcr8r01PM",
+	"1qfaj3nHt8s2k7p383fenOSS7dhpfPemYptIcXeb69bEHuYtN3FVnK8+lVwXnEAslLztpRt7h7gzCJ7H",
+	"GMMVkk0fi1TImR3wmS7TEk4JJb0l19B0NrSB8/Eec3W6g0cSalYAp5t7wwRU1q7Mhx6H0A1pomEOOu64",
+	"SLMPPXN/LhHrJbZ3jsU1AXRzIKY34dJy7aU2Nxry2+bHG375QFJRvgC1+kNv+EE3afrQ6/Mv9HC+BPBO",
+	"PvRuO3v4vorveB0V6tpqsJJCsTrVSqfd0hdbhBIiUwqpPOEegwlXT9ItnLAxxl4CCtfMcru+KkRbHNj2",
+	"qubKgX31vLmyQoewQDeCq20/tcBACyByB28RIFgboRKxl8At9pzlrqDeAis/aHSzF2/+zJdmtRBPGwd2",
+	"uc/DhcQgB5/Ukl3r12FcYrWGnMPLQXZIJLEi5I4I3mrqP+o6rPVmMjk1XslNjVOfoKs9/VzLNHhimwVi",
+	"SqjuqEh1f6Qd5eEg0NQtwQAmULPGCiawXw4C/VlIAfiFSE6AuuNPuBTpwIYaMzmj+kGe2j5/VIJEj0q3",
+	"jdcmhpNKM1jJLVkhOY/8LTQvunWhlIxpQl9GRg0GJxO8iZfq8ywsc7/ZijqQX7kbQysRnVG5IIjl6dwG",
+	"Kpp0BltFyzbpqVR2WUhNnWZvLt0sfZU49ra946R/j63ixNuWNo0ront9EZ84tl1vXw7ShW3zht4S7EkO",
+	"jF+kY8/iTOMRMo+/LCOq6FVmMfw2K4pFPjw+/iTvDKZwN8rRIErNo2OqKZRcpRIQWoScVyN904EYkNCl",
+	"Z2kMLSOCaGKH1P9okguzWH1vEVVHaDkMR1EcFaCaRtMEb4wE5siFp0Skv5dwCokqSEBEgGWk1ApRyTnY",
+	"l7zANjtSd2N5EqfTXKdY5JJjkUsGB/zXGdvMeriuCA2QsVEZxeSJIOylAO2YolGsJG73TDWAcmpGhhaj",
+	"wvoIqCYjUg/s8K0pmDi3/nZ5+S54XhazNIt+4+lnIPXj7k8rzYMktrNv+kAj3ceIlETaz2hQkceJND94",
+	"jVaLPTe104HEcJVLQ0osWQXEyzd/kM9wEFMMRUTaqvcijsYq4eBRQannIAPD5X42eLoRMh2P4nR0jKd5",
+	"/PrV6dnbizMSe6OCzKgGyO/PLi6D5+9ewdgYi8pod30SxotZeELyiB7wyP7+dHByMvgPoiYLlYC8As++",
+	"GcBTTqXhngnHY1w3X0D0ifkYFLqAAnpv4tCKZa0sg7IekTx1MCzTgP9VEsaUtA1ihzuejO3rx2ScpwLq",
+	"C8sexbby9880xCn+fHn5+mdkd9VH+c9BUVJ1MqD5KMwLr0FOQ4f8aoLJ73DPZvQJQUIbskjhr/lUqUcB",
+	"vt6sQSEdnQIajUwL+lrCwkUsIuMuDqTTPQRb+E20Jouc2BBJ0KIAU4JmJEkBz54+1cRMatM6aVbHKHPi",
+	"MzveymrE1BXm9rZByc7/jujy56d/3t1UVGjPM5WkjZkMQBbPy/k8xLQOPiONCRV0I75EovZPPdOKqvcR",
+	"Pz/mv3FNi9Ijx75JJ6jfsmasJrU2ZRplJJUNaGsAl2mU5kDBDzHvwta+Q9oOJMCHXBeqYAK2Drf+QWOD",
+	"ZEQh7FSVNJ1OpcqfD2l4MaqCNVInjLsir2jp8RXxqXKsrP62AT6kSofUD3j1ISMjIH+AKnwEi8uCub6z",
+	"CvPQ/j0Qp7P0JqfOzBG+dR2FwbsfLwM9xSA4IwKEpAo3jLxxEuVjqkZwMxP/HHG3KKeQYHbb+fDiJeEF",
+	"LXyPJ6Gn+Lp3O0lrAA+vwyhGSNbQAYDiP6La+dOePmLBqLJo6bpXP+IUGTwdmwR/hBjRQEf85vQdnGQa",
+	"o3Pg/8xRY4I9/iK4QAZjY4LMMVjHxAb45IZDtPjhq8SreZB2+mDwgHjjd+lkeR8oUHPaEPKy395AD9B7",
+	"EYfLQc+V9HW29b3Tj9Pqqsxq83IMomx+VcbxkrH66f6xOkpA8Y8mjl+0hs1vwk+qDfHJOiAI6UVuFOK0",
+	"PUnQ4mgEeHEk1F+e9VwSeMwlrFsp4Y8LzvtByQrkiilwAhamGI5WNCP9wlyLfJbe/F+U3PvVkNM+402t",
+	"xTeseMwQOOKy+zu5IxcXZ1LFHz0S2v5B0xxobQzrklmlPiSjxJFK8PgmgcZZWxOkiiU4AfcMkGkontaH",
+	"57np7tuONm008R0JpS0D69yu+lL4pOUdFHFVHF0rp7pfjV+K5gW/I1l9eWY5p2Cmn4FStU5JIaDVMVul",
+	"BZrIATIC+ugnIOer5KuR0NNWgOYYMlOF0wOhokwGAmqW9GAo5/2LIgZb6xcSDy5suSKaBt6ZZLO+c/yF",
+	"/3t7LJExa6VYm91ly7U5KrfEPEy0Ouq5MRh3xOXpJaFinWbSbIJMGW+4DJ3UekWl9G3A16dvc621oGnB",
+	"o+lW78HX0nw1BFbIx7/v+7BG7X4p4TIVnDPdKZ2O2HIXJOffwfBNMNrbXtoxENX6aBMn7oLn+T5VLD3F",
+	"HxaFTEm8jijkevacfhQeDOLioscT6ca3SHNfRhtXHEY8OmAX1QHhDYhJB+QtPXCb7QiJCj704PdZWgIa",
+	"T9MPPfriA3CHmw896lHyoSfW9+C5+VU/+e5DTwJH2SBOjsGFr1wBDRU6gZBZmVTX4UjM1q9nLftO7CoF",
+	"e8mNod4x7DzgePyE2w0m3PM8Z3O4mMq191syf7GBDL7dr6QHm2Zod+pcaMeEF5oFm2oWENNpscF/fHho",
+	"XzmuJKIwf9i90Ecr86D7S246R7nzOmQ41XWv71fCsxB8JD5+4iPEoa1NZ0WKE1/dhnKc0CgTiewnUhc2",
+	"lIob11cKCKwvysAXOqy1Otb8s+JVJpOjaQuNhUacRr71yo+hW+ai9ZK+lHbCD/CWSki3R2+Xpiq2WXu9",
+	"SfsdryoBfR7/6fM8ri7XQy7evA7+G/6n2yX5g1nuSAcaY1wnk8F4WaT5OFyoPzVHrLFR/ebglzxQMbU1",
+	"bCsWy8nVnwuaguBwHf22FgjwTvDi/LIR5OWAwAw6x3RObgLUNuYbfiXAooTA6TIvVD1UkY0ahnObvEMJ",
+	"0MR0y1jlbKB6DsrxotCeYGMeCxPKfJHAv2HQgEXwxN3xYT+obyx40lj+YaVQooteMtqb15Q2sfqIgyfe",
+	"gzwcPDKD3imFJtTiqaQTlSUTTlEXk3cfqZ3zimNro2xhGSgVWiAifr47v7gMKqNw2oJufAMirWOupPC8",
+	"tgaluVQSqSY96r5iLN1uYOS0XVL5elF4F5sSyVFqzUx6thnagLFTom6TRRKrGxg19vUMo7j+WtOs3ClX",
+	"zHPawaWXFg6epLbd4SUplQQmqcEeR/OosKEFcm4kgKcl1lig0qqmy5aEgonhk5yAHKAjYzurk9ZtAFv0",
+	"CYJETvm9LJP//Es6uuQp6qEKfRNqcvJ0vpo1XzAqPTLou/gAnEa/+3AD1G+ItbUnUunIqILiHbFfcDYA",
+	"d8Z7GK4txrgOqWy4Gc6ZPSJ7s9MbdjfEtJIlt1/hm/IQbV9xN8FNp5/VZXIsn2RkcdbtaRCM7iBaTNp0",
+	"RUivVqpru/c20+9B3nonEbHLzb+qJpA/CuaPgvmjYP4HFMwrNJUxIcyoUqHXdBEBM5GWmHtgKHeW0M1I",
+	"e5PS+Z0wUxXhAalpU1U4dn36vwvZ1zCZhyz/3jcnfJSA71kCXlPo4L4E4LIuAS9AJucSPL7+UL9jmbh8",
+	"FIofheJHofhRKH4UinfGZDLbscMvC79XR5IomlcadgATSjOpM6IbNNbbeFQjJla3tOibfL1MHSG0S6rR",
+	"QKVviaOI7Tqp9Dp3S0uScTgMDqjN9oE47nX9NJzN6SzR7GBt7M/Vx7qeiu2g/kQKcw7kwX8hzT7kGDqc",
+	"GQu3pRm8OEtvgnmYLI3jgVKHGyEerVxPN1N5kCxPL86XfFAFIFnoK91OHlneI8t7ZHl/JJb3fuOGTy6X",
+	"qFKOnTE+0xZotXPBbRfTXX2qKF/a7yVdhFqa13Tq2kOBh/Ai82RK7Dej6YWxGWdJ5iOpEjcR7mlfRruP",
+	"rv/h1gng2gBwv3BypwmE/lAbjUK6oNZopL/QRiLul5SY+PJK1x2f6mgK3bP+yHFNepKGB9/fX8nLRfmc",
+	"HygX5cWtUBv9TVXrbaYeWeojS31kqX8kloqsZBVlqAaA7oBvzlS8WJvygelmhjIwO6eMEnjqSQ/oi3ZF",
+	"bMwmPfV1hPkSxvjMHFXC6r10/m+4sj1GY9P4XYoeYEYEgikIR1R4xpYKaUuEwLdNqtjW0DUlbGicHUL1",
+	"hU7q2iKNbKqB8a+aQrbq2B9OEpeLb05S7coErl/SUd6pqgbntotdH78yKSdSy8FUArLGG2vyyduSt77H",
+	"+fd4cjR+lwtLldpDuBHjWZYm1CeexS67V9sAooWIGoiuTRXYPO4zms/VJIJprNkMZgpevRhgvTROxKap",
+	"v8D/31JVaBDkiSgYf7NOtasa416cvT67PKt/zZ7gire3uyeZfMFY1SXGv3SQJ+lJjpOZB8dd7M6PHOCB",
+	"ByOFA5h2pm6fE65dRnCYi4aWKev3bm164qtdQENR8CPM+kcMzXy2y3vqWyU85tVRj7OvK+k9+8/9T12k",
+	"KRuKfVSn4Z3m5Lwq1erS1uCu8p+lFKuKyJ3SnWLugZecrzdup8AC5RLhovsN5fijsTHo28fGcaGAhe7Y",
+	"QtWCPBfyBa1km7sI37x60durDNGC4fcoQuAhtHqi6LAcZ0QDsSw7bON+fb8U8U8T3kRYkNuTb6mWWkt0",
+	"lT62WGAwzBrihbEj/VNTbpwF/T+a7VTKskar8g78Jbwe8WkLfHppe3BguWdKITaRIRtjFhIdzC3/yjmY",
+	"Or0dReBa/3f7QaXbZKa4mqEnNbNNItaJmL+vTMoNo2iQAjwmPK+LGtD4SAfFVFNX7ZXC5JWWDjvg/E6N",
+	"ai+tP+P4AGuHMOU+JC3L4eiVihGeTjxcrz54kSrWO+CmUDNJFI9cuSbkOsC++3RuSlSvtFr84FYxaKnE",
+	"qf9sN0h06G5x2/9yx54xTkFU6qE1UXohvkU7LW76G9TB0h1usIr/kgw1CPj9sjN9VI+X3n/p7c2SuvxZ",
+	"gy25dfLb7D3k0+tWRrVRJVy6wYver5JplCiWx2p9CV3GgQtT3IAvVsYCodtXm4YKuhV2NR+3hUW+p03s",
+	"ERnrTbE7G5C8gGs7i9xpG7je/lbpIKjFjobxjb23fEq6qWy192ALTE0Pwz2CtdossTNQm80T22RE/c7x",
+	"FySAK7VT1hXzirbp1uchoY7i7DhyneDNHUJSRHvbF5PerPQU9nUibmk/HLhnzO+YqOpWDVcaOm6qlPBn",
+	"aKLfLymvdK1sJ+ff7J+mVmAtVsGcy7KypxPrON8bhZdltFH4FxpDXHzfUNe+qGCy1Hhi/Vk3RnJBonKH",
+	"fCOaI663qMG/D6T7+seMmnH1jJFWE+Bbz9pfAju8NvSLhoG5VFYjXP16s29QezUKUIN5IEDUIj0nuVp9",
+	"BoLLxvUqKTTYYcajgmY8xPMqVhGNvEmsqFDW+p1jl/G0vVl75C+iDZ/uCgd3r1mvQb8L2yYXXmTHB0Fz",
+	"Hi6pPSX3jugjn1nAHUGeHknV5XlYjGfSRqiY3a8i3vFWfUXJ/OFwEZ+OnmYGqzvR9Y2U8Zq0w87DdmGy",
+	"9EiS2pVIEdxuDL7TFxt7o6vAwYTggN85kO6SP75/LfURMfYnjpJPTnsY/GDY4DraPFYmut710rbtecmG",
+	"QvaM2QB/djNiH4YMm93aregofA/RgE3vgGb0t/HlPQbcPQbcPQbc7YDiu/TqvoU4ot+r49nLpC7O1Uyc",
+	"jYD2Vg0WB6Y0YqZQ3FLsOFxEx6bvF9IW+bal0xHbZWrtdnztjQYVO6G022laKNk4yh5l7IwFIKFEcMfQ",
+	"VDGRNkd4qW07/uqcZg0aEs0RvgOKP8XersWNUgjvlz++MjXen2CLiUNdD/j5K+kA8+TN6bvDqimU6vt/",
+	"vP1/",
 }
 
 // decodeSpec returns the embedded OpenAPI spec as raw JSON bytes,
@@ -43,6 +43,23 @@ const (
 	StoreKeyErrorCount = "errorCount"           // Count of errors on a store.
 	StoreKeyMock       = "mockData"             // Store loads mock data from a file or directory.
 	StoreKeyCA         = "certificateAuthority" // Path to CA certificate.
+	StoreKeyName       = "name"                 // Optional name to identify the store in metrics and logs.
+	StoreKeyTimeout    = "timeout"              // Optional timeout for each query to the store.
+	StoreKeyStrategy   = "strategy"             // Optional strategy for querying multiple stores in the domain.
+)
+
+// Strategies for querying a domain with multiple stores, set by [StoreKeyStrategy].
+// All stores in a domain use the same strategy, it can be set on any of them.
+// Stores are tried in configuration order.
+const (
+	// StoreStrategyAll queries all stores and merges the results. This is the default.
+	StoreStrategyAll = "all"
+	// StoreStrategyFirst queries stores in order, stopping at the first store that returns results.
+	StoreStrategyFirst = "first"
+	// StoreStrategyFastest queries all stores concurrently and uses the first successful response.
+	StoreStrategyFastest = "fastest"
+	// StoreStrategyFallback queries the first (primary) store, and the next store only if the previous one fails.
+	StoreStrategyFallback = "fallback"
 )
 
 // Rule configures a template rule.
@@ -71,6 +88,13 @@ type Rule struct {
 	// Each template is applied to an object from one of the `start` classes.
 	// If any template yields a blank string or an error, the rule does not apply.
 	Result ResultSpec `json:"result"`
+
+	// Priority orders queries for the same goal class: queries generated by higher priority rules are executed first.
+	// If a goal class reaches its query limit, queries from high priority rules are kept.
+	// Priority only breaks ties within one class and one step of the search,
+	// queries for different classes run concurrently regardless of priority.
+	// Default is 0, use a negative priority for rules that generate expensive or low-value queries.
+	Priority int `json:"priority,omitempty"`
 }
 
 // ClassSpec specifies one or more classes.
@@ -128,7 +152,32 @@ type Tuning struct {
 	// Each session has a separate search engine, configuration and state.
 	SessionTimeout Duration `json:"sessionTimeout,omitempty"`
 
+	// JobTimeout cancels asynchronous jobs that run longer than this timeout, the job fails.
+	// Jobs and streaming searches are not limited by RequestTimeout.
+	// If omitted or 0, jobs time out after 10 minutes.
+	JobTimeout Duration `json:"jobTimeout,omitempty"`
+
 	// UnsafeSharedSession skips authentication and uses a single shared session for all requests.
 	// WARNING: This disables per-user session isolation and should only be used for development or testing.
 	UnsafeSharedSession bool `json:"unsafeSharedSession,omitempty"`
+
+	// QueryCacheTTL enables caching of store query results for all domains.
+	// Cached results are re-used for identical queries and constraints until they are older than the TTL.
+	// If omitted or 0, results are not cached.
+	QueryCacheTTL Duration `json:"queryCacheTTL,omitempty"`
+
+	// QueryCacheTTLs overrides QueryCacheTTL for individual domains, keyed by domain name.
+	// A value of 0 disables caching for the domain.
+	QueryCacheTTLs map[string]Duration `json:"queryCacheTTLs,omitempty"`
+}
+
+// QueryCacheTTLFor returns the query cache TTL for a domain, 0 means no caching.
+func (t *Tuning) QueryCacheTTLFor(domain string) Duration {
+	if t == nil {
+		return 0
+	}
+	if ttl, ok := t.QueryCacheTTLs[domain]; ok {
+		return ttl
+	}
+	return t.QueryCacheTTL
 }
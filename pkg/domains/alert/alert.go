@@ -68,6 +68,8 @@ const (
 	StoreKeyMetrics      = "metrics"
 	StoreKeyAlertmanager = "alertmanager"
 	StoreKeyLokiRuler    = "lokiRuler"
+	StoreKeyRules        = "rules"
+	StoreKeyTenant       = "tenant"
 )
 
 func (domain) Store(s any) (korrel8r.Store, error) {
@@ -76,6 +78,20 @@ func (domain) Store(s any) (korrel8r.Store, error) {
 		return nil, err
 	}
 	metrics, alertmanager, lokiRuler := cs[StoreKeyMetrics], cs[StoreKeyAlertmanager], cs[StoreKeyLokiRuler]
+	if rules := cs[StoreKeyRules]; rules != "" {
+		if metrics != "" || alertmanager != "" || lokiRuler != "" {
+			return nil, fmt.Errorf("%v cannot be combined with %v, %v or %v", StoreKeyRules, StoreKeyMetrics, StoreKeyAlertmanager, StoreKeyLokiRuler)
+		}
+		rulesURL, err := url.Parse(rules)
+		if err != nil {
+			return nil, err
+		}
+		hc, err := k8s.NewHTTPClient(cs)
+		if err != nil {
+			return nil, err
+		}
+		return NewRulesStore(rulesURL, cs[StoreKeyTenant], hc), nil
+	}
 	metricsURL, err := url.Parse(metrics)
 	if err != nil {
 		return nil, err
@@ -369,6 +385,19 @@ func convertLabelSetToMap(m model.LabelSet) map[string]string {
 	return res
 }
 
+// newPrometheusObject returns an Object for a Prometheus alert with the expression of its rule.
+func newPrometheusObject(a *v1.Alert, expression string) *Object {
+	return &Object{
+		Labels:      convertLabelSetToMap(a.Labels),
+		Annotations: convertLabelSetToMap(a.Annotations),
+		Status:      string(a.State),
+		Value:       a.Value,
+		StartsAt:    a.ActiveAt,
+		Expression:  expression,
+		Fingerprint: a.Labels.Fingerprint().String(),
+	}
+}
+
 // matchesSubquery returns true if the prometheus alert matches part of korrel8r query.
 func matchesSubquery(q map[string]string, a *v1.Alert) bool {
 	for k, v := range q {
@@ -534,15 +563,7 @@ func (s *Store) getSubquery(ctx context.Context, prometheusRules v1.RulesResult,
 			}
 			for _, a := range ar.Alerts {
 				if matchesSubquery(subQuery, a) {
-					alerts = append(alerts, &Object{
-						Labels:      convertLabelSetToMap(a.Labels),
-						Annotations: convertLabelSetToMap(a.Annotations),
-						Status:      string(a.State),
-						Value:       a.Value,
-						StartsAt:    a.ActiveAt,
-						Expression:  ar.Query,
-						Fingerprint: a.Labels.Fingerprint().String(),
-					})
+					alerts = append(alerts, newPrometheusObject(a, ar.Query))
 				}
 			}
 		}
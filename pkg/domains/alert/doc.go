@@ -31,4 +31,27 @@
 //	alertmanager: ALERTMANAGER_URL
 //
 // At least one of the fields "metrics" or "alertmanager" must be present.
+//
+// # Rules API store
+//
+// A client of a Prometheus-compatible rules API, without Alertmanager.
+// Any server that implements /api/v1/rules and /api/v1/alerts can be used,
+// for example Prometheus, Thanos ruler, Mimir or VictoriaMetrics vmalert.
+//
+//	domain: alert
+//	rules: RULES_API_URL # URL prefix of the /api/v1 endpoints, for example https://mimir.example.com/prometheus
+//	tenant: TENANT_ID    # Optional, sets the X-Scope-OrgID header for multi-tenant servers.
+//
+// The "rules" field cannot be combined with "metrics", "alertmanager" or "lokiRuler".
+// Alerts from the rules API and the alerts API are merged, so the rule expression is included when available.
+//
+// To merge alerts from several servers, configure an alert store for each one.
+// Alerts are queried from all stores and de-duplicated by label fingerprint:
+//
+//	stores:
+//	  - domain: alert
+//	    rules: https://thanos-ruler.example.com
+//	  - domain: alert
+//	    rules: https://mimir.example.com/prometheus
+//	    tenant: team-a
 package alert
@@ -0,0 +1,137 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+// RulesStore is a client of a Prometheus-compatible rules and alerts API, without Alertmanager.
+//
+// It works with any server that implements the Prometheus /api/v1/rules and /api/v1/alerts endpoints,
+// for example Prometheus, Thanos ruler, Mimir or VictoriaMetrics vmalert.
+type RulesStore struct {
+	*impl.Store
+	base   *url.URL
+	tenant string
+	hc     *http.Client
+}
+
+// NewRulesStore returns a store for the Prometheus-compatible API at base.
+// If tenant is not empty it is sent in the X-Scope-OrgID header.
+func NewRulesStore(base *url.URL, tenant string, hc *http.Client) *RulesStore {
+	return &RulesStore{Store: impl.NewStore(Domain), base: base, tenant: tenant, hc: hc}
+}
+
+func (s *RulesStore) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	q, err := impl.TypeAssert[*Query](query)
+	if err != nil {
+		return err
+	}
+	alerts, err := s.getAlerts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, subquery := range q.Parsed {
+		for _, a := range alerts {
+			if matchesSubquery(subquery, a.alert) && c.CompareTime(a.obj.StartsAt) == 0 {
+				result.Append(a.obj)
+			}
+		}
+	}
+	return nil
+}
+
+type rulesAlert struct {
+	alert *v1.Alert
+	obj   *Object
+}
+
+// getAlerts gets alerts from the rules API, merged with active alerts from the alerts API.
+// Alerts from the rules API include the rule expression, so they are preferred.
+// Returns an error only if both APIs fail.
+func (s *RulesStore) getAlerts(ctx context.Context) ([]rulesAlert, error) {
+	var (
+		rules  v1.RulesResult
+		active v1.AlertsResult
+		alerts []rulesAlert
+	)
+	rulesErr := s.get(ctx, "api/v1/rules", url.Values{"type": {"alert"}}, &rules)
+	if rulesErr != nil {
+		log.V(1).Info("failed to query rules API", "url", s.base, "error", rulesErr)
+	}
+	alertsErr := s.get(ctx, "api/v1/alerts", nil, &active)
+	if alertsErr != nil {
+		log.V(1).Info("failed to query alerts API", "url", s.base, "error", alertsErr)
+	}
+	if rulesErr != nil && alertsErr != nil {
+		return nil, errors.Join(rulesErr, alertsErr)
+	}
+	seen := map[string]bool{}
+	add := func(a *v1.Alert, expression string) {
+		o := newPrometheusObject(a, expression)
+		if !seen[o.Fingerprint] {
+			seen[o.Fingerprint] = true
+			alerts = append(alerts, rulesAlert{alert: a, obj: o})
+		}
+	}
+	for _, rg := range rules.Groups {
+		for _, r := range rg.Rules {
+			if ar, ok := r.(v1.AlertingRule); ok {
+				for _, a := range ar.Alerts {
+					add(a, ar.Query)
+				}
+			}
+		}
+	}
+	for i := range active.Alerts {
+		add(&active.Alerts[i], "")
+	}
+	return alerts, nil
+}
+
+// get a Prometheus API endpoint and decode the data field of the response.
+func (s *RulesStore) get(ctx context.Context, path string, params url.Values, data any) error {
+	u := s.base.JoinPath(path)
+	u.RawQuery = params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if s.tenant != "" {
+		req.Header.Set("X-Scope-OrgID", s.tenant)
+	}
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("alert: GET %v: %w", u, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		if b, err := io.ReadAll(resp.Body); err == nil && len(b) > 0 {
+			return fmt.Errorf("alert: GET %v: %v: %v", u, resp.Status, string(b))
+		}
+		return fmt.Errorf("alert: GET %v: %v", u, resp.Status)
+	}
+	apiResp := struct {
+		Status string `json:"status"`
+		Data   any    `json:"data"`
+		Error  string `json:"error"`
+	}{Data: data}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("alert: GET %v: %w", u, err)
+	}
+	if apiResp.Status != "success" {
+		return fmt.Errorf("alert: GET %v: status %v: %v", u, apiResp.Status, apiResp.Error)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package alert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rulesResponse = `{"status":"success","data":{"groups":[{"name":"g","file":"f","interval":60,"rules":[
+{"type":"alerting","name":"PodDown","query":"up == 0","duration":0,"labels":{},"annotations":{},"health":"ok","evaluationTime":0,"lastEvaluation":"2024-01-01T00:00:00Z","state":"firing",
+ "alerts":[{"labels":{"alertname":"PodDown","namespace":"ns","pod":"a"},"annotations":{},"state":"firing","activeAt":"2024-01-01T00:00:00Z","value":"0"}]},
+{"type":"recording","name":"r","query":"sum(up)","labels":{},"health":"ok","evaluationTime":0,"lastEvaluation":"2024-01-01T00:00:00Z"}
+]}]}}`
+
+const alertsResponse = `{"status":"success","data":{"alerts":[
+{"labels":{"alertname":"PodDown","namespace":"ns","pod":"a"},"annotations":{},"state":"firing","activeAt":"2024-01-01T00:00:00Z","value":"0"},
+{"labels":{"alertname":"Other","namespace":"other"},"annotations":{},"state":"pending","activeAt":"2024-01-01T00:00:00Z","value":"1"}
+]}}`
+
+func newRulesServer(t *testing.T, rules, alerts string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tenant1", r.Header.Get("X-Scope-OrgID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/prefix/api/v1/rules":
+			assert.Equal(t, "alert", r.URL.Query().Get("type"))
+			if rules == "" {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(rules))
+		case "/prefix/api/v1/alerts":
+			if alerts == "" {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(alerts))
+		default:
+			t.Errorf("unexpected path: %v", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRulesStore_Get(t *testing.T) {
+	for _, x := range []struct {
+		name, rules, alerts, query string
+		want                       []string
+		expression                 string
+	}{
+		{"merged", rulesResponse, alertsResponse, `{}`, []string{"PodDown", "Other"}, "up == 0"},
+		{"match", rulesResponse, alertsResponse, `{"namespace":"other"}`, []string{"Other"}, ""},
+		{"rules only", rulesResponse, "", `{}`, []string{"PodDown"}, "up == 0"},
+		{"alerts only", "", alertsResponse, `{}`, []string{"PodDown", "Other"}, ""},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			server := newRulesServer(t, x.rules, x.alerts)
+			u, _ := url.Parse(server.URL + "/prefix")
+			s := NewRulesStore(u, "tenant1", server.Client())
+			q, err := Domain.Query("alert:alert:" + x.query)
+			require.NoError(t, err)
+			r := result.New(Class{})
+			require.NoError(t, s.Get(context.Background(), q, nil, r))
+			var got []string
+			for _, o := range r.List() {
+				got = append(got, Class{}.Preview(o))
+			}
+			assert.Equal(t, x.want, got)
+			assert.Equal(t, x.expression, r.List()[0].(*Object).Expression)
+		})
+	}
+}
+
+func TestRulesStore_GetError(t *testing.T) {
+	server := newRulesServer(t, "", "")
+	u, _ := url.Parse(server.URL + "/prefix")
+	s := NewRulesStore(u, "tenant1", server.Client())
+	err := s.Get(context.Background(), &Query{Parsed: []map[string]string{{}}}, nil, result.New(Class{}))
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestDomain_Store_rules(t *testing.T) {
+	_, err := Domain.Store(config.Store{StoreKeyRules: "http://example.com", StoreKeyAlertmanager: "http://example.com"})
+	assert.ErrorContains(t, err, "cannot be combined")
+}
@@ -4,11 +4,14 @@ package domains
 
 import (
 	"github.com/korrel8r/korrel8r/pkg/domains/alert"
+	"github.com/korrel8r/korrel8r/pkg/domains/audit"
+	"github.com/korrel8r/korrel8r/pkg/domains/event"
 	"github.com/korrel8r/korrel8r/pkg/domains/incident"
 	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
 	"github.com/korrel8r/korrel8r/pkg/domains/log"
 	"github.com/korrel8r/korrel8r/pkg/domains/metric"
 	"github.com/korrel8r/korrel8r/pkg/domains/netflow"
+	"github.com/korrel8r/korrel8r/pkg/domains/profile"
 	"github.com/korrel8r/korrel8r/pkg/domains/trace"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 )
@@ -21,4 +24,7 @@ var All = []korrel8r.Domain{
 	alert.Domain,
 	metric.Domain,
 	incident.Domain,
+	event.Domain,
+	profile.Domain,
+	audit.Domain,
 }
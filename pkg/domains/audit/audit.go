@@ -0,0 +1,264 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package audit
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/loki"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+)
+
+var _ = impl.AssertDomainTypes(Domain, Object(nil), Class{}, &Query{}, &Store{})
+
+//go:embed doc.md
+var description string
+
+const name = "audit"
+
+var Domain = &domain{impl.NewDomain(name, description, Class{})}
+
+type domain struct{ *impl.Domain }
+
+func (d *domain) Query(s string) (korrel8r.Query, error) {
+	_, query, err := impl.UnmarshalQueryString[Query](d, s)
+	return &query, err
+}
+
+const (
+	StoreKeyLoki      = "loki"
+	StoreKeyLokiStack = "lokiStack"
+)
+
+func (*domain) Store(s any) (korrel8r.Store, error) {
+	cs, err := impl.TypeAssert[config.Store](s)
+	if err != nil {
+		return nil, err
+	}
+	loki, lokiStack := cs[StoreKeyLoki], cs[StoreKeyLokiStack]
+	if (loki == "") == (lokiStack == "") {
+		return nil, errors.New("must set exactly one of loki or lokiStack URLs")
+	}
+	hc, err := k8s.NewHTTPClient(cs)
+	if err != nil {
+		return nil, err
+	}
+	newStore := NewLokiStore
+	if lokiStack != "" {
+		loki, newStore = lokiStack, NewLokiStackStore
+	}
+	u, err := url.Parse(loki)
+	if err != nil {
+		return nil, err
+	}
+	return newStore(u, hc), nil
+}
+
+// Class represents any audit event. There is only a single class, named "event".
+type Class struct{}
+
+func (c Class) Domain() korrel8r.Domain                     { return Domain }
+func (c Class) Name() string                                { return "event" }
+func (c Class) String() string                              { return korrel8r.ClassString(c) }
+func (c Class) Description() string                         { return "Kubernetes API server audit event." }
+func (c Class) Unmarshal(b []byte) (korrel8r.Object, error) { return impl.UnmarshalAs[Object](b) }
+
+// ID is the audit ID and stage: there may be an event for each stage of a request.
+func (c Class) ID(o korrel8r.Object) any {
+	if o, _ := o.(Object); o != nil {
+		return o.AuditID + "/" + o.Stage
+	}
+	return nil
+}
+
+func (c Class) Preview(o korrel8r.Object) string {
+	return impl.Preview(o, func(o Object) string { return o.String() })
+}
+
+// Object is an audit event, passed as *Event when used as a korrel8r.Object.
+type Object = *Event
+
+// Event is a Kubernetes audit event, in the form of an audit.k8s.io/v1 Event.
+//
+// Only the fields that are useful for correlation are included.
+type Event struct {
+	Level                    string            `json:"level,omitempty"`
+	AuditID                  string            `json:"auditID"`
+	Stage                    string            `json:"stage,omitempty"`
+	RequestURI               string            `json:"requestURI,omitempty"`
+	Verb                     string            `json:"verb"`
+	User                     UserInfo          `json:"user"`
+	ImpersonatedUser         *UserInfo         `json:"impersonatedUser,omitempty"`
+	SourceIPs                []string          `json:"sourceIPs,omitempty"`
+	UserAgent                string            `json:"userAgent,omitempty"`
+	ObjectRef                *ObjectReference  `json:"objectRef,omitempty"`
+	ResponseStatus           *Status           `json:"responseStatus,omitempty"`
+	RequestReceivedTimestamp time.Time         `json:"requestReceivedTimestamp,omitzero"`
+	StageTimestamp           time.Time         `json:"stageTimestamp,omitzero"`
+	Annotations              map[string]string `json:"annotations,omitempty"`
+}
+
+// UserInfo identifies the user that made a request.
+type UserInfo struct {
+	Username string   `json:"username,omitempty"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// ObjectReference identifies the object a request applies to.
+type ObjectReference struct {
+	Resource    string `json:"resource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	UID         string `json:"uid,omitempty"`
+	APIGroup    string `json:"apiGroup,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+}
+
+// Status is the response status of a request.
+type Status struct {
+	Code    int    `json:"code,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// String returns a one-line summary: USER VERB RESOURCE[/SUBRESOURCE] [NAMESPACE/]NAME CODE
+func (e *Event) String() string {
+	w := &strings.Builder{}
+	fmt.Fprintf(w, "%v %v", e.User.Username, e.Verb)
+	if r := e.ObjectRef; r != nil {
+		fmt.Fprintf(w, " %v", r.Resource)
+		if r.Subresource != "" {
+			fmt.Fprintf(w, "/%v", r.Subresource)
+		}
+		switch {
+		case r.Namespace != "" && r.Name != "":
+			fmt.Fprintf(w, " %v/%v", r.Namespace, r.Name)
+		case r.Name != "":
+			fmt.Fprintf(w, " %v", r.Name)
+		case r.Namespace != "":
+			fmt.Fprintf(w, " %v/", r.Namespace)
+		}
+	} else if e.RequestURI != "" {
+		fmt.Fprintf(w, " %v", e.RequestURI)
+	}
+	if s := e.ResponseStatus; s != nil && s.Code != 0 {
+		fmt.Fprintf(w, " %v", s.Code)
+	}
+	return w.String()
+}
+
+// Query selects audit events. Empty fields match any event.
+type Query struct {
+	// Verbs to match, for example "create", "update", "patch", "delete".
+	Verbs []string `json:"verbs,omitempty"`
+	// User is the name of the user making the request.
+	User string `json:"user,omitempty"`
+	// ObjectRef selects events for a resource or object.
+	ObjectRef *ObjectReference `json:"objectRef,omitempty"`
+	// Code is the HTTP response status code.
+	Code int `json:"code,omitempty"`
+	// Stage of the request, for example "ResponseComplete".
+	Stage string `json:"stage,omitempty"`
+	// Start of the time range, further restricts the constraint.
+	Start *time.Time `json:"start,omitempty"`
+	// End of the time range, further restricts the constraint.
+	End *time.Time `json:"end,omitempty"`
+}
+
+func (q *Query) Class() korrel8r.Class { return Class{} }
+func (q *Query) Data() string          { b, _ := json.Marshal(q); return string(b) }
+func (q *Query) String() string        { return korrel8r.QueryString(q) }
+
+// LogQL returns a LogQL expression that selects the audit events matching the query.
+func (q *Query) LogQL() string {
+	w := &strings.Builder{}
+	w.WriteString(`{log_type="audit"}|json|auditID!=""`)
+	add := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "|%v=%q", label, value)
+		}
+	}
+	switch len(q.Verbs) {
+	case 0:
+	case 1:
+		add("verb", q.Verbs[0])
+	default:
+		verbs := make([]string, len(q.Verbs))
+		for i, v := range q.Verbs {
+			verbs[i] = regexp.QuoteMeta(v)
+		}
+		fmt.Fprintf(w, "|verb=~%q", strings.Join(verbs, "|"))
+	}
+	add("user_username", q.User)
+	if r := q.ObjectRef; r != nil {
+		add("objectRef_resource", r.Resource)
+		add("objectRef_namespace", r.Namespace)
+		add("objectRef_name", r.Name)
+		add("objectRef_uid", r.UID)
+		add("objectRef_apiGroup", r.APIGroup)
+		add("objectRef_apiVersion", r.APIVersion)
+		add("objectRef_subresource", r.Subresource)
+	}
+	if q.Code != 0 {
+		fmt.Fprintf(w, "|responseStatus_code=%v", q.Code)
+	}
+	add("stage", q.Stage)
+	return w.String()
+}
+
+// Store retrieves audit events stored in Loki.
+type Store struct {
+	*impl.Store
+	*loki.Client
+	tenant string // LokiStack tenant, empty for plain Loki.
+}
+
+// NewLokiStore returns a store that uses plain Loki URLs.
+func NewLokiStore(base *url.URL, h *http.Client) *Store {
+	return &Store{Store: impl.NewStore(Domain), Client: loki.New(h, base)}
+}
+
+// NewLokiStackStore returns a store that uses the LokiStack "audit" tenant.
+func NewLokiStackStore(base *url.URL, h *http.Client) *Store {
+	return &Store{Store: impl.NewStore(Domain), Client: loki.New(h, base), tenant: "audit"}
+}
+
+func (s *Store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	q, err := impl.TypeAssert[*Query](query)
+	if err != nil {
+		return err
+	}
+	collect := func(l *loki.Log) {
+		if e := newEvent(l); e != nil {
+			result.Append(e)
+		}
+	}
+	if s.tenant != "" {
+		return s.GetStack(ctx, q.LogQL(), s.tenant, c.WithTimeRange(q.Start, q.End), collect)
+	}
+	return s.Client.Get(ctx, q.LogQL(), c.WithTimeRange(q.Start, q.End), collect)
+}
+
+// newEvent parses a log body as an audit event, returns nil if it is not an audit event.
+func newEvent(l *loki.Log) *Event {
+	var e Event
+	if err := json.Unmarshal([]byte(l.Body), &e); err != nil || e.AuditID == "" {
+		return nil
+	}
+	return &e
+}
@@ -0,0 +1,18 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
+	"github.com/korrel8r/korrel8r/pkg/domains/audit"
+)
+
+var fixture = domain.Fixture{
+	Query:        &audit.Query{},
+	ClusterSetup: func(testing.TB) bool { return false },
+}
+
+func TestAuditDomain(t *testing.T)      { fixture.Test(t) }
+func BenchmarkAuditDomain(b *testing.B) { fixture.Benchmark(b) }
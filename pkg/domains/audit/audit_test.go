@@ -0,0 +1,94 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_LogQL(t *testing.T) {
+	for _, x := range []struct {
+		query, want string
+	}{
+		{`{}`, `{log_type="audit"}|json|auditID!=""`},
+		{`{"verbs":["delete"],"user":"alice"}`, `{log_type="audit"}|json|auditID!=""|verb="delete"|user_username="alice"`},
+		{`{"verbs":["update","patch"],"objectRef":{"resource":"pods","namespace":"ns","name":"x"}}`,
+			`{log_type="audit"}|json|auditID!=""|verb=~"update|patch"|objectRef_resource="pods"|objectRef_namespace="ns"|objectRef_name="x"`},
+		{`{"code":403,"stage":"ResponseComplete"}`, `{log_type="audit"}|json|auditID!=""|responseStatus_code=403|stage="ResponseComplete"`},
+	} {
+		t.Run(x.query, func(t *testing.T) {
+			q, err := Domain.Query("audit:event:" + x.query)
+			require.NoError(t, err)
+			assert.Equal(t, x.want, q.(*Query).LogQL())
+		})
+	}
+	_, err := Domain.Query(`audit:event:{"bad":1}`)
+	assert.Error(t, err)
+}
+
+func TestEvent_String(t *testing.T) {
+	e := &Event{
+		Verb:           "delete",
+		User:           UserInfo{Username: "alice"},
+		ObjectRef:      &ObjectReference{Resource: "pods", Namespace: "ns", Name: "x"},
+		ResponseStatus: &Status{Code: 200},
+	}
+	assert.Equal(t, "alice delete pods ns/x 200", e.String())
+	e.ObjectRef = &ObjectReference{Resource: "pods", Subresource: "log", Name: "x"}
+	e.ResponseStatus = nil
+	assert.Equal(t, "alice delete pods/log x", e.String())
+	e.ObjectRef = nil
+	e.RequestURI = "/healthz"
+	assert.Equal(t, "alice delete /healthz", e.String())
+}
+
+func TestStore_Get(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.Query().Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC).UnixNano()
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"streams","result":[{"stream":{"log_type":"audit"},"values":[
+["%v", "{\"auditID\":\"a\",\"stage\":\"ResponseComplete\",\"verb\":\"delete\",\"user\":{\"username\":\"alice\"},\"objectRef\":{\"resource\":\"pods\",\"namespace\":\"ns\",\"name\":\"x\"},\"responseStatus\":{\"code\":200}}"],
+["%v", "not an audit event"]
+]}]}}`, now, now-1)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	for _, s := range []*Store{NewLokiStore(u, server.Client()), NewLokiStackStore(u, server.Client())} {
+		t.Run(s.tenant, func(t *testing.T) {
+			q := &Query{Verbs: []string{"delete"}}
+			r := result.New(Class{})
+			require.NoError(t, s.Get(context.Background(), q, &korrel8r.Constraint{}, r))
+			assert.Equal(t, q.LogQL(), gotQuery)
+			if s.tenant == "" {
+				assert.Equal(t, "/loki/api/v1/query_range", gotPath)
+			} else {
+				assert.Equal(t, "/api/logs/v1/audit/loki/api/v1/query_range", gotPath)
+			}
+			require.Len(t, r.List(), 1)
+			assert.Equal(t, "alice delete pods ns/x 200", Class{}.Preview(r.List()[0]))
+			assert.Equal(t, "a/ResponseComplete", Class{}.ID(r.List()[0]))
+		})
+	}
+}
+
+func TestDomain_Store(t *testing.T) {
+	_, err := Domain.Store(config.Store{})
+	assert.ErrorContains(t, err, "exactly one")
+	_, err = Domain.Store(config.Store{StoreKeyLoki: "http://a", StoreKeyLokiStack: "http://b"})
+	assert.ErrorContains(t, err, "exactly one")
+}
@@ -0,0 +1,71 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package audit is a korrel8r domain for Kubernetes API server audit events.
+//
+// Audit logs are also available as opaque log lines in the log:audit class.
+// The audit domain parses them as structured events so they can be queried by
+// verb, user, object and response status, and correlated with the objects they refer to.
+// This answers questions like "who changed this object?"
+//
+// # Classes
+//
+//	audit:event
+//
+// # Object
+//
+// An audit object is an [audit.k8s.io/v1 Event], for example:
+//
+//	{
+//	  "auditID": "c2b3a1...",
+//	  "stage": "ResponseComplete",
+//	  "verb": "delete",
+//	  "user": {"username": "system:admin", "groups": ["system:masters"]},
+//	  "objectRef": {"resource": "pods", "namespace": "default", "name": "my-pod", "apiVersion": "v1"},
+//	  "responseStatus": {"code": 200},
+//	  "requestReceivedTimestamp": "2025-03-01T10:00:00Z",
+//	  "stageTimestamp": "2025-03-01T10:00:00Z"
+//	}
+//
+// Only fields useful for correlation are included, request and response bodies are dropped.
+//
+// # Query
+//
+// Query selectors are JSON objects. All fields are optional, an empty selector matches all events.
+//
+//	{
+//	  "verbs": ["VERB", ...],
+//	  "user": "USERNAME",
+//	  "objectRef": {"resource": "RESOURCE", "namespace": "NAMESPACE", "name": "NAME", "uid": "UID", "apiGroup": "GROUP", "apiVersion": "VERSION", "subresource": "SUBRESOURCE"},
+//	  "code": HTTP_STATUS_CODE,
+//	  "stage": "STAGE",
+//	  "start": "RFC3339 time",
+//	  "end": "RFC3339 time"
+//	}
+//
+// The objectRef resource is the plural resource name used in API paths, for example "pods" or "deployments".
+// Start and end further restrict the constraint interval.
+//
+// Changes to a pod:
+//
+//	audit:event:{"verbs":["create","update","patch","delete"],"objectRef":{"resource":"pods","namespace":"default","name":"my-pod"}}
+//
+// Requests denied by authorization:
+//
+//	audit:event:{"code":403}
+//
+// # Store
+//
+// Audit events are read from a LokiStack audit tenant or a plain Loki server.
+// Set exactly one of lokiStack or loki.
+//
+//	domain: audit
+//	lokiStack: https://URL_OF_DEFAULT_LOKISTACK
+//
+// # Template functions
+//
+// The k8s domain provides functions to convert between k8s classes and resource names:
+//   - k8sResource: Takes (apiVersion, kind), returns the plural resource name.
+//   - k8sClassForResource: Takes (apiGroup, apiVersion, resource), returns the k8s class.
+//
+// [audit.k8s.io/v1 Event]: https://kubernetes.io/docs/reference/config-api/apiserver-audit.v1/#audit-k8s-io-v1-Event
+package audit
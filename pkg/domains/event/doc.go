@@ -0,0 +1,51 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package event is a korrel8r domain for Kubernetes events.
+//
+// Events are also available as k8s:Event.v1 objects in the k8s domain.
+// The event domain filters events by the time they happened, rather than the time they were created,
+// so it can answer questions like "what happened to this pod in the last 10 minutes?"
+//
+// # Classes
+//
+//	event:event
+//
+// # Object
+//
+// An event object is a map in the same form as a Kubernetes core/v1 Event resource.
+//
+// The time of an event is the first of these fields that is set:
+// lastTimestamp, eventTime, firstTimestamp, metadata.creationTimestamp.
+// Only events with a time inside the constraint interval are returned.
+// If there are more events than the constraint limit, the most recent events are returned.
+//
+// # Query
+//
+// Query selectors are JSON objects. All fields are optional, an empty selector matches all events.
+//
+//	{
+//	  "namespace": "NAMESPACE",
+//	  "involvedObject": {"kind": "KIND", "apiVersion": "VERSION", "namespace": "NAMESPACE", "name": "NAME", "uid": "UID"},
+//	  "reason": "REASON",
+//	  "type": "Normal|Warning",
+//	  "start": "RFC3339 time",
+//	  "end": "RFC3339 time"
+//	}
+//
+// Start and end further restrict the constraint interval.
+// If namespace is omitted, the involved object namespace is used.
+//
+// Events for a pod:
+//
+//	event:event:{"involvedObject":{"kind":"Pod","namespace":"default","name":"my-pod"}}
+//
+// Warning events in a namespace:
+//
+//	event:event:{"namespace":"default","type":"Warning"}
+//
+// # Store
+//
+// Uses the kube config default cluster, like the k8s domain. No additional configuration is needed.
+//
+//	domain: event
+package event
@@ -0,0 +1,206 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package event
+
+import (
+	"context"
+	_ "embed"
+	"slices"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = impl.AssertDomainTypes(Domain, Object(nil), Class{}, &Query{}, &Store{})
+
+//go:embed doc.md
+var description string
+
+const name = "event"
+
+var Domain = &domain{impl.NewDomain(name, description, Class{})}
+
+type domain struct{ *impl.Domain }
+
+func (d *domain) Query(s string) (korrel8r.Query, error) {
+	_, query, err := impl.UnmarshalQueryString[Query](d, s)
+	return &query, err
+}
+
+// Store connects to the kube config default cluster. The config parameter is ignored.
+func (*domain) Store(any) (korrel8r.Store, error) {
+	c, err := k8s.NewClient(nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(c), nil
+}
+
+// Class represents any event. There is only a single class, named "event".
+type Class struct{}
+
+func (c Class) Domain() korrel8r.Domain                     { return Domain }
+func (c Class) Name() string                                { return name }
+func (c Class) String() string                              { return korrel8r.ClassString(c) }
+func (c Class) Description() string                         { return description }
+func (c Class) Unmarshal(b []byte) (korrel8r.Object, error) { return impl.UnmarshalAs[Object](b) }
+
+func (c Class) ID(o korrel8r.Object) any {
+	if o, _ := o.(Object); o != nil {
+		return client.ObjectKeyFromObject(k8s.ToUnstructured(o))
+	}
+	return nil
+}
+
+func (c Class) Preview(o korrel8r.Object) string {
+	if o, _ := o.(Object); o != nil {
+		m, _ := o["message"].(string)
+		return m
+	}
+	return ""
+}
+
+// Object is a map in the form of a core/v1 Event resource.
+type Object = k8s.Object
+
+// ObjectReference selects the object involved in an event.
+type ObjectReference struct {
+	Kind       string `json:"kind,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// Query selects events. Empty fields match any event.
+type Query struct {
+	// Namespace of the events, defaults to the involved object namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// InvolvedObject selects events about an object.
+	InvolvedObject *ObjectReference `json:"involvedObject,omitempty"`
+	// Reason is a short machine-readable reason, for example "BackOff".
+	Reason string `json:"reason,omitempty"`
+	// Type is "Normal" or "Warning".
+	Type string `json:"type,omitempty"`
+	// Start of the time range, further restricts the constraint.
+	Start *time.Time `json:"start,omitempty"`
+	// End of the time range, further restricts the constraint.
+	End *time.Time `json:"end,omitempty"`
+}
+
+func (q *Query) Class() korrel8r.Class { return Class{} }
+func (q *Query) Data() string          { b, _ := json.Marshal(q); return string(b) }
+func (q *Query) String() string        { return korrel8r.QueryString(q) }
+
+// namespace to list events from.
+func (q *Query) namespace() string {
+	if q.Namespace == "" && q.InvolvedObject != nil {
+		return q.InvolvedObject.Namespace
+	}
+	return q.Namespace
+}
+
+// fields returns field selectors for the query, supported by the API server for events.
+func (q *Query) fields() client.MatchingFields {
+	f := client.MatchingFields{}
+	add := func(k, v string) {
+		if v != "" {
+			f[k] = v
+		}
+	}
+	if o := q.InvolvedObject; o != nil {
+		add("involvedObject.kind", o.Kind)
+		add("involvedObject.apiVersion", o.APIVersion)
+		add("involvedObject.namespace", o.Namespace)
+		add("involvedObject.name", o.Name)
+		add("involvedObject.uid", o.UID)
+	}
+	add("reason", q.Reason)
+	add("type", q.Type)
+	return f
+}
+
+// mostRecent sorts events by time and returns at most limit of the most recent, limit 0 means no limit.
+func mostRecent(events []corev1.Event, limit int) []corev1.Event {
+	slices.SortStableFunc(events, func(a, b corev1.Event) int { return Time(&a).Compare(Time(&b)) })
+	if limit > 0 && len(events) > limit {
+		events = events[len(events)-limit:]
+	}
+	return events
+}
+
+// Time returns the time an event happened.
+// This is the first non-zero value of lastTimestamp, eventTime, firstTimestamp, creationTimestamp.
+func Time(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// Store lists events from the Kubernetes API server.
+type Store struct {
+	*impl.Store
+	c client.Client
+}
+
+// NewStore returns a store that uses client c to list events.
+func NewStore(c client.Client) *Store { return &Store{Store: impl.NewStore(Domain), c: c} }
+
+func (s *Store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	q, err := impl.TypeAssert[*Query](query)
+	if err != nil {
+		return err
+	}
+	var opts []client.ListOption
+	if ns := q.namespace(); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	if f := q.fields(); len(f) > 0 {
+		opts = append(opts, f)
+	}
+	// Time filtering is done here, the API server does not support time-based selectors for events.
+	// Events are listed in pages of at most limit, only the most recent events in the time range are kept.
+	c = c.WithTimeRange(q.Start, q.End)
+	limit := c.GetLimit()
+	if limit > 0 {
+		opts = append(opts, client.Limit(int64(limit)))
+	}
+	var events []corev1.Event
+	for cont := ""; ; {
+		list := &corev1.EventList{}
+		if err := s.c.List(ctx, list, append(opts, client.Continue(cont))...); err != nil {
+			return err
+		}
+		for _, e := range list.Items {
+			if c.CompareTime(Time(&e)) == 0 {
+				events = append(events, e)
+			}
+		}
+		events = mostRecent(events, limit)
+		if cont = list.Continue; cont == "" {
+			break
+		}
+	}
+	for i := range events {
+		e := &events[i]
+		e.APIVersion, e.Kind = "v1", "Event" // Not set on list items.
+		o, err := k8s.FromStructured(e)
+		if err != nil {
+			return err
+		}
+		result.Append(o)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package event_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
+	"github.com/korrel8r/korrel8r/pkg/domains/event"
+)
+
+var fixture = domain.Fixture{Query: &event.Query{}}
+
+func TestEventDomain(t *testing.T)      { fixture.Test(t) }
+func BenchmarkEventDomain(b *testing.B) { fixture.Benchmark(b) }
@@ -0,0 +1,146 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package event
+
+import (
+	"cmp"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var base = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func newEvent(name, pod, reason, eventType string, minutes int) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "ns", Name: name},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", APIVersion: "v1", Namespace: "ns", Name: pod},
+		Reason:         reason,
+		Type:           eventType,
+		Message:        name,
+		LastTimestamp:  metav1.NewTime(base.Add(time.Duration(minutes) * time.Minute)),
+	}
+}
+
+func newStore(events ...client.Object) *Store {
+	b := fake.NewClientBuilder().WithObjects(events...)
+	for _, field := range []string{"involvedObject.kind", "involvedObject.namespace", "involvedObject.name", "reason", "type"} {
+		b = b.WithIndex(&corev1.Event{}, field, func(o client.Object) []string {
+			e := o.(*corev1.Event)
+			return []string{map[string]string{
+				"involvedObject.kind":      e.InvolvedObject.Kind,
+				"involvedObject.namespace": e.InvolvedObject.Namespace,
+				"involvedObject.name":      e.InvolvedObject.Name,
+				"reason":                   e.Reason,
+				"type":                     e.Type,
+			}[field]}
+		})
+	}
+	return NewStore(b.Build())
+}
+
+func TestTime(t *testing.T) {
+	at := func(m int) time.Time { return base.Add(time.Duration(m) * time.Minute) }
+	e := &corev1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(at(1))}}
+	assert.Equal(t, at(1), Time(e))
+	e.FirstTimestamp = metav1.NewTime(at(2))
+	assert.Equal(t, at(2), Time(e))
+	e.EventTime = metav1.NewMicroTime(at(3))
+	assert.Equal(t, at(3), Time(e))
+	e.LastTimestamp = metav1.NewTime(at(4))
+	assert.Equal(t, at(4), Time(e))
+}
+
+func TestStore_Get(t *testing.T) {
+	s := newStore(
+		newEvent("a1", "a", "Scheduled", "Normal", 1),
+		newEvent("a2", "a", "BackOff", "Warning", 2),
+		newEvent("a3", "a", "BackOff", "Warning", 3),
+		newEvent("b1", "b", "BackOff", "Warning", 4),
+	)
+	at := func(m int) *time.Time { return new(base.Add(time.Duration(m) * time.Minute)) }
+	for _, x := range []struct {
+		name  string
+		query string
+		c     *korrel8r.Constraint
+		want  []string
+	}{
+		{"all", `{}`, nil, []string{"a1", "a2", "a3", "b1"}},
+		{"involved object", `{"involvedObject":{"kind":"Pod","namespace":"ns","name":"a"}}`, nil, []string{"a1", "a2", "a3"}},
+		{"reason and type", `{"namespace":"ns","reason":"BackOff","type":"Warning"}`, nil, []string{"a2", "a3", "b1"}},
+		{"constraint", `{}`, &korrel8r.Constraint{Start: at(2), End: at(3)}, []string{"a2", "a3"}},
+		{"query time", `{"start":"2025-03-01T10:02:00Z"}`, &korrel8r.Constraint{End: at(3)}, []string{"a2", "a3"}},
+		{"limit keeps recent", `{}`, &korrel8r.Constraint{Limit: new(2)}, []string{"a3", "b1"}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			q, err := Domain.Query("event:event:" + x.query)
+			require.NoError(t, err)
+			r := result.New(Class{})
+			require.NoError(t, s.Get(context.Background(), q, x.c, r))
+			var got []string
+			for _, o := range r.List() {
+				got = append(got, Class{}.Preview(o))
+			}
+			assert.Equal(t, x.want, got)
+		})
+	}
+}
+
+func TestStore_Get_pages(t *testing.T) {
+	// The fake client does not support paging, serve pages of at most Limit events.
+	var pages []int64
+	c := fake.NewClientBuilder().WithObjects(
+		newEvent("a1", "a", "Scheduled", "Normal", 4),
+		newEvent("a2", "a", "BackOff", "Warning", 1),
+		newEvent("a3", "a", "BackOff", "Warning", 3),
+		newEvent("a4", "a", "BackOff", "Warning", 2),
+		newEvent("a5", "a", "BackOff", "Warning", 5),
+	).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			o := (&client.ListOptions{}).ApplyOptions(opts)
+			pages = append(pages, o.Limit)
+			if err := c.List(ctx, list); err != nil {
+				return err
+			}
+			el := list.(*corev1.EventList)
+			start, _ := strconv.Atoi(cmp.Or(o.Continue, "0"))
+			end := min(start+int(o.Limit), len(el.Items))
+			if end < len(el.Items) {
+				el.Continue = strconv.Itoa(end)
+			}
+			el.Items = el.Items[start:end]
+			return nil
+		},
+	}).Build()
+	q, err := Domain.Query("event:event:{}")
+	require.NoError(t, err)
+	r := result.New(Class{})
+	require.NoError(t, NewStore(c).Get(context.Background(), q, &korrel8r.Constraint{Limit: new(2), End: new(base.Add(4 * time.Minute))}, r))
+	var got []string
+	for _, o := range r.List() {
+		got = append(got, Class{}.Preview(o))
+	}
+	assert.Equal(t, []string{"a3", "a1"}, got, "most recent events in the time range")
+	assert.Equal(t, []int64{2, 2, 2}, pages)
+}
+
+func TestStore_GetObject(t *testing.T) {
+	s := newStore(newEvent("a1", "a", "Scheduled", "Normal", 1))
+	r := result.New(Class{})
+	require.NoError(t, s.Get(context.Background(), &Query{}, nil, r))
+	require.Len(t, r.List(), 1)
+	o := r.List()[0].(Object)
+	assert.Equal(t, "Event", o["kind"])
+	assert.Equal(t, map[string]any{"kind": "Pod", "apiVersion": "v1", "namespace": "ns", "name": "a"}, o["involvedObject"])
+}
@@ -10,12 +10,17 @@
 //
 // # Object
 //
-// An incident object contains id and mapping to the sources.
-// Alert is the only supported source type at present.
+// An incident object contains the incident id, the sources grouped in the incident,
+// and the components affected by the incident.
+//
+// A source has a type, for example "alert" or "cluster_operator_condition", and labels identifying it.
+// Each source is mapped to a component and layer by the cluster health analyzer.
+// Labels of alert sources are also listed in the "alertsLabels" field.
 //
 // # Query
 //
 // Query selectors are JSON objects.
+// All the fields in a query must match.
 //
 // Getting an incident by ID:
 //
@@ -25,6 +30,17 @@
 //
 //	incident:incident:{"alertLabels":{"alertname":"AlertmanagerReceiversNotConfigured","namespace":"openshift-monitoring"}}
 //
+// Getting incidents with a source that has all the given labels, optionally of a given source type:
+//
+//	incident:incident:{"sourceLabels":{"namespace":"demo","pod":"web-1"}}
+//	incident:incident:{"sourceType":"cluster_operator_condition","sourceLabels":{"name":"etcd"}}
+//
+// Getting incidents that affect a component or layer:
+//
+//	incident:incident:{"component":"etcd","layer":"core"}
+//
+// Without a start time constraint, only incidents that are open at the end of the constraint are returned.
+//
 // # Store
 //
 // A client of Prometheus. Store configuration:
@@ -3,11 +3,13 @@
 package incident
 
 import (
+	"cmp"
 	"context"
 	_ "embed"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -86,17 +88,64 @@ func (c Class) Preview(o korrel8r.Object) string {
 // Object contains incident data, passed as *Object when used as a korrel8r.Object.
 type Object struct {
 	// Common fields.
-	Id           string              `json:"id"`
+	Id string `json:"id"`
+	// AlertsLabels are the labels of alert sources, alerts are also included in Sources.
 	AlertsLabels []map[string]string `json:"alertsLabels"`
+	// Sources are the signals grouped in the incident.
+	Sources []Source `json:"sources,omitempty"`
+	// Components affected by the incident.
+	Components []Component `json:"components,omitempty"`
 
 	// Prometheus fields.
 	Value string `json:"value"`
 }
 
+// Source types used by the cluster health analyzer.
+const (
+	SourceAlert                    = "alert"
+	SourceClusterOperatorCondition = "cluster_operator_condition"
+)
+
+// Source is a signal that the cluster health analyzer grouped into an incident.
+type Source struct {
+	// Type of source, for example [SourceAlert].
+	Type string `json:"type"`
+	// Labels identifying the source, for an alert these are the alert labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Component and Layer the source was mapped to.
+	Component string `json:"component,omitempty"`
+	Layer     string `json:"layer,omitempty"`
+}
+
+// Component is a part of the cluster affected by an incident.
+// Components are grouped in layers, for example "core" or "workload".
+type Component struct {
+	Name  string `json:"name"`
+	Layer string `json:"layer,omitempty"`
+}
+
+// Namespaces returns the sorted, unique namespace labels of the incident sources.
+func (o *Object) Namespaces() []string {
+	var namespaces []string
+	for _, src := range o.Sources {
+		if ns := src.Labels["namespace"]; ns != "" && !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
 type Query struct {
 	Id string `json:"id,omitempty"`
 	// Alert labels to match against
 	AlertLabels map[string]string `json:"alertLabels,omitempty"`
+	// SourceType and SourceLabels match incidents with a source of this type that has all of these labels.
+	SourceType   string            `json:"sourceType,omitempty"`
+	SourceLabels map[string]string `json:"sourceLabels,omitempty"`
+	// Component and Layer match incidents affecting a component.
+	Component string `json:"component,omitempty"`
+	Layer     string `json:"layer,omitempty"`
 }
 
 func (q *Query) Class() korrel8r.Class { return Class{} }
@@ -192,28 +241,31 @@ func loadObjects(data model.Vector) []*Object {
 		for k, v := range s.Metric {
 			labels[string(k)] = string(v)
 		}
-
 		id := labels["group_id"]
-
 		i, found := incidents[id]
 		if !found {
 			i = &Object{Id: id}
 			incidents[id] = i
 		}
-
 		srcLabels := make(map[string]string)
 		for k, v := range labels {
 			if strings.HasPrefix(k, srcLabelPrefix) {
 				srcLabels[k[len(srcLabelPrefix):]] = v
 			}
 		}
-		if labels["type"] == "alert" {
+		if labels["type"] == SourceAlert {
 			i.AlertsLabels = append(i.AlertsLabels, srcLabels)
 		}
+		i.Sources = append(i.Sources, Source{Type: labels["type"], Labels: srcLabels, Component: labels["component"], Layer: labels["layer"]})
+		if c := (Component{Name: labels["component"], Layer: labels["layer"]}); c.Name != "" && !slices.Contains(i.Components, c) {
+			i.Components = append(i.Components, c)
+		}
 	}
-
 	ret := make([]*Object, 0, len(incidents))
 	for _, i := range incidents {
+		slices.SortFunc(i.Components, func(a, b Component) int {
+			return cmp.Or(strings.Compare(a.Layer, b.Layer), strings.Compare(a.Name, b.Name))
+		})
 		ret = append(ret, i)
 	}
 	return ret
@@ -221,22 +273,41 @@ func loadObjects(data model.Vector) []*Object {
 
 func filterObjects(objects []*Object, q *Query) (ret []*Object) {
 	for _, o := range objects {
-		if len(q.AlertLabels) > 0 {
-			// Check for any source labels matching the labels in the query.
-			for _, l := range o.AlertsLabels {
-				if isSubsetOf(l, q.AlertLabels) {
-					ret = append(ret, o)
-					break
-				}
-			}
-		} else {
-			// No AlertLabels provided: no futher filtering needed.
+		if matchAlertLabels(o, q) && matchSource(o, q) && matchComponent(o, q) {
 			ret = append(ret, o)
 		}
 	}
 	return ret
 }
 
+// matchAlertLabels is true if all the labels of one of the alert sources are in q.AlertLabels.
+func matchAlertLabels(o *Object, q *Query) bool {
+	if len(q.AlertLabels) == 0 {
+		return true // No AlertLabels provided: no futher filtering needed.
+	}
+	return slices.ContainsFunc(o.AlertsLabels, func(l map[string]string) bool { return isSubsetOf(l, q.AlertLabels) })
+}
+
+// matchSource is true if a source has type q.SourceType and all the labels in q.SourceLabels.
+func matchSource(o *Object, q *Query) bool {
+	if q.SourceType == "" && len(q.SourceLabels) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(o.Sources, func(s Source) bool {
+		return (q.SourceType == "" || s.Type == q.SourceType) && isSubsetOf(q.SourceLabels, s.Labels)
+	})
+}
+
+// matchComponent is true if the incident affects a component with name q.Component in layer q.Layer.
+func matchComponent(o *Object, q *Query) bool {
+	if q.Component == "" && q.Layer == "" {
+		return true
+	}
+	return slices.ContainsFunc(o.Components, func(c Component) bool {
+		return (q.Component == "" || c.Name == q.Component) && (q.Layer == "" || c.Layer == q.Layer)
+	})
+}
+
 func isSubsetOf(part, whole map[string]string) bool {
 	for k, v := range part {
 		if whole[k] != v {
@@ -0,0 +1,51 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package incident
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAndFilterObjects(t *testing.T) {
+	data := model.Vector{
+		{Metric: model.Metric{"group_id": "a", "type": "alert", "component": "etcd", "layer": "core",
+			"src_alertname": "EtcdDown", "src_namespace": "openshift-etcd", "src_pod": "etcd-0"}},
+		{Metric: model.Metric{"group_id": "a", "type": "cluster_operator_condition", "component": "etcd", "layer": "core",
+			"src_name": "etcd", "src_condition": "Degraded"}},
+		{Metric: model.Metric{"group_id": "b", "type": "alert", "component": "app", "layer": "workload",
+			"src_alertname": "KubePodCrashLooping", "src_namespace": "demo", "src_pod": "web-1"}},
+	}
+	objects := loadObjects(data)
+	require.Len(t, objects, 2)
+	byID := map[string]*Object{}
+	for _, o := range objects {
+		byID[o.Id] = o
+	}
+	a := byID["a"]
+	assert.Equal(t, []map[string]string{{"alertname": "EtcdDown", "namespace": "openshift-etcd", "pod": "etcd-0"}}, a.AlertsLabels)
+	assert.ElementsMatch(t, []Source{
+		{Type: SourceAlert, Labels: map[string]string{"alertname": "EtcdDown", "namespace": "openshift-etcd", "pod": "etcd-0"}, Component: "etcd", Layer: "core"},
+		{Type: SourceClusterOperatorCondition, Labels: map[string]string{"name": "etcd", "condition": "Degraded"}, Component: "etcd", Layer: "core"},
+	}, a.Sources)
+	assert.Equal(t, []Component{{Name: "etcd", Layer: "core"}}, a.Components)
+	assert.Equal(t, []string{"openshift-etcd"}, a.Namespaces())
+
+	ids := func(q *Query) (ids []string) {
+		for _, o := range filterObjects(objects, q) {
+			ids = append(ids, o.Id)
+		}
+		return ids
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, ids(&Query{}))
+	assert.Equal(t, []string{"a"}, ids(&Query{AlertLabels: map[string]string{"alertname": "EtcdDown", "namespace": "openshift-etcd", "pod": "etcd-0", "x": "y"}}))
+	assert.Equal(t, []string{"b"}, ids(&Query{SourceLabels: map[string]string{"namespace": "demo", "pod": "web-1"}}))
+	assert.Equal(t, []string{"a"}, ids(&Query{SourceType: SourceClusterOperatorCondition}))
+	assert.Empty(t, ids(&Query{SourceType: SourceClusterOperatorCondition, SourceLabels: map[string]string{"namespace": "demo"}}))
+	assert.Equal(t, []string{"b"}, ids(&Query{Layer: "workload"}))
+	assert.Equal(t, []string{"a"}, ids(&Query{Component: "etcd", Layer: "core"}))
+	assert.Empty(t, ids(&Query{Component: "etcd", Layer: "workload"}))
+}
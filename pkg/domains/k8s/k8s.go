@@ -20,6 +20,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/unique"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -270,6 +271,44 @@ func (c Class) New() Object {
 	return FromUnstructured(u)
 }
 
+// Resource returns the plural resource name for the class, for example "pods" for class "Pod".
+// Uses the discovered resource name if known, otherwise guesses from the kind.
+func (c Class) Resource() string {
+	Domain.m.Lock()
+	r := Domain.resources[c]
+	Domain.m.Unlock()
+	return resourceName(c, r)
+}
+
+func resourceName(c Class, r metav1.APIResource) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	plural, _ := meta.UnsafeGuessKindToResource(c.GVK())
+	return plural.Resource
+}
+
+// ClassForResource returns the class for a plural resource name, or nil if not found.
+// If version is empty, the preferred version of the group is used.
+func (d *domain) ClassForResource(group, version, resource string) korrel8r.Class {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if version == "" {
+		g := d.groups[group]
+		if g == nil {
+			return nil
+		}
+		version = g.PreferredVersion.Version
+	}
+	for c, r := range d.resources {
+		c := c.(Class)
+		if c.Group == group && c.Version == version && resourceName(c, r) == resource {
+			return c
+		}
+	}
+	return nil
+}
+
 // Namespaced returns true if the k8s resource represented by this class is namespaced
 func (c Class) Namespaced() bool {
 	Domain.m.Lock()
@@ -201,3 +201,12 @@ func TestClass_DefaultNamespaceed(t *testing.T) {
 	assert.True(t, deployment.Namespaced())
 	assert.True(t, pod.Namespaced())
 }
+
+func TestClass_Resource(t *testing.T) {
+	assert.Equal(t, "pods", pod.Resource())
+	assert.Equal(t, "deployments", deployment.Resource())
+	assert.Equal(t, pod, Domain.ClassForResource("", "", "pods"))
+	assert.Equal(t, deployment, Domain.ClassForResource("apps", "v1", "deployments"))
+	assert.Nil(t, Domain.ClassForResource("apps", "", "nonesuch"))
+	assert.Nil(t, Domain.ClassForResource("nonesuch", "", "pods"))
+}
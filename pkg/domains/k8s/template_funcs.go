@@ -11,6 +11,14 @@
 //	k8sIsNamespaced
 //		Takes a k8s Class argument, returns true if the class is a namespace-scoped resource.
 //
+//	k8sResource
+//		Takes string arguments (apiVersion, kind).
+//		Returns the plural resource name used in API paths, for example "pods" for kind "Pod".
+//
+//	k8sClassForResource
+//		Takes string arguments (apiGroup, version, resource). Version may be empty.
+//		Returns the korrel8r.Class for the plural resource name, or an error.
+//
 //	k8sHealthStatus
 //		Takes a k8s Object, evaluates its health using the kube-health library.
 //		Returns "Error", "Warning", or "" for healthy/unknown objects.
@@ -18,6 +26,8 @@
 package k8s
 
 import (
+	"fmt"
+
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/rhobs/kube-health/pkg/analyze"
 	khstatus "github.com/rhobs/kube-health/pkg/status"
@@ -33,6 +43,15 @@ func (d *domain) TemplateFuncs() map[string]any {
 			kc, ok := c.(Class)
 			return ok && kc.Namespaced()
 		},
+		"k8sResource": func(apiVersion, kind string) string {
+			return Class(schema.FromAPIVersionAndKind(apiVersion, kind)).Resource()
+		},
+		"k8sClassForResource": func(group, version, resource string) (korrel8r.Class, error) {
+			if c := d.ClassForResource(group, version, resource); c != nil {
+				return c, nil
+			}
+			return nil, fmt.Errorf("no k8s class for resource: %v", schema.GroupVersionResource{Group: group, Version: version, Resource: resource})
+		},
 		"k8sHealthStatus": k8sHealthStatus,
 	}
 }
@@ -3,7 +3,7 @@
 // Package log is a korrel8r domain for application, infrastructure, and audit logs.
 //
 // Logs can be stored on the cluster in LokiStack or in an external Loki server.
-// They can also be retrieved directly from the Kubernetes API server.
+// They can also be read as [OTLP] JSON log records, or retrieved directly from the Kubernetes API server.
 // Direct API server access does not provide long-term log storage,
 // but it gives short-term access when there is no long term log store available.
 //
@@ -25,6 +25,11 @@
 // For direct logs: the pod, namespace, and label meta-data are added as attributes
 // using the same label names as for stored logs.
 //
+// For OTLP logs: resource and log record attributes become attributes, with "." replaced by "_",
+// for example "k8s.pod.name" becomes "k8s_pod_name".
+// Nested attribute maps and structured log bodies are flattened, as for JSON log bodies in Loki.
+// The log record severity and trace context become "severity_text", "severity_number", "trace_id" and "span_id".
+//
 // Special attributes:
 //   - body: original log message.
 //   - timestamp: time the log was produced, if known, in RFC3339 format.
@@ -40,7 +45,7 @@
 //
 // For stored logs, korrel8r returns whatever format has been stored in Loki.
 //
-// For direct logs, both Viaq and OTEL attributes are included to ease migration.
+// For direct and OTLP logs, both Viaq and OTEL attributes are included to ease migration.
 //
 // # Query
 //
@@ -87,9 +92,32 @@
 //
 // Log queries work as follows:
 //  1. If lokiStack is set, try to connect to the URL and retrieve stored logs.
-//  2. If direct is true and lokiStack get fails (or lokiStack is not set): use the API server directly.
+//  2. If otlp is set and previous stores fail (or are not set): read OTLP log records.
+//  3. If direct is true and previous stores fail (or are not set): use the API server directly.
+//
+// At least one of lokiStack, otlp and direct must be set.
+//
+// # OTLP Store Configuration
+//
+// The otlp store reads log records in the [OTLP] JSON encoding, for teams that don't run Loki.
+// Records are read on each query, so this store is intended for small log volumes.
+//
+//	domain: log
+//	otlp: /var/log/otel/logs.jsonl
+//
+// The otlp value is one of:
+//   - Path or file: URL of a file containing a sequence of OTLP JSON LogsData messages,
+//     for example written by the OpenTelemetry Collector [file exporter].
+//   - Path or file: URL of a directory, all .json and .jsonl files in the directory are read.
+//   - http or https URL that returns OTLP JSON LogsData messages.
+//
+// The log class of a record is given by the "log_type" attribute if present,
+// otherwise it is inferred from the "k8s.namespace.name" resource attribute, like logTypeForNamespace.
 //
-// At least one of lokiStack and direct must be set.
+// Container selector queries match the "k8s.namespace.name", "k8s.pod.name", "k8s.container.name"
+// and "k8s.pod.labels.*" attributes. The "fields" selector is not supported.
+// LogQL queries must be a stream selector, optionally followed by json and label filter stages.
+// Line filters and metric queries are not supported.
 //
 // # Template functions
 //
@@ -100,4 +128,6 @@
 //     Returns a map where each key is replaced by the result of logSafeLabel.
 //
 // [LogQL]: https://grafana.com/docs/loki/latest/query
+// [OTLP]: https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+// [file exporter]: https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/exporter/fileexporter
 package log
@@ -34,6 +34,7 @@ const (
 	StoreKeyLoki      = "loki"
 	StoreKeyLokiStack = "lokiStack"
 	StoreKeyDirect    = "direct"
+	StoreKeyOTLP      = "otlp"
 )
 
 func (*domain) Store(s any) (korrel8r.Store, error) {
@@ -51,8 +52,8 @@ func (*domain) Store(s any) (korrel8r.Store, error) {
 type Store = impl.TryStores
 
 func NewStore(cs config.Store, k8sStore *k8s.Store) (*Store, error) {
-	var stores impl.TryStores // Collect loki and pod store
-	loki, lokiStack, direct := cs[StoreKeyLoki], cs[StoreKeyLokiStack], cs[StoreKeyDirect]
+	var stores impl.TryStores // Collect loki, otlp and pod store
+	loki, lokiStack, otlp, direct := cs[StoreKeyLoki], cs[StoreKeyLokiStack], cs[StoreKeyOTLP], cs[StoreKeyDirect]
 
 	if loki != "" && lokiStack != "" {
 		return nil, fmt.Errorf("can't set both loki and lokiStack URLs")
@@ -75,6 +76,13 @@ func NewStore(cs config.Store, k8sStore *k8s.Store) (*Store, error) {
 		}
 		stores = append(stores, NewLokiStackStore(u, hc))
 	}
+	if otlp != "" {
+		s, err := NewOTLPStore(otlp, hc)
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, s)
+	}
 
 	if ok, err := strconv.ParseBool(direct); direct != "" && err != nil {
 		return nil, err
@@ -87,7 +95,7 @@ func NewStore(cs config.Store, k8sStore *k8s.Store) (*Store, error) {
 	}
 
 	if len(stores) == 0 {
-		return nil, errors.New("must set at least one of loki, lokiStack or direct")
+		return nil, errors.New("must set at least one of loki, lokiStack, otlp or direct")
 	}
 	return &stores, nil
 }
@@ -0,0 +1,435 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package log
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/otel"
+)
+
+// Attributes set by the OTLP store that are not copied from OTLP attributes.
+const (
+	AttrSeverityText   = "severity_text"
+	AttrSeverityNumber = "severity_number"
+	AttrTraceID        = "trace_id"
+	AttrSpanID         = "span_id"
+	AttrLogType        = "log_type"
+)
+
+type otlpStore struct {
+	*impl.Store
+	source *url.URL
+	hc     *http.Client
+}
+
+// NewOTLPStore returns a store that reads OTLP JSON log records from source.
+// Source is an http or https URL that returns OTLP JSON, the path of an OTLP JSON file,
+// or the path of a directory containing OTLP JSON files with extension .json or .jsonl.
+// Files can be written by the OpenTelemetry Collector file exporter, for example.
+func NewOTLPStore(source string, h *http.Client) (korrel8r.Store, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https":
+	case "file", "":
+		u = &url.URL{Scheme: "file", Path: cmp.Or(u.Path, u.Opaque)}
+	default:
+		return nil, fmt.Errorf("invalid OTLP log source, expecting http, https or file URL: %v", source)
+	}
+	return &otlpStore{Store: impl.NewStore(Domain), source: u, hc: h}, nil
+}
+
+func (s *otlpStore) Get(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, r korrel8r.Appender) error {
+	q, err := impl.TypeAssert[*Query](query)
+	if err != nil {
+		return err
+	}
+	match, err := otlpMatcher(q)
+	if err != nil {
+		return err
+	}
+	var logs []Object
+	err = s.read(ctx, func(o Object) {
+		if match(o) {
+			t, _ := o.SortTime()
+			if constraint.CompareTime(t) == 0 {
+				logs = append(logs, o)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	// Keep the most recent logs if there is a limit.
+	slices.SortStableFunc(logs, func(a, b Object) int {
+		ta, _ := a.SortTime()
+		tb, _ := b.SortTime()
+		return ta.Compare(tb)
+	})
+	if limit := constraint.GetLimit(); limit > 0 && len(logs) > limit {
+		logs = logs[len(logs)-limit:]
+	}
+	for _, o := range logs {
+		r.Append(o)
+	}
+	return nil
+}
+
+// read calls collect for each log record in the source.
+func (s *otlpStore) read(ctx context.Context, collect func(Object)) error {
+	if s.source.Scheme != "file" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source.String(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := s.hc.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("%v: %v", resp.Status, req.URL)
+		}
+		return decodeOTLP(resp.Body, collect)
+	}
+	files := []string{s.source.Path}
+	if info, err := os.Stat(s.source.Path); err != nil {
+		return err
+	} else if info.IsDir() {
+		entries, err := os.ReadDir(s.source.Path)
+		if err != nil {
+			return err
+		}
+		files = files[:0]
+		for _, e := range entries {
+			if ext := filepath.Ext(e.Name()); e.Type().IsRegular() && (ext == ".json" || ext == ".jsonl") {
+				files = append(files, filepath.Join(s.source.Path, e.Name()))
+			}
+		}
+	}
+	for _, name := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := decodeOTLP(bytes.NewReader(data), collect); err != nil {
+			return fmt.Errorf("%v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// decodeOTLP decodes a sequence of OTLP JSON LogsData messages, as written by the collector file exporter.
+func decodeOTLP(r io.Reader, collect func(Object)) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var data otlpLogsData
+		if err := decoder.Decode(&data); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		data.collect(collect)
+	}
+}
+
+// OTLP JSON encoding of LogsData.
+// Trace and span IDs are hex strings in OTLP JSON, so they can't be decoded by protojson.
+// Attribute values are decoded by [otel.Value].
+type otlpLogsData struct {
+	ResourceLogs []struct {
+		Resource struct {
+			Attributes otel.KeyValueList `json:"attributes"`
+		} `json:"resource"`
+		ScopeLogs []struct {
+			LogRecords []otlpLogRecord `json:"logRecords"`
+		} `json:"scopeLogs"`
+	} `json:"resourceLogs"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         unixNano          `json:"timeUnixNano"`
+	ObservedTimeUnixNano unixNano          `json:"observedTimeUnixNano"`
+	SeverityNumber       any               `json:"severityNumber"`
+	SeverityText         string            `json:"severityText"`
+	Body                 otel.Value        `json:"body"`
+	Attributes           otel.KeyValueList `json:"attributes"`
+	TraceID              string            `json:"traceId"`
+	SpanID               string            `json:"spanId"`
+}
+
+func (d *otlpLogsData) collect(collect func(Object)) {
+	for _, rl := range d.ResourceLogs {
+		resource := Object{}
+		addOTLPAttributes(resource, "", rl.Resource.Attributes.Map())
+		for _, sl := range rl.ScopeLogs {
+			for _, lr := range sl.LogRecords {
+				collect(lr.object(resource))
+			}
+		}
+	}
+}
+
+// object returns a log Object with the resource attributes and the attributes of the log record.
+func (lr *otlpLogRecord) object(resource Object) Object {
+	o := maps.Clone(resource)
+	addOTLPAttributes(o, "", lr.Attributes.Map())
+	switch body := lr.Body.Value.(type) {
+	case string:
+		o[AttrBody] = body
+	case otel.KeyValueList: // Flatten structured body, like a JSON log body in Loki.
+		addOTLPAttributes(o, "", body.Map())
+		b, _ := json.Marshal(plainValue(body))
+		o[AttrBody] = string(b)
+	case nil:
+		o[AttrBody] = ""
+	default:
+		o[AttrBody] = attrString(body)
+	}
+	if t := time.Time(lr.TimeUnixNano); !t.IsZero() {
+		o[AttrTimestamp] = t.Format(time.RFC3339Nano)
+	}
+	if t := time.Time(lr.ObservedTimeUnixNano); !t.IsZero() {
+		o[AttrObservedTimestamp] = t.Format(time.RFC3339Nano)
+	}
+	set := func(k, v string) {
+		if v != "" {
+			o[k] = v
+		}
+	}
+	set(AttrSeverityText, lr.SeverityText)
+	if lr.SeverityNumber != nil {
+		set(AttrSeverityNumber, fmt.Sprint(lr.SeverityNumber))
+	}
+	set(AttrTraceID, lr.TraceID)
+	set(AttrSpanID, lr.SpanID)
+	// Include Viaq attributes, as for direct logs, so rules using either form work.
+	for k8sAttr, viaqAttr := range map[string]string{
+		AttrK8sPodName:       AttrKubernetesPodName,
+		AttrK8sNamespaceName: AttrKubernetesNamespaceName,
+		AttrK8sContainerName: AttrKubernetesContainerName,
+	} {
+		if o[viaqAttr] == "" {
+			set(viaqAttr, o[k8sAttr])
+		}
+	}
+	if o[AttrLogType] == "" {
+		o[AttrLogType] = logTypeForNamespace(o[AttrK8sNamespaceName])
+	}
+	return o
+}
+
+// addOTLPAttributes adds attributes to o, using safe label names.
+// Nested attribute maps are flattened, joining key paths with "_".
+func addOTLPAttributes(o Object, prefix string, attrs map[string]any) {
+	for k, v := range attrs {
+		key := SafeLabel(prefix + k)
+		if kvs, ok := v.(otel.KeyValueList); ok {
+			addOTLPAttributes(o, key+"_", kvs.Map())
+		} else {
+			o[key] = attrString(v)
+		}
+	}
+}
+
+// attrString returns a string attribute value, non-string values are JSON encoded.
+func attrString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(plainValue(v))
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+// plainValue replaces nested [otel.KeyValueList] values with maps, for JSON encoding.
+func plainValue(v any) any {
+	switch v := v.(type) {
+	case otel.KeyValueList:
+		m := v.Map()
+		for k, x := range m {
+			m[k] = plainValue(x)
+		}
+		return m
+	case []any:
+		a := make([]any, len(v))
+		for i, x := range v {
+			a[i] = plainValue(x)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+// unixNano is a time encoded as Unix nanoseconds, as a JSON string or number.
+type unixNano time.Time
+
+func (t *unixNano) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		*t = unixNano(time.Unix(0, int64(n)))
+	}
+	return nil
+}
+
+// otlpMatcher returns a function to select log objects for q.
+// A direct container selector is matched against the k8s attributes,
+// a LogQL query must be a stream selector, optionally followed by json and label filter stages.
+func otlpMatcher(q *Query) (func(Object) bool, error) {
+	class := q.class.Name()
+	if d := q.direct; d != nil {
+		if len(d.Fields) > 0 {
+			return nil, fmt.Errorf("OTLP log store cannot select by fields: %v", q)
+		}
+		return func(o Object) bool {
+			if o[AttrLogType] != class ||
+				(d.Namespace != "" && o[AttrK8sNamespaceName] != d.Namespace) ||
+				(d.Name != "" && o[AttrK8sPodName] != d.Name) ||
+				!d.IsContainerSelected(o[AttrK8sContainerName]) {
+				return false
+			}
+			for k, v := range d.Labels {
+				k = SafeLabel(k)
+				if o["k8s_pod_labels_"+k] != v && o["kubernetes_labels_"+k] != v {
+					return false
+				}
+			}
+			return true
+		}, nil
+	}
+	matchers, err := parseLogQL(q.logQL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", err, q)
+	}
+	return func(o Object) bool {
+		if o[AttrLogType] != class {
+			return false
+		}
+		for _, m := range matchers {
+			if !m.match(o) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+type labelMatcher struct {
+	name, op, value string
+	re              *regexp.Regexp
+}
+
+func (m *labelMatcher) match(o Object) bool {
+	v := o[m.name]
+	switch m.op {
+	case "=", "==":
+		return v == m.value
+	case "!=":
+		return v != m.value
+	case "=~":
+		return m.re.MatchString(v)
+	default: // "!~"
+		return !m.re.MatchString(v)
+	}
+}
+
+var (
+	matcherRE     = `([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(=~|!~|!=|==|=)\s*("(?:[^"\\]|\\.)*"|` + "`[^`]*`)"
+	streamStartRE = regexp.MustCompile(`^\s*{\s*`)
+	streamNextRE  = regexp.MustCompile(`^` + matcherRE + `\s*(,\s*|})`)
+	stageRE       = regexp.MustCompile(`^\s*\|\s*(?:(json)\b|` + matcherRE + `)`)
+	errLogQL      = errors.New("OTLP log store only supports LogQL stream selectors with json and label filter stages")
+)
+
+// parseLogQL parses the subset of LogQL supported by the OTLP store.
+func parseLogQL(logQL string) ([]*labelMatcher, error) {
+	var matchers []*labelMatcher
+	add := func(name, op, quoted string) error {
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return err
+		}
+		m := &labelMatcher{name: name, op: op, value: value}
+		if op == "=~" || op == "!~" {
+			if m.re, err = regexp.Compile("^(?:" + value + ")$"); err != nil {
+				return err
+			}
+		}
+		matchers = append(matchers, m)
+		return nil
+	}
+	s := logQL
+	loc := streamStartRE.FindStringIndex(s)
+	if loc == nil {
+		return nil, errLogQL
+	}
+	s = s[loc[1]:]
+	if strings.HasPrefix(s, "}") {
+		s = s[1:]
+	} else {
+		for done := false; !done; {
+			m := streamNextRE.FindStringSubmatch(s)
+			if m == nil {
+				return nil, errLogQL
+			}
+			if err := add(m[1], m[2], m[3]); err != nil {
+				return nil, err
+			}
+			s, done = s[len(m[0]):], m[4] == "}"
+		}
+	}
+	for strings.TrimSpace(s) != "" {
+		m := stageRE.FindStringSubmatch(s)
+		if m == nil {
+			return nil, errLogQL
+		}
+		if m[1] == "" {
+			if err := add(m[2], m[3], m[4]); err != nil {
+				return nil, err
+			}
+		}
+		s = s[len(m[0]):]
+	}
+	return matchers, nil
+}
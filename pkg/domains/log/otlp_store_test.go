@@ -0,0 +1,119 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package log
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func otlpGet(t *testing.T, source, query string, c *korrel8r.Constraint) []Object {
+	t.Helper()
+	s, err := NewOTLPStore(source, http.DefaultClient)
+	require.NoError(t, err)
+	q, err := NewQuery(query)
+	require.NoError(t, err)
+	list := result.NewList()
+	require.NoError(t, s.Get(t.Context(), q, c, list))
+	var logs []Object
+	for _, o := range list.List() {
+		logs = append(logs, o.(Object))
+	}
+	return logs
+}
+
+func bodies(logs []Object) (b []string) {
+	for _, o := range logs {
+		b = append(b, o[AttrBody])
+	}
+	return b
+}
+
+func TestOTLPStore_Object(t *testing.T) {
+	logs := otlpGet(t, "testdata/otlp.jsonl", `log:application:{"namespace":"myapp"}`, nil)
+	require.Len(t, logs, 2)
+	assert.Equal(t, Object{
+		"body":                      "hello",
+		"timestamp":                 "2025-09-05T20:36:56Z",
+		"observed_timestamp":        "2025-09-05T20:36:56.1Z",
+		"severity_text":             "INFO",
+		"severity_number":           "9",
+		"trace_id":                  "5b8efff798038103d269b633813fc60c",
+		"span_id":                   "eee19b7ec3c1b174",
+		"http_status_code":          "200",
+		"service_name":              "myservice",
+		"k8s_namespace_name":        "myapp",
+		"k8s_pod_name":              "myapp-1",
+		"k8s_container_name":        "server",
+		"k8s_pod_labels_app":        "myapp",
+		"kubernetes_namespace_name": "myapp",
+		"kubernetes_pod_name":       "myapp-1",
+		"kubernetes_container_name": "server",
+		"log_type":                  "application",
+	}, logs[0])
+	// Structured body is flattened into attributes.
+	assert.Equal(t, `{"code":3,"msg":"failed"}`, logs[1][AttrBody])
+	assert.Equal(t, "failed", logs[1]["msg"])
+	assert.Equal(t, "3", logs[1]["code"])
+}
+
+func TestOTLPStore_Get(t *testing.T) {
+	for _, x := range []struct {
+		query string
+		c     *korrel8r.Constraint
+		want  []string
+	}{
+		{`log:application:{}`, nil, []string{"hello", `{"code":3,"msg":"failed"}`}},
+		{`log:infrastructure:{}`, nil, []string{"compacted"}},
+		{`log:audit:{}`, nil, nil},
+		{`log:application:{"namespace":"myapp","name":"myapp-1","containers":["server"]}`, nil, []string{"hello", `{"code":3,"msg":"failed"}`}},
+		{`log:application:{"labels":{"app":"myapp"}}`, nil, []string{"hello", `{"code":3,"msg":"failed"}`}},
+		{`log:application:{"labels":{"app":"other"}}`, nil, nil},
+		{`log:application:{"containers":["other"]}`, nil, nil},
+		{`log:application:{kubernetes_namespace_name="myapp"}|json|severity_text="ERROR"`, nil, []string{`{"code":3,"msg":"failed"}`}},
+		{`log:infrastructure:{k8s_pod_name=~"etcd-.*", k8s_container_name!="x"}`, nil, []string{"compacted"}},
+		{`log:application:{k8s_pod_name!~"myapp-.*"}`, nil, nil},
+		{`log:application:{}`, &korrel8r.Constraint{Limit: ptr.To(1)}, []string{`{"code":3,"msg":"failed"}`}},
+		{`log:application:{}`, &korrel8r.Constraint{End: ptr.To(time.Unix(1757104616, 500))}, []string{"hello"}},
+	} {
+		t.Run(x.query, func(t *testing.T) {
+			assert.Equal(t, x.want, bodies(otlpGet(t, "testdata/otlp.jsonl", x.query, x.c)))
+		})
+	}
+}
+
+func TestOTLPStore_Get_unsupported(t *testing.T) {
+	s, err := NewOTLPStore("testdata/otlp.jsonl", http.DefaultClient)
+	require.NoError(t, err)
+	for _, query := range []string{
+		`log:application:{"fields":{"status.phase":"Running"}}`,
+		`log:application:{k8s_pod_name="x"} |= "error"`,
+		`log:application:sum(rate({k8s_pod_name="x"}[1m]))`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			q, err := NewQuery(query)
+			require.NoError(t, err)
+			assert.Error(t, s.Get(t.Context(), q, nil, result.NewList()))
+		})
+	}
+}
+
+func TestOTLPStore_Sources(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer server.Close()
+	for _, source := range []string{"testdata", "file:testdata/otlp.jsonl", server.URL + "/otlp.jsonl"} {
+		t.Run(source, func(t *testing.T) {
+			assert.Equal(t, []string{"compacted"}, bodies(otlpGet(t, source, `log:infrastructure:{}`, nil)))
+		})
+	}
+	_, err := NewOTLPStore("ftp://somewhere", http.DefaultClient)
+	assert.Error(t, err)
+}
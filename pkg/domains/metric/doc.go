@@ -5,13 +5,23 @@
 // # Classes
 //
 //	metric:metric
+//	metric:samples
 //
 // # Object
 //
-// A [Metric] is a time series identified by a label set. Korrel8r only uses labels
+// A metric:metric object is a [Metric], a time series identified by a label set. Korrel8r only uses labels
 // for correlation, it does not use sample values. If a korrel8r search has time
 // constraints, then metrics with no values that meet the constraint are ignored.
 //
+// A metric:samples object is a [Series]: a label set with sample values inside the constraint interval,
+// and summary statistics of the samples. Use it to see how a metric behaved, for example whether it spiked.
+//
+//	{"metric": {"__name__": "container_memory_working_set_bytes", "namespace": "default", "pod": "my-pod"},
+//	 "samples": [[1740823200, "1048576"], [1740823236, "2097152"]],
+//	 "stats": {"count": 2, "min": "1048576", "max": "2097152", "avg": "1572864", "last": "2097152"}}
+//
+// Samples are [timestamp, value] pairs, values are strings so that NaN and infinite values can be represented.
+//
 // # Query
 //
 // Selector is a [PromQL] query string.
@@ -24,6 +34,18 @@
 //	metric:metric:kube_pod_info{namespace="default"}
 //	metric:metric:{namespace="tracing-app-k6",pod="k6-tracing-564cf6dc8b-hpxd2"}
 //
+// For metric:samples the full PromQL expression is evaluated as a range query over the constraint interval,
+// returning the resulting series. The interval defaults to the last hour,
+// the resolution step is chosen to give about 100 samples per series.
+// The constraint limit restricts the number of series.
+//
+// Examples:
+//
+//	metric:samples:container_memory_working_set_bytes{namespace="default",pod="my-pod"}
+//	metric:samples:sum(rate(container_cpu_usage_seconds_total{namespace="default"}[5m]))
+//
+// To get samples for a metric:metric object, use its label set as a metric:samples query.
+//
 // # Store
 //
 // Prometheus is the store, store configuration:
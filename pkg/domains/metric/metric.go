@@ -30,18 +30,25 @@ const (
 var description string
 
 var (
-	Domain = &domain{Domain: impl.NewDomain(name, description, Class{})}
+	Domain = &domain{Domain: impl.NewDomain(name, description, Class{}, SamplesClass{})}
 
 	log = logging.Log()
 
 	_ = impl.AssertDomainTypes(Domain, Object{}, Class{}, Query(""), &Store{})
+	_ = impl.AssertDomainTypes(Domain, SamplesObject(nil), SamplesClass{}, SamplesQuery(""), &Store{})
 )
 
 type domain struct{ *impl.Domain }
 
 func (d domain) Query(s string) (korrel8r.Query, error) {
-	_, qs, err := impl.ParseQuery(d, s)
-	return Query(qs), err
+	c, qs, err := impl.ParseQuery(d, s)
+	if err != nil {
+		return nil, err
+	}
+	if c == (SamplesClass{}) {
+		return SamplesQuery(qs), nil
+	}
+	return Query(qs), nil
 }
 
 const StoreKeyMetricURL = name
@@ -116,6 +123,9 @@ type response struct {
 }
 
 func (s *Store) Get(ctx context.Context, kquery korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	if query, ok := kquery.(SamplesQuery); ok {
+		return s.getSamples(ctx, query, c, result)
+	}
 	query, err := impl.TypeAssert[Query](kquery)
 	if err != nil {
 		return err
@@ -0,0 +1,165 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package metric
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/prometheus"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/prometheus/common/model"
+)
+
+const samplesName = "samples"
+
+// SamplesClass represents time series with sample values. There is only a single samples class, named "samples".
+type SamplesClass struct{}
+
+func (c SamplesClass) Domain() korrel8r.Domain { return Domain }
+func (c SamplesClass) Name() string            { return samplesName }
+func (c SamplesClass) String() string          { return korrel8r.ClassString(c) }
+
+func (c SamplesClass) Unmarshal(b []byte) (korrel8r.Object, error) {
+	return impl.UnmarshalAs[SamplesObject](b)
+}
+
+func (c SamplesClass) Preview(o korrel8r.Object) string {
+	return impl.Preview(o, func(o SamplesObject) string { return o.String() })
+}
+
+func (c SamplesClass) ID(o korrel8r.Object) any {
+	if o, _ := o.(SamplesObject); o != nil {
+		return o.Metric.Fingerprint()
+	}
+	return nil
+}
+
+// SamplesObject is a time series with samples, passed as *Series when used as a korrel8r.Object.
+type SamplesObject = *Series
+
+// Series is a time series with sample values inside the constraint interval, and summary statistics.
+type Series struct {
+	// Metric is the label set of the series.
+	Metric model.Metric `json:"metric"`
+	// Samples are [timestamp, value] pairs in time order.
+	Samples []model.SamplePair `json:"samples"`
+	// Stats summarize the samples.
+	Stats Stats `json:"stats"`
+}
+
+// Stats are summary statistics for the samples in a Series, NaN samples are ignored.
+type Stats struct {
+	Count int               `json:"count"`
+	Min   model.SampleValue `json:"min"`
+	Max   model.SampleValue `json:"max"`
+	Avg   model.SampleValue `json:"avg"`
+	Last  model.SampleValue `json:"last"`
+}
+
+// String returns the series labels and summary statistics.
+func (s *Series) String() string {
+	return fmt.Sprintf("%v count=%v min=%v max=%v avg=%v last=%v",
+		s.Metric, s.Stats.Count, s.Stats.Min, s.Stats.Max, s.Stats.Avg, s.Stats.Last)
+}
+
+// NewSeries returns a series for samples, and computes the summary statistics.
+func NewSeries(m model.Metric, samples []model.SamplePair) *Series {
+	s := &Series{Metric: m, Samples: samples}
+	var sum float64
+	for _, p := range samples {
+		v := float64(p.Value)
+		if math.IsNaN(v) {
+			continue
+		}
+		if s.Stats.Count == 0 || v < float64(s.Stats.Min) {
+			s.Stats.Min = p.Value
+		}
+		if s.Stats.Count == 0 || v > float64(s.Stats.Max) {
+			s.Stats.Max = p.Value
+		}
+		s.Stats.Last = p.Value
+		s.Stats.Count++
+		sum += v
+	}
+	if s.Stats.Count > 0 {
+		s.Stats.Avg = model.SampleValue(sum / float64(s.Stats.Count))
+	}
+	return s
+}
+
+// SamplesQuery is a [PromQL] expression, evaluated over the constraint interval.
+// Unlike [Query], the full expression is evaluated and the resulting series and samples are returned.
+//
+// [PromQL]: https://prometheus.io/docs/prometheus/latest/querying/basics/
+type SamplesQuery string
+
+func (q SamplesQuery) Class() korrel8r.Class { return SamplesClass{} }
+func (q SamplesQuery) Data() string          { return string(q) }
+func (q SamplesQuery) String() string        { return korrel8r.QueryString(q) }
+
+// Default interval and resolution for range queries.
+const (
+	defaultRange     = time.Hour
+	samplesPerSeries = 100
+)
+
+// step returns a query resolution step giving about samplesPerSeries samples in the interval, at least 1s.
+func step(start, end time.Time) time.Duration {
+	return max(end.Sub(start)/samplesPerSeries, time.Second).Round(time.Second)
+}
+
+type rangeResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string       `json:"resultType"`
+		Result     model.Matrix `json:"result"`
+	} `json:"data"`
+}
+
+func (s *Store) getSamples(ctx context.Context, query SamplesQuery, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	selectors, err := Query(query).Selectors()
+	if err != nil {
+		return err
+	}
+	end := c.GetEnd()
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := c.GetStart()
+	if start.IsZero() {
+		start = end.Add(-defaultRange)
+	}
+	q := url.Values{}
+	q.Set("query", string(query))
+	q.Set("start", formatTime(start))
+	q.Set("end", formatTime(end))
+	q.Set("step", strconv.FormatFloat(step(start, end).Seconds(), 'f', -1, 64))
+	namespaces := extractNamespaces(selectors)
+	prometheus.AddNamespaceParams(q, namespaces)
+	u := prometheus.EffectiveURL(ctx, s.baseURL, s.k8sClient).JoinPath("/api/v1/query_range")
+	u.RawQuery = q.Encode()
+	log.V(5).Info("querying metric samples", "query", query, "namespaces", namespaces, "url", u.String())
+	var r rangeResponse
+	if err := impl.Get(ctx, u, s.Client, &r); err != nil {
+		return fmt.Errorf("metric samples query error: %w", err)
+	}
+	if r.Status != "success" {
+		return fmt.Errorf("GET %v: unexpected status: %v", u, r.Status)
+	}
+	if r.Data.ResultType != model.ValMatrix.String() {
+		return fmt.Errorf("GET %v: unexpected result type: %v", u, r.Data.ResultType)
+	}
+	for i, ss := range r.Data.Result {
+		if limit := c.GetLimit(); limit > 0 && i >= limit {
+			break
+		}
+		result.Append(NewSeries(ss.Metric, ss.Values))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package metric
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDomain_Query_samples(t *testing.T) {
+	q, err := Domain.Query(`metric:samples:rate(x{namespace="a"}[5m])`)
+	require.NoError(t, err)
+	assert.Equal(t, SamplesQuery(`rate(x{namespace="a"}[5m])`), q)
+	q, err = Domain.Query(`metric:metric:x{namespace="a"}`)
+	require.NoError(t, err)
+	assert.Equal(t, Query(`x{namespace="a"}`), q)
+}
+
+func TestNewSeries(t *testing.T) {
+	s := NewSeries(model.Metric{"__name__": "x"}, []model.SamplePair{
+		{Timestamp: 1000, Value: 2},
+		{Timestamp: 2000, Value: 8},
+		{Timestamp: 3000, Value: model.SampleValue(math.NaN())},
+		{Timestamp: 4000, Value: 5},
+	})
+	assert.Equal(t, Stats{Count: 3, Min: 2, Max: 8, Avg: 5, Last: 5}, s.Stats)
+	assert.Equal(t, "x count=3 min=2 max=8 avg=5 last=5", s.String())
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	s2, err := SamplesClass{}.Unmarshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, s.Stats, s2.(*Series).Stats)
+	assert.Equal(t, Stats{}, NewSeries(nil, nil).Stats)
+}
+
+func TestStep(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, 36*time.Second, step(start, start.Add(time.Hour)))
+	assert.Equal(t, time.Second, step(start, start.Add(time.Minute)))
+}
+
+func TestStore_Get_samples(t *testing.T) {
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query_range", r.URL.Path)
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+{"metric":{"pod":"a"},"values":[[1740823200,"1"],[1740823236,"3"]]},
+{"metric":{"pod":"b"},"values":[[1740823200,"4"]]}
+]}}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	k8sClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			obj.(*authv1.SelfSubjectAccessReview).Status.Allowed = true
+			return nil
+		},
+	}).Build()
+	s := &Store{Client: server.Client(), baseURL: u, k8sClient: k8sClient, Store: impl.NewStore(Domain)}
+
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	r := result.New(SamplesClass{})
+	require.NoError(t, s.Get(context.Background(), SamplesQuery(`x{namespace="ns"}`), &korrel8r.Constraint{Start: &start, End: &end}, r))
+	assert.Equal(t, `x{namespace="ns"}`, got.Get("query"))
+	assert.Equal(t, "1740823200", got.Get("start"))
+	assert.Equal(t, "1740826800", got.Get("end"))
+	assert.Equal(t, "36", got.Get("step"))
+	assert.Equal(t, "ns", got.Get("namespace"))
+	require.Len(t, r.List(), 2)
+	assert.Equal(t, `{pod="a"} count=2 min=1 max=3 avg=2 last=3`, SamplesClass{}.Preview(r.List()[0]))
+	assert.Equal(t, `{pod="b"} count=1 min=4 max=4 avg=4 last=4`, SamplesClass{}.Preview(r.List()[1]))
+
+	r = result.New(SamplesClass{})
+	require.NoError(t, s.Get(context.Background(), SamplesQuery(`x`), &korrel8r.Constraint{Limit: new(1)}, r))
+	assert.Len(t, r.List(), 1)
+}
@@ -5,10 +5,17 @@
 // # Classes
 //
 //	netflow:network
+//	netflow:summary
 //
 // # Object
 //
-// JSON object in [NetFlow] format.
+// A netflow:network object is a JSON object in [NetFlow] format.
+//
+// A netflow:summary object is the total bytes and packets sent from a source to a destination endpoint
+// during the constraint time window, by default the last hour.
+// Endpoints are identified by namespace, owner name and owner type, for example a Deployment.
+// Endpoint fields are empty for addresses outside the cluster.
+// Flows marked as duplicates are not counted.
 //
 // # Query
 //
@@ -18,6 +25,12 @@
 //	netflow:network:{SrcK8S_Type="Pod", SrcK8S_Namespace="myNamespace"}
 //	netflow:network:{DstK8S_Namespace="openshift-apiserver", DstK8S_OwnerName="apiserver"}
 //
+// A netflow:summary query is a LogQL log query selecting the flows to summarize.
+// The store converts it to LogQL metric queries, flows are not returned.
+// Results are sorted by bytes, largest first. Example:
+//
+//	netflow:summary:{SrcK8S_Namespace="myNamespace"}
+//
 // # Store
 //
 // To connect to a netflow lokiStack store use this configuration:
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/loki"
@@ -20,7 +21,10 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
 )
 
-var _ = impl.AssertDomainTypes(Domain, Object{}, Class{}, Query(""), &stackStore{})
+var (
+	_ = impl.AssertDomainTypes(Domain, Object{}, Class{}, Query(""), &stackStore{})
+	_ = impl.AssertDomainTypes(Domain, SummaryObject(nil), SummaryClass{}, SummaryQuery(""), &stackStore{})
+)
 
 // Domain for log records produced by openshift-logging.
 //
@@ -32,15 +36,18 @@ var _ = impl.AssertDomainTypes(Domain, Object{}, Class{}, Query(""), &stackStore
 //go:embed doc.md
 var description string
 
-var Domain = domain{Domain: impl.NewDomain("netflow", description, Class{})}
+var Domain = domain{Domain: impl.NewDomain("netflow", description, Class{}, SummaryClass{})}
 
 type domain struct{ *impl.Domain }
 
 func (d domain) Query(s string) (korrel8r.Query, error) {
-	_, s, err := impl.ParseQuery(d, s)
+	c, s, err := impl.ParseQuery(d, s)
 	if err != nil {
 		return nil, err
 	}
+	if c == (SummaryClass{}) {
+		return SummaryQuery(strings.TrimSpace(s)), nil
+	}
 	return Query(s), nil
 }
 
@@ -87,11 +94,12 @@ func (domain) Store(s any) (korrel8r.Store, error) {
 func (domain) TemplateFuncs() map[string]any {
 	return map[string]any{
 		// Convert a netflow type field to a k8s class.
-		// Need to add the "apps" group to the Deployment type.
+		// Need to add the "apps" group to workload types.
 		"netflowTypeToK8s": func(t string) (string, error) {
-			if t == "Deployment" {
-				return "k8s:Deployment.v1.apps", nil
-			} else {
+			switch t {
+			case "Deployment", "DaemonSet", "ReplicaSet", "StatefulSet":
+				return fmt.Sprintf("k8s:%v.v1.apps", t), nil
+			default:
 				return fmt.Sprintf("k8s:%v.v1", t), nil
 			}
 		},
@@ -162,6 +170,9 @@ type store struct {
 
 func (store) Domain() korrel8r.Domain { return Domain }
 func (s *store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	if q, ok := query.(SummaryQuery); ok {
+		return getSummary(ctx, q, c, s.Query, result)
+	}
 	q, err := impl.TypeAssert[Query](query)
 	if err != nil {
 		return err
@@ -173,6 +184,11 @@ type stackStore struct{ store }
 
 func (stackStore) Domain() korrel8r.Domain { return Domain }
 func (s *stackStore) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	if q, ok := query.(SummaryQuery); ok {
+		return getSummary(ctx, q, c, func(ctx context.Context, logQL string, at time.Time) ([]loki.Sample, error) {
+			return s.QueryStack(ctx, logQL, "network", at)
+		}, result)
+	}
 	q, err := impl.TypeAssert[Query](query)
 	if err != nil {
 		return err
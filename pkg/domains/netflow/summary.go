@@ -0,0 +1,159 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package netflow
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/loki"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+)
+
+const summaryName = "summary"
+
+// SummaryClass represents flows aggregated by source and destination workload. There is only a single summary class, named "summary".
+type SummaryClass struct{}
+
+func (c SummaryClass) Domain() korrel8r.Domain { return Domain }
+func (c SummaryClass) Name() string            { return summaryName }
+func (c SummaryClass) String() string          { return korrel8r.ClassString(c) }
+
+func (c SummaryClass) Unmarshal(data []byte) (korrel8r.Object, error) {
+	return impl.UnmarshalAs[SummaryObject](data)
+}
+
+func (c SummaryClass) Preview(o korrel8r.Object) string {
+	return impl.Preview(o, func(o SummaryObject) string { return o.String() })
+}
+
+// ID is the pair of endpoints, there is one summary for each pair in a result.
+func (c SummaryClass) ID(o korrel8r.Object) any {
+	if s, _ := o.(SummaryObject); s != nil {
+		return [2]Endpoint{s.Src, s.Dst}
+	}
+	return nil
+}
+
+// SummaryObject is a flow summary, passed as *Summary when used as a korrel8r.Object.
+type SummaryObject = *Summary
+
+// Endpoint identifies one end of a flow by the owning workload.
+// Fields are empty for endpoints outside the cluster.
+type Endpoint struct {
+	Namespace string `json:"namespace,omitempty"`
+	OwnerName string `json:"ownerName,omitempty"`
+	OwnerType string `json:"ownerType,omitempty"`
+}
+
+func (e Endpoint) String() string {
+	switch {
+	case e.OwnerName == "":
+		return "(external)"
+	case e.Namespace == "":
+		return fmt.Sprintf("%v/%v", e.OwnerType, e.OwnerName)
+	default:
+		return fmt.Sprintf("%v/%v/%v", e.OwnerType, e.Namespace, e.OwnerName)
+	}
+}
+
+// Summary is the total traffic between a source and destination endpoint in a time window.
+type Summary struct {
+	Src     Endpoint  `json:"src"`
+	Dst     Endpoint  `json:"dst"`
+	Bytes   int64     `json:"bytes"`
+	Packets int64     `json:"packets"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+func (s *Summary) String() string {
+	return fmt.Sprintf("%v -> %v bytes=%v packets=%v", s.Src, s.Dst, s.Bytes, s.Packets)
+}
+
+// SummaryQuery is a LogQL log query selecting the flows to summarize.
+type SummaryQuery string
+
+func (q SummaryQuery) Class() korrel8r.Class { return SummaryClass{} }
+func (q SummaryQuery) Data() string          { return string(q) }
+func (q SummaryQuery) String() string        { return korrel8r.QueryString(q) }
+
+// Default summary window if the constraint has no start time.
+const defaultWindow = time.Hour
+
+var (
+	hasJSON     = regexp.MustCompile(`\|\s*json\b`)
+	summaryKeys = []string{
+		"SrcK8S_Namespace", "SrcK8S_OwnerName", "SrcK8S_OwnerType",
+		"DstK8S_Namespace", "DstK8S_OwnerName", "DstK8S_OwnerType",
+	}
+)
+
+// metricQuery returns a LogQL metric query that sums field over the window, grouped by endpoints.
+// Flows marked as duplicates by the network observability agent are not counted.
+func (q SummaryQuery) metricQuery(field string, window time.Duration) string {
+	logQL := strings.TrimSpace(string(q))
+	if !hasJSON.MatchString(logQL) {
+		logQL += "|json"
+	}
+	return fmt.Sprintf(`sum by (%v) (sum_over_time(%v|Duplicate!="true"|unwrap %v|__error__=""[%vs]))`,
+		strings.Join(summaryKeys, ","), logQL, field, int64(window.Seconds()))
+}
+
+// window returns the summary time window for a constraint.
+func window(c *korrel8r.Constraint) (start, end time.Time) {
+	end = c.GetEnd()
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start = c.GetStart()
+	if start.IsZero() || !start.Before(end) {
+		start = end.Add(-defaultWindow)
+	}
+	return start, end
+}
+
+// getSummary evaluates byte and packet totals using query, and appends summaries sorted by bytes.
+func getSummary(ctx context.Context, q SummaryQuery, c *korrel8r.Constraint, query func(ctx context.Context, logQL string, at time.Time) ([]loki.Sample, error), result korrel8r.Appender) error {
+	start, end := window(c)
+	summaries := map[[2]Endpoint]*Summary{}
+	for _, field := range []string{"Bytes", "Packets"} {
+		samples, err := query(ctx, q.metricQuery(field, end.Sub(start)), end)
+		if err != nil {
+			return err
+		}
+		for _, s := range samples {
+			src := Endpoint{s.Labels["SrcK8S_Namespace"], s.Labels["SrcK8S_OwnerName"], s.Labels["SrcK8S_OwnerType"]}
+			dst := Endpoint{s.Labels["DstK8S_Namespace"], s.Labels["DstK8S_OwnerName"], s.Labels["DstK8S_OwnerType"]}
+			key := [2]Endpoint{src, dst}
+			sum := summaries[key]
+			if sum == nil {
+				sum = &Summary{Src: src, Dst: dst, Start: start, End: end}
+				summaries[key] = sum
+			}
+			if field == "Bytes" {
+				sum.Bytes = int64(s.Value)
+			} else {
+				sum.Packets = int64(s.Value)
+			}
+		}
+	}
+	list := slices.SortedFunc(maps.Values(summaries), func(a, b *Summary) int {
+		return cmp.Or(cmp.Compare(b.Bytes, a.Bytes), cmp.Compare(b.Packets, a.Packets),
+			strings.Compare(a.String(), b.String()))
+	})
+	if limit := c.GetLimit(); limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	for _, s := range list {
+		result.Append(s)
+	}
+	return nil
+}
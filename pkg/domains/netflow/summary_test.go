@@ -0,0 +1,94 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package netflow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomain_Query_summary(t *testing.T) {
+	q, err := Domain.Query(`netflow:summary:{SrcK8S_Namespace="a"}`)
+	require.NoError(t, err)
+	assert.Equal(t, SummaryQuery(`{SrcK8S_Namespace="a"}`), q)
+}
+
+func TestSummaryQuery_metricQuery(t *testing.T) {
+	const by = `sum by (SrcK8S_Namespace,SrcK8S_OwnerName,SrcK8S_OwnerType,DstK8S_Namespace,DstK8S_OwnerName,DstK8S_OwnerType)`
+	assert.Equal(t, by+` (sum_over_time({SrcK8S_Namespace="a"}|json|Duplicate!="true"|unwrap Bytes|__error__=""[3600s]))`,
+		SummaryQuery(`{SrcK8S_Namespace="a"}`).metricQuery("Bytes", time.Hour))
+	assert.Equal(t, by+` (sum_over_time({SrcK8S_Namespace="a"} | json | SrcK8S_Name="p"|Duplicate!="true"|unwrap Packets|__error__=""[60s]))`,
+		SummaryQuery(`{SrcK8S_Namespace="a"} | json | SrcK8S_Name="p"`).metricQuery("Packets", time.Minute))
+}
+
+func TestStore_Get_summary(t *testing.T) {
+	var paths []string
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		got = r.URL.Query()
+		value := func(bytes, packets string) string {
+			if strings.Contains(got.Get("query"), "unwrap Bytes") {
+				return bytes
+			}
+			return packets
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+{"metric":{"SrcK8S_Namespace":"a","SrcK8S_OwnerName":"web","SrcK8S_OwnerType":"Deployment","DstK8S_Namespace":"b","DstK8S_OwnerName":"db","DstK8S_OwnerType":"StatefulSet"},
+ "value":[1740826800,"` + value("1000", "10") + `"]},
+{"metric":{"SrcK8S_Namespace":"a","SrcK8S_OwnerName":"web","SrcK8S_OwnerType":"Deployment"},
+ "value":[1740826800,"` + value("5000", "20") + `"]}]}}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	for _, x := range []struct {
+		name     string
+		newStore func(*url.URL, *http.Client) (korrel8r.Store, error)
+		path     string
+	}{
+		{"plain", NewPlainLokiStore, "/loki/api/v1/query"},
+		{"stack", NewLokiStackStore, "/api/logs/v1/network/loki/api/v1/query"},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			paths = nil
+			s, err := x.newStore(u, server.Client())
+			require.NoError(t, err)
+			r := result.New(SummaryClass{})
+			require.NoError(t, s.Get(context.Background(), SummaryQuery(`{SrcK8S_Namespace="a"}`), &korrel8r.Constraint{Start: &start, End: &end}, r))
+			assert.Equal(t, []string{x.path, x.path}, paths)
+			assert.Equal(t, "1740826800000000000", got.Get("time"))
+			assert.Contains(t, got.Get("query"), "[3600s]")
+			var previews []string
+			for _, o := range r.List() {
+				previews = append(previews, SummaryClass{}.Preview(o))
+			}
+			assert.Equal(t, []string{
+				"Deployment/a/web -> (external) bytes=5000 packets=20",
+				"Deployment/a/web -> StatefulSet/b/db bytes=1000 packets=10",
+			}, previews)
+			assert.Equal(t, &Summary{
+				Src:   Endpoint{Namespace: "a", OwnerName: "web", OwnerType: "Deployment"},
+				Dst:   Endpoint{Namespace: "b", OwnerName: "db", OwnerType: "StatefulSet"},
+				Bytes: 1000, Packets: 10, Start: start, End: end,
+			}, r.List()[1])
+
+			r = result.New(SummaryClass{})
+			require.NoError(t, s.Get(context.Background(), SummaryQuery(`{SrcK8S_Namespace="a"}`), &korrel8r.Constraint{Limit: new(1)}, r))
+			assert.Len(t, r.List(), 1)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package profile is a korrel8r domain for continuous profiles.
+//
+// Profiles are collected by a [Pyroscope] compatible profiling server.
+//
+// # Classes
+//
+//	profile:profile
+//
+// # Object
+//
+// A profile object is a profile series: a profile type and a set of labels.
+//
+//	{"profileType": "process_cpu:cpu:nanoseconds:cpu:nanoseconds", "labels": {"namespace": "default", "pod": "my-pod", "service_name": "my-service"}}
+//
+// Use the profile type and labels to view the profile data in a profiling UI.
+//
+// # Query
+//
+// Queries use the Pyroscope query syntax: a profile type ID followed by a label selector.
+//
+//	PROFILE_TYPE{LABEL_MATCHERS}
+//
+// The profile type is optional, if omitted series of all profile types are returned.
+// Label matchers are the same as Prometheus label matchers: `=`, `!=`, `=~`, `!~`.
+//
+// CPU profiles for a pod:
+//
+//	profile:profile:process_cpu:cpu:nanoseconds:cpu:nanoseconds{namespace="default", pod="my-pod"}
+//
+// All profiles for a span:
+//
+//	profile:profile:{service_name="my-service", span_id="0123456789abcdef"}
+//
+// # Store
+//
+// A client of the Pyroscope HTTP query API. Store configuration:
+//
+//	domain: profile
+//	pyroscope: PYROSCOPE_URL
+//	tenant: TENANT_ID # Optional, sets the X-Scope-OrgID header for multi-tenant servers.
+//
+// For testing without a server, a mock store loads profile series from a file:
+//
+//	domain: profile
+//	mockData: FILE
+//
+// [Pyroscope]: https://grafana.com/docs/pyroscope/latest/
+package profile
@@ -0,0 +1,251 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package profile
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+)
+
+var _ = impl.AssertDomainTypes(Domain, Object(nil), Class{}, Query(""), &Store{})
+
+//go:embed doc.md
+var description string
+
+const name = "profile"
+
+var Domain = &domain{impl.NewDomain(name, description, Class{})}
+
+type domain struct{ *impl.Domain }
+
+func (d *domain) Query(s string) (korrel8r.Query, error) {
+	_, qs, err := impl.ParseQuery(d, s)
+	if err != nil {
+		return nil, err
+	}
+	q := Query(strings.TrimSpace(qs))
+	if _, _, err := q.Parse(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+const (
+	StoreKeyPyroscope = "pyroscope"
+	StoreKeyTenant    = "tenant"
+)
+
+func (*domain) Store(s any) (korrel8r.Store, error) {
+	cs, err := impl.TypeAssert[config.Store](s)
+	if err != nil {
+		return nil, err
+	}
+	pyroscope := cs[StoreKeyPyroscope]
+	if pyroscope == "" {
+		return nil, fmt.Errorf("must set %v URL", StoreKeyPyroscope)
+	}
+	u, err := url.Parse(pyroscope)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := k8s.NewHTTPClient(cs)
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(u, cs[StoreKeyTenant], hc), nil
+}
+
+// Class represents any profile series. There is only a single class, named "profile".
+type Class struct{}
+
+func (c Class) Domain() korrel8r.Domain                     { return Domain }
+func (c Class) Name() string                                { return name }
+func (c Class) String() string                              { return korrel8r.ClassString(c) }
+func (c Class) Unmarshal(b []byte) (korrel8r.Object, error) { return impl.UnmarshalAs[Object](b) }
+func (c Class) ID(o korrel8r.Object) any {
+	if o, _ := o.(Object); o != nil {
+		return o.String()
+	}
+	return nil
+}
+
+func (c Class) Preview(o korrel8r.Object) string {
+	return impl.Preview(o, func(o Object) string { return o.String() })
+}
+
+// Object is a profile series, passed as *Series when used as a korrel8r.Object.
+type Object = *Series
+
+// Series identifies a profile series by profile type and labels.
+type Series struct {
+	// ProfileType ID, for example "process_cpu:cpu:nanoseconds:cpu:nanoseconds".
+	ProfileType string `json:"profileType"`
+	// Labels of the series.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// String returns the series in query form: PROFILE_TYPE{LABELS}
+func (s *Series) String() string {
+	w := &strings.Builder{}
+	w.WriteString(s.ProfileType)
+	w.WriteByte('{')
+	for i, k := range slices.Sorted(maps.Keys(s.Labels)) {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		fmt.Fprintf(w, "%v=%q", k, s.Labels[k])
+	}
+	w.WriteByte('}')
+	return w.String()
+}
+
+// Query is a Pyroscope query: PROFILE_TYPE{LABEL_MATCHERS}
+type Query string
+
+func (q Query) Class() korrel8r.Class { return Class{} }
+func (q Query) Data() string          { return string(q) }
+func (q Query) String() string        { return korrel8r.QueryString(q) }
+
+// Parse the query into a profile type (may be empty) and a label selector.
+func (q Query) Parse() (profileType, selector string, err error) {
+	s := strings.TrimSpace(string(q))
+	i := strings.IndexByte(s, '{')
+	if i < 0 {
+		return s, "{}", nil
+	}
+	if !strings.HasSuffix(s, "}") {
+		return "", "", fmt.Errorf("invalid profile query, missing '}': %v", s)
+	}
+	return strings.TrimSpace(s[:i]), s[i:], nil
+}
+
+// matcher returns a Pyroscope series matcher for the query.
+func (q Query) matcher() (string, error) {
+	profileType, selector, err := q.Parse()
+	if err != nil || profileType == "" {
+		return selector, err
+	}
+	m := fmt.Sprintf("%v=%q", labelProfileType, profileType)
+	if inner := strings.TrimSpace(selector[1 : len(selector)-1]); inner != "" {
+		m += ", " + inner
+	}
+	return "{" + m + "}", nil
+}
+
+// Labels with special meaning to Pyroscope.
+const (
+	labelProfileType = "__profile_type__"
+	labelPrefix      = "__" // Reserved labels are not included in Series.Labels
+)
+
+// Store is a client of the Pyroscope HTTP query API.
+type Store struct {
+	*impl.Store
+	base   *url.URL
+	tenant string
+	hc     *http.Client
+}
+
+// NewStore returns a store for the Pyroscope server at base.
+// If tenant is not empty it is sent in the X-Scope-OrgID header.
+func NewStore(base *url.URL, tenant string, hc *http.Client) *Store {
+	return &Store{Store: impl.NewStore(Domain), base: base, tenant: tenant, hc: hc}
+}
+
+// seriesRequest is the JSON form of a querier.v1.SeriesRequest.
+type seriesRequest struct {
+	Matchers []string `json:"matchers"`
+	Start    int64    `json:"start,omitempty"` // Milliseconds since the epoch.
+	End      int64    `json:"end,omitempty"`   // Milliseconds since the epoch.
+}
+
+// seriesResponse is the JSON form of a querier.v1.SeriesResponse.
+type seriesResponse struct {
+	LabelsSet []struct {
+		Labels []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"labels"`
+	} `json:"labelsSet"`
+}
+
+func (s *Store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	q, err := impl.TypeAssert[Query](query)
+	if err != nil {
+		return err
+	}
+	matcher, err := q.matcher()
+	if err != nil {
+		return err
+	}
+	req := seriesRequest{Matchers: []string{matcher}}
+	if start := c.GetStart(); !start.IsZero() {
+		req.Start = start.UnixMilli()
+	}
+	if end := c.GetEnd(); !end.IsZero() {
+		req.End = end.UnixMilli()
+	}
+	var resp seriesResponse
+	if err := s.post(ctx, "querier.v1.QuerierService/Series", req, &resp); err != nil {
+		return err
+	}
+	for i, ls := range resp.LabelsSet {
+		if limit := c.GetLimit(); limit > 0 && i >= limit {
+			break
+		}
+		series := &Series{Labels: map[string]string{}}
+		for _, l := range ls.Labels {
+			switch {
+			case l.Name == labelProfileType:
+				series.ProfileType = l.Value
+			case !strings.HasPrefix(l.Name, labelPrefix):
+				series.Labels[l.Name] = l.Value
+			}
+		}
+		result.Append(series)
+	}
+	return nil
+}
+
+// post a JSON request to a Connect API endpoint and decode the response.
+func (s *Store) post(ctx context.Context, path string, body, response any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	u := s.base.JoinPath(path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.tenant != "" {
+		req.Header.Set("X-Scope-OrgID", s.tenant)
+	}
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		if b, err := io.ReadAll(resp.Body); err == nil && len(b) > 0 {
+			return fmt.Errorf("%v: %v", resp.Status, string(b))
+		}
+		return fmt.Errorf("%v", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
@@ -0,0 +1,18 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package profile_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
+	"github.com/korrel8r/korrel8r/pkg/domains/profile"
+)
+
+var fixture = domain.Fixture{
+	Query:        profile.Query(`{}`),
+	ClusterSetup: func(testing.TB) bool { return false },
+}
+
+func TestProfileDomain(t *testing.T)      { fixture.Test(t) }
+func BenchmarkProfileDomain(b *testing.B) { fixture.Benchmark(b) }
@@ -0,0 +1,82 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package profile
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_Parse(t *testing.T) {
+	for _, x := range []struct {
+		query, profileType, selector, matcher string
+	}{
+		{`process_cpu:cpu:nanoseconds:cpu:nanoseconds{pod="x"}`, "process_cpu:cpu:nanoseconds:cpu:nanoseconds", `{pod="x"}`,
+			`{__profile_type__="process_cpu:cpu:nanoseconds:cpu:nanoseconds", pod="x"}`},
+		{`memory:inuse_space:bytes:space:bytes{}`, "memory:inuse_space:bytes:space:bytes", `{}`,
+			`{__profile_type__="memory:inuse_space:bytes:space:bytes"}`},
+		{`{service_name="a", span_id="b"}`, "", `{service_name="a", span_id="b"}`, `{service_name="a", span_id="b"}`},
+		{`memory:inuse_space:bytes:space:bytes`, "memory:inuse_space:bytes:space:bytes", `{}`,
+			`{__profile_type__="memory:inuse_space:bytes:space:bytes"}`},
+	} {
+		t.Run(x.query, func(t *testing.T) {
+			q, err := Domain.Query("profile:profile:" + x.query)
+			require.NoError(t, err)
+			profileType, selector, err := q.(Query).Parse()
+			require.NoError(t, err)
+			assert.Equal(t, x.profileType, profileType)
+			assert.Equal(t, x.selector, selector)
+			matcher, err := q.(Query).matcher()
+			require.NoError(t, err)
+			assert.Equal(t, x.matcher, matcher)
+		})
+	}
+	_, err := Domain.Query(`profile:profile:{pod="x"`)
+	assert.ErrorContains(t, err, "missing '}'")
+}
+
+func TestSeries_String(t *testing.T) {
+	s := &Series{ProfileType: "cpu", Labels: map[string]string{"pod": "x", "namespace": "y"}}
+	assert.Equal(t, `cpu{namespace="y",pod="x"}`, s.String())
+}
+
+func TestStore_Get(t *testing.T) {
+	var got seriesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/querier.v1.QuerierService/Series", r.URL.Path)
+		assert.Equal(t, "tenant1", r.Header.Get("X-Scope-OrgID"))
+		b, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(b, &got))
+		_, _ = w.Write([]byte(`{"labelsSet":[
+{"labels":[{"name":"__name__","value":"process_cpu"},{"name":"__profile_type__","value":"process_cpu:cpu:nanoseconds:cpu:nanoseconds"},{"name":"pod","value":"a"}]},
+{"labels":[{"name":"__name__","value":"memory"},{"name":"__profile_type__","value":"memory:inuse_space:bytes:space:bytes"},{"name":"pod","value":"a"}]}
+]}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	s := NewStore(u, "tenant1", server.Client())
+
+	start, end := time.UnixMilli(1000), time.UnixMilli(2000)
+	r := result.New(Class{})
+	require.NoError(t, s.Get(context.Background(), Query(`{pod="a"}`), &korrel8r.Constraint{Start: &start, End: &end}, r))
+	assert.Equal(t, seriesRequest{Matchers: []string{`{pod="a"}`}, Start: 1000, End: 2000}, got)
+	assert.Equal(t, []korrel8r.Object{
+		&Series{ProfileType: "process_cpu:cpu:nanoseconds:cpu:nanoseconds", Labels: map[string]string{"pod": "a"}},
+		&Series{ProfileType: "memory:inuse_space:bytes:space:bytes", Labels: map[string]string{"pod": "a"}},
+	}, r.List())
+
+	r = result.New(Class{})
+	require.NoError(t, s.Get(context.Background(), Query(`{pod="a"}`), &korrel8r.Constraint{Limit: new(1)}, r))
+	assert.Len(t, r.List(), 1)
+}
@@ -2,16 +2,21 @@
 
 // Package trace is a korrel8r domain for OpenTelemetry traces.
 //
-// OpenTelemetry [traces] stored in the Grafana [Tempo] data store.
+// OpenTelemetry [traces] stored in the Grafana [Tempo] or [Jaeger] data stores.
 //
 // # Classes
 //
 //	trace:span
+//	trace:trace
 //
 // # Object
 //
-// Represents a [span].
-// A trace is a set of spans with the same trace-id, there is no explicit class representing a trace.
+// A trace:span object represents a [span].
+//
+// A trace:trace object represents a complete trace: all the spans with the same trace-id.
+// Spans are arranged as a tree using the parent span ID.
+// A trace also lists the spans on the critical path, which determine the duration of the trace,
+// and the spans with error status.
 //
 // # Query
 //
@@ -30,16 +35,41 @@
 //
 //	trace:span:a7880cc221e84e0d07b15993358811b7,b7880cc221e84e0d07b15993358811b7
 //
+// A trace:trace query has the same forms, it returns each complete trace that contains a selected span.
+// Example:
+//
+//	trace:trace:{resource.k8s.namespace.name="korrel8r"}
+//
 // # Store
 //
-// The trace domain accepts an optional "tempoStack" field with a URL to connect.
+// The trace domain requires exactly one of the following fields with the URL of a store:
+//   - "tempoStack": TempoStack gateway search URL, including the tenant path.
+//   - "tempo": root URL of a plain Tempo server. Optional "tenant" sets the X-Scope-OrgID header.
+//   - "jaeger": root URL of the Jaeger query service HTTP API, usually port 16686.
+//
+// Examples:
 //
 //	stores:
-//	    domain: trace
-//	    tempoStack: "https://url-of-tempostack"
+//	  - domain: trace
+//	    tempoStack: "https://url-of-tempostack/api/traces/v1/platform/tempo/api/search"
+//	  - domain: trace
+//	    tempo: "http://tempo:3200"
+//	    tenant: "my-tenant"
+//	  - domain: trace
+//	    jaeger: "http://jaeger-query:16686"
+//
+// Jaeger does not support TraceQL, the Jaeger store translates a subset of TraceQL:
+// a set of equality matchers joined by &&, which is the form generated by korrel8r rules.
+// Matchers for resource.service.name and name select the Jaeger service and operation,
+// status=error selects spans with the Jaeger error tag, other attribute matchers are Jaeger tags.
+// If there is no service matcher, each service is searched.
+// The Jaeger gRPC query API is not supported, use the HTTP API.
+//
+// Trace-id queries are supported by all stores.
 //
 // [traces]: https://opentelemetry.io/docs/concepts/signals/traces
 // [Tempo]: https://grafana.com/docs/tempo/latest/
+// [Jaeger]: https://www.jaegertracing.io/
 // [span]: https://opentelemetry.io/docs/concepts/signals/traces/#spans
 // [TraceQL]: https://grafana.com/docs/tempo/latest/traceql/
 package trace
@@ -0,0 +1,295 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/otel"
+)
+
+// NewJaegerStore returns a store for the Jaeger query service HTTP API at base.
+//
+// Jaeger does not support TraceQL, the store translates a subset of TraceQL to Jaeger search parameters.
+// A query is a set of equality matchers joined by &&, for example:
+//
+//	{resource.service.name="frontend" && resource.k8s.pod.name="frontend-1" && status=error}
+//
+// Matchers for resource.service.name and name select the Jaeger service and operation,
+// status=error matches the Jaeger error tag, other attribute matchers are Jaeger tags.
+// If there is no service matcher, each service known to Jaeger is searched.
+func NewJaegerStore(base *url.URL, h *http.Client) (korrel8r.Store, error) {
+	return &jaegerStore{Store: impl.NewStore(Domain), base: base, hc: h}, nil
+}
+
+type jaegerStore struct {
+	*impl.Store
+	base *url.URL
+	hc   *http.Client
+}
+
+func (s *jaegerStore) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	if q, ok := query.(TraceQuery); ok {
+		return s.getTraces(ctx, q.SpanQuery(), c, func(jt *jaegerTrace) {
+			var spans []*Span
+			jt.collect(func(s *Span) { spans = append(spans, s) })
+			if t := NewTrace(spans); t != nil {
+				result.Append(t)
+			}
+		})
+	}
+	q, err := impl.TypeAssert[Query](query)
+	if err != nil {
+		return err
+	}
+	return s.getTraces(ctx, q, c, func(jt *jaegerTrace) { jt.collect(func(s *Span) { result.Append(s) }) })
+}
+
+// getTraces gets traces by ID, or searches for traces with spans matching a query.
+// Traces by ID are fetched in parallel.
+// Jaeger always returns complete traces.
+func (s *jaegerStore) getTraces(ctx context.Context, q Query, c *korrel8r.Constraint, collect func(*jaegerTrace)) error {
+	collectAll := func(traces []jaegerTrace) {
+		for i := range traces {
+			collect(&traces[i])
+		}
+	}
+	if ids := q.TraceIDs(); ids != nil {
+		return fetchTraces(ctx, ids, func(ctx context.Context, id TraceID) ([]jaegerTrace, error) {
+			var r jaegerResponse[[]jaegerTrace]
+			err := s.get(ctx, "api/traces/"+url.PathEscape(string(id)), nil, &r)
+			return r.Data, err
+		}, collectAll)
+	}
+	jq, err := parseJaegerQuery(q.Data())
+	if err != nil {
+		return err
+	}
+	services := []string{jq.service}
+	if jq.service == "" {
+		var r jaegerResponse[[]string]
+		if err := s.get(ctx, "api/services", nil, &r); err != nil {
+			return err
+		}
+		services = r.Data
+	}
+	params := jq.params(c)
+	limit, count := c.GetLimit(), 0 // Limit is max number of traces, not spans.
+	for _, service := range services {
+		if limit > 0 && count >= limit {
+			break
+		}
+		params.Set("service", service)
+		if limit > 0 {
+			params.Set("limit", strconv.Itoa(limit-count))
+		}
+		var r jaegerResponse[[]jaegerTrace]
+		if err := s.get(ctx, "api/traces", params, &r); err != nil {
+			return err
+		}
+		count += len(r.Data)
+		collectAll(r.Data)
+	}
+	return nil
+}
+
+func (s *jaegerStore) get(ctx context.Context, path string, params url.Values, body interface{ error() error }) error {
+	u := s.base.JoinPath(path)
+	u.RawQuery = params.Encode()
+	if err := impl.Get(ctx, u, s.hc, body); err != nil {
+		return err
+	}
+	return body.error()
+}
+
+// jaegerQuery holds Jaeger search parameters translated from TraceQL.
+type jaegerQuery struct {
+	service, operation string
+	tags               map[string]string
+}
+
+var (
+	// jaegerMatcher matches a leading TraceQL equality matcher with a quoted or literal value.
+	jaegerMatcher = regexp.MustCompile(`^\s*([\w.:]+)\s*=\s*("(?:[^"\\]|\\.)*"|[\w.+-]+)\s*(&&|$)`)
+	scopePrefix   = regexp.MustCompile(`^(resource|span)?\.`)
+)
+
+// parseJaegerQuery translates a TraceQL query of the form {matcher && ...} to Jaeger search parameters.
+func parseJaegerQuery(traceQL string) (*jaegerQuery, error) {
+	unsupported := func() error { return fmt.Errorf("TraceQL query not supported by Jaeger store: %v", traceQL) }
+	s, ok := strings.CutPrefix(strings.TrimSpace(traceQL), "{")
+	if !ok {
+		return nil, unsupported()
+	}
+	if s, ok = strings.CutSuffix(s, "}"); !ok {
+		return nil, unsupported()
+	}
+	jq := &jaegerQuery{tags: map[string]string{}}
+	for s = strings.TrimSpace(s); s != ""; {
+		m := jaegerMatcher.FindStringSubmatch(s)
+		if m == nil {
+			return nil, unsupported()
+		}
+		s = strings.TrimSpace(s[len(m[0]):])
+		name, value := m[1], m[2]
+		if v, err := strconv.Unquote(value); err == nil {
+			value = v
+		}
+		switch {
+		case name == "name":
+			jq.operation = value
+		case name == "status" && value == "error":
+			jq.tags[jaegerTagError] = "true"
+		case scopePrefix.MatchString(name):
+			if name = scopePrefix.ReplaceAllString(name, ""); name == otel.AttrServiceName {
+				jq.service = value
+			} else {
+				jq.tags[name] = value
+			}
+		default:
+			return nil, unsupported()
+		}
+	}
+	return jq, nil
+}
+
+// params returns Jaeger search parameters, except for the service.
+func (jq *jaegerQuery) params(c *korrel8r.Constraint) url.Values {
+	v := url.Values{}
+	if jq.operation != "" {
+		v.Set("operation", jq.operation)
+	}
+	if len(jq.tags) > 0 {
+		b, _ := json.Marshal(jq.tags)
+		v.Set("tags", string(b))
+	}
+	start, end := c.GetStart(), c.GetEnd()
+	if !start.IsZero() && end.IsZero() { // Can't have start without end.
+		end = time.Now()
+	}
+	if !start.IsZero() {
+		v.Set("start", strconv.FormatInt(start.UnixMicro(), 10))
+	}
+	if !end.IsZero() {
+		v.Set("end", strconv.FormatInt(end.UnixMicro(), 10))
+	}
+	return v
+}
+
+// Note: jaeger types are for decoding the Jaeger query service response, converted to Span for korrel8r.
+
+type jaegerResponse[T any] struct {
+	Data   T `json:"data"`
+	Errors []struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"errors"`
+}
+
+func (r *jaegerResponse[T]) error() error {
+	if len(r.Errors) > 0 {
+		return fmt.Errorf("jaeger error %v: %v", r.Errors[0].Code, r.Errors[0].Msg)
+	}
+	return nil
+}
+
+type jaegerTrace struct {
+	TraceID   TraceID                  `json:"traceID"`
+	Spans     []jaegerSpan             `json:"spans"`
+	Processes map[string]jaegerProcess `json:"processes"`
+}
+
+type jaegerSpan struct {
+	TraceID       TraceID           `json:"traceID"`
+	SpanID        SpanID            `json:"spanID"`
+	OperationName string            `json:"operationName"`
+	References    []jaegerReference `json:"references"`
+	StartTime     int64             `json:"startTime"` // Microseconds since the epoch.
+	Duration      int64             `json:"duration"`  // Microseconds.
+	Tags          []jaegerKeyValue  `json:"tags"`
+	ProcessID     string            `json:"processID"`
+}
+
+type jaegerReference struct {
+	RefType string  `json:"refType"`
+	TraceID TraceID `json:"traceID"`
+	SpanID  SpanID  `json:"spanID"`
+}
+
+type jaegerProcess struct {
+	ServiceName string           `json:"serviceName"`
+	Tags        []jaegerKeyValue `json:"tags"`
+}
+
+type jaegerKeyValue struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// Jaeger tags that are converted to span status.
+const (
+	jaegerTagError             = "error"
+	jaegerTagStatusCode        = "otel.status_code"
+	jaegerTagStatusDescription = "otel.status_description"
+)
+
+func addTags(m map[string]any, tags []jaegerKeyValue) {
+	for _, kv := range tags {
+		if f, ok := kv.Value.(float64); ok && kv.Type == "int64" {
+			m[kv.Key] = int64(f)
+		} else {
+			m[kv.Key] = kv.Value
+		}
+	}
+}
+
+// collect calls collect() on each *Span.
+func (t *jaegerTrace) collect(collect func(*Span)) {
+	for _, js := range t.Spans {
+		start := time.UnixMicro(js.StartTime)
+		span := &Span{
+			Name:       js.OperationName,
+			Context:    SpanContext{TraceID: js.TraceID, SpanID: js.SpanID},
+			StartTime:  start,
+			EndTime:    start.Add(time.Duration(js.Duration) * time.Microsecond),
+			Attributes: map[string]any{},
+			Status:     Status{Code: StatusUnset},
+		}
+		for _, ref := range js.References {
+			if ref.RefType == "CHILD_OF" && ref.TraceID == js.TraceID {
+				span.ParentID = &ref.SpanID
+				break
+			}
+		}
+		if p, ok := t.Processes[js.ProcessID]; ok {
+			addTags(span.Attributes, p.Tags)
+			span.Attributes[otel.AttrServiceName] = p.ServiceName
+		}
+		addTags(span.Attributes, js.Tags)
+		// Jaeger stores span status as tags, move them to the status field.
+		switch code, _ := span.Attributes[jaegerTagStatusCode].(string); {
+		case code == "ERROR":
+			span.Status.Code = StatusError
+		case code == "OK":
+			span.Status.Code = StatusOK
+		case span.Attributes[jaegerTagError] == true || span.Attributes[jaegerTagError] == "true":
+			span.Status.Code = StatusError
+		}
+		span.Status.Description, _ = span.Attributes[jaegerTagStatusDescription].(string)
+		delete(span.Attributes, jaegerTagError)
+		delete(span.Attributes, jaegerTagStatusCode)
+		delete(span.Attributes, jaegerTagStatusDescription)
+		collect(span)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package trace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJaegerQuery(t *testing.T) {
+	for _, x := range []struct {
+		traceQL string
+		want    *jaegerQuery
+	}{
+		{`{}`, &jaegerQuery{tags: map[string]string{}}},
+		{`{resource.k8s.namespace.name="ns"&&resource.k8s.pod.name="p"}`,
+			&jaegerQuery{tags: map[string]string{"k8s.namespace.name": "ns", "k8s.pod.name": "p"}}},
+		{`{ resource.service.name = "frontend" && name="GET /" && status=error && span.http.status_code=500 && .x="a\"b" }`,
+			&jaegerQuery{service: "frontend", operation: "GET /",
+				tags: map[string]string{"error": "true", "http.status_code": "500", "x": `a"b`}}},
+	} {
+		t.Run(x.traceQL, func(t *testing.T) {
+			got, err := parseJaegerQuery(x.traceQL)
+			require.NoError(t, err)
+			assert.Equal(t, x.want, got)
+		})
+	}
+	for _, traceQL := range []string{
+		`resource.service.name="x"`,
+		`{resource.service.name="x" || resource.service.name="y"}`,
+		`{duration>1s}`,
+		`{status=ok}`,
+		`{.a="x" .b="y"}`,
+		`{.a="x"} | select(.b)`,
+	} {
+		t.Run(traceQL, func(t *testing.T) {
+			_, err := parseJaegerQuery(traceQL)
+			assert.ErrorContains(t, err, "not supported by Jaeger")
+		})
+	}
+}
+
+const jaegerTraces = `{"data":[{"traceID":"ab12","spans":[
+{"traceID":"ab12","spanID":"s1","operationName":"GET /","references":[],"startTime":1700000000000000,"duration":2000,
+ "tags":[{"key":"http.status_code","type":"int64","value":500},{"key":"error","type":"bool","value":true}],"processID":"p1"},
+{"traceID":"ab12","spanID":"s2","operationName":"query","references":[{"refType":"CHILD_OF","traceID":"ab12","spanID":"s1"}],
+ "startTime":1700000000000500,"duration":1000,
+ "tags":[{"key":"otel.status_code","type":"string","value":"OK"}],"processID":"p1"}],
+"processes":{"p1":{"serviceName":"frontend","tags":[{"key":"k8s.pod.name","type":"string","value":"p"}]}}}]}`
+
+func TestJaegerStore_Get(t *testing.T) {
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/services":
+			_, _ = w.Write([]byte(`{"data":["frontend","backend"]}`))
+		case "/api/traces":
+			got = append(got, r.URL.Query())
+			if r.URL.Query().Get("service") == "frontend" {
+				_, _ = w.Write([]byte(jaegerTraces))
+			} else {
+				_, _ = w.Write([]byte(`{"data":[]}`))
+			}
+		case "/api/traces/ab12":
+			_, _ = w.Write([]byte(jaegerTraces))
+		default:
+			http.Error(w, `{"data":null,"errors":[{"code":404,"msg":"trace not found"}]}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	s, err := NewJaegerStore(u, server.Client())
+	require.NoError(t, err)
+
+	start := time.UnixMicro(1700000000000000)
+	end := start.Add(time.Hour)
+	r := result.New(Class{})
+	q := Query(`{resource.k8s.pod.name="p"}`)
+	require.NoError(t, s.Get(context.Background(), q, &korrel8r.Constraint{Start: &start, End: &end}, r))
+	require.Len(t, got, 2)
+	assert.Equal(t, url.Values{
+		"service": {"frontend"},
+		"tags":    {`{"k8s.pod.name":"p"}`},
+		"start":   {"1700000000000000"},
+		"end":     {"1700003600000000"},
+	}, got[0])
+	assert.Equal(t, "backend", got[1].Get("service"))
+
+	parent := SpanID("s1")
+	want := []korrel8r.Object{
+		&Span{
+			Name:       "GET /",
+			Context:    SpanContext{TraceID: "ab12", SpanID: "s1"},
+			StartTime:  start,
+			EndTime:    start.Add(2 * time.Millisecond),
+			Attributes: map[string]any{"service.name": "frontend", "k8s.pod.name": "p", "http.status_code": int64(500)},
+			Status:     Status{Code: StatusError},
+		},
+		&Span{
+			Name:       "query",
+			Context:    SpanContext{TraceID: "ab12", SpanID: "s2"},
+			ParentID:   &parent,
+			StartTime:  start.Add(500 * time.Microsecond),
+			EndTime:    start.Add(1500 * time.Microsecond),
+			Attributes: map[string]any{"service.name": "frontend", "k8s.pod.name": "p"},
+			Status:     Status{Code: StatusOK},
+		},
+	}
+	assert.Equal(t, want, r.List())
+
+	// Limit stops searching services once enough traces are found.
+	got = nil
+	require.NoError(t, s.Get(context.Background(), q, &korrel8r.Constraint{Limit: new(1)}, result.New(Class{})))
+	require.Len(t, got, 1)
+	assert.Equal(t, "1", got[0].Get("limit"))
+
+	// Trace ID query.
+	r = result.New(Class{})
+	require.NoError(t, s.Get(context.Background(), Query(`ab12`), nil, r))
+	assert.Equal(t, want, r.List())
+	assert.ErrorContains(t, s.Get(context.Background(), Query(`cd34`), nil, r), "trace not found")
+
+	// Trace query returns complete traces.
+	for _, q := range []TraceQuery{`ab12`, `{resource.service.name="frontend"}`} {
+		r = result.New(TraceClass{})
+		require.NoError(t, s.Get(context.Background(), q, nil, r))
+		require.Len(t, r.List(), 1)
+		tr := r.List()[0].(*Trace)
+		assert.Equal(t, []SpanID{"s1", "s2"}, tr.CriticalPath)
+		assert.Equal(t, []SpanID{"s1"}, tr.ErrorSpans)
+		assert.Equal(t, want[1], tr.Roots[0].Children[0].Span)
+	}
+}
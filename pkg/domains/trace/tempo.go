@@ -4,7 +4,10 @@ package trace
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -12,10 +15,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/types"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
 	"github.com/korrel8r/korrel8r/pkg/otel"
+	otlpcommon "go.opentelemetry.io/proto/otlp/common/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // Note: tempoTrace and tempoSpan are for decoding the tempo response, converted to Span for korrel8r.
@@ -47,20 +54,13 @@ type tempoSpan struct {
 }
 
 type client struct {
-	hc   *http.Client
-	base *url.URL
+	hc     *http.Client
+	base   *url.URL // Search URL.
+	tenant string   // Optional X-Scope-OrgID header.
 }
 
 func newClient(c *http.Client, base *url.URL) *client { return &client{hc: c, base: base} }
 
-// TODO: removed plain tempo store, not currently used. Put it back if necessary.
-// func (c *client) Get(ctx context.Context, traceQL string, collect func(*Span)) error { ... }
-
-// GetStack uses the TempoStack tenant API to get tracees for a TraceQL query with a Constraint.
-func (c *client) GetStack(ctx context.Context, traceQL string, constraint *korrel8r.Constraint, collect func(*Span)) error {
-	return c.get(ctx, traceQL, constraint, collect)
-}
-
 const ( // Tempo query keywords and field names
 	query      = "q"
 	statusAttr = "status"
@@ -95,8 +95,22 @@ func defaultSelect(traceQL string) string {
 	return traceQL
 }
 
+// traceQL returns the TraceQL form of a query, a trace-id list is converted to trace:id matchers.
+func (q Query) traceQL() string {
+	ids := q.TraceIDs()
+	if ids == nil {
+		return q.Data()
+	}
+	matchers := make([]string, len(ids))
+	for i, id := range ids {
+		matchers[i] = fmt.Sprintf("trace:id=%q", id)
+	}
+	return "{" + strings.Join(matchers, "||") + "}"
+}
+
 func formatTime(t time.Time) string { return strconv.FormatInt(t.UTC().Unix(), 10) }
 
+// get spans for a TraceQL query with a Constraint.
 func (c *client) get(ctx context.Context, traceQL string, constraint *korrel8r.Constraint, collect func(*Span)) error {
 	u := *c.base // Copy, don't modify base.
 	v := url.Values{query: []string{defaultSelect(traceQL)}}
@@ -117,13 +131,140 @@ func (c *client) get(ctx context.Context, traceQL string, constraint *korrel8r.C
 	u.RawQuery = v.Encode()
 
 	var response tempoResponse
-	if err := impl.Get(ctx, &u, c.hc, &response); err != nil {
+	if err := getJSON(ctx, &u, c.hc, tenantHeader(c.tenant), &response); err != nil {
 		return err
 	}
 	response.collect(collect)
 	return nil
 }
 
+func tenantHeader(tenant string) http.Header {
+	if tenant == "" {
+		return nil
+	}
+	return http.Header{"X-Scope-OrgID": {tenant}}
+}
+
+// getJSON is like [impl.Get] with extra request headers.
+func getJSON(ctx context.Context, u *url.URL, hc *http.Client, header http.Header, body any) error {
+	if header == nil {
+		return impl.Get(ctx, u, hc, body)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	maps.Copy(req.Header, header)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		if b, err := io.ReadAll(resp.Body); err == nil && len(b) > 0 {
+			return fmt.Errorf("%v: %v", resp.Status, string(b))
+		}
+		return fmt.Errorf("%v", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(body)
+}
+
+// getTraces gets complete traces for a trace-id list, or for traces with spans matching a TraceQL query.
+// Each trace is a separate request, requests run in parallel.
+// The constraint limit is the maximum number of traces.
+func (c *client) getTraces(ctx context.Context, q TraceQuery, constraint *korrel8r.Constraint, collect func(*Trace)) error {
+	ids := q.SpanQuery().TraceIDs()
+	if ids == nil {
+		seen := map[TraceID]bool{}
+		if err := c.get(ctx, q.SpanQuery().traceQL(), constraint, func(s *Span) {
+			if !seen[s.Context.TraceID] {
+				seen[s.Context.TraceID] = true
+				ids = append(ids, s.Context.TraceID)
+			}
+		}); err != nil {
+			return err
+		}
+	}
+	if limit := constraint.GetLimit(); limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return fetchTraces(ctx, ids, func(ctx context.Context, id TraceID) (*Trace, error) {
+		var spans []*Span
+		err := c.getTrace(ctx, id, func(s *Span) { spans = append(spans, s) })
+		return NewTrace(spans), err
+	}, func(t *Trace) {
+		if t != nil {
+			collect(t)
+		}
+	})
+}
+
+// tempoTraceResponse is the OTLP JSON response to a trace-by-id request.
+type tempoTraceResponse struct {
+	Batches       []json.RawMessage `json:"batches"`       // Tempo v1 API.
+	ResourceSpans []json.RawMessage `json:"resourceSpans"` // OTLP standard.
+}
+
+// getTrace gets all the spans in a trace by ID.
+// The trace-by-id URL is a sibling of the search URL: api/search becomes api/traces/ID.
+func (c *client) getTrace(ctx context.Context, id TraceID, collect func(*Span)) error {
+	u := c.base.JoinPath("..", "traces", url.PathEscape(string(id)))
+	var response tempoTraceResponse
+	if err := getJSON(ctx, u, c.hc, tenantHeader(c.tenant), &response); err != nil {
+		return err
+	}
+	for _, b := range append(response.Batches, response.ResourceSpans...) {
+		var rs tracev1.ResourceSpans
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, &rs); err != nil {
+			return fmt.Errorf("trace %v: %w", id, err)
+		}
+		collectOTLP(&rs, collect)
+	}
+	return nil
+}
+
+// collectOTLP calls collect() on each *Span in OTLP resource spans.
+// Resource attributes are included in the span attributes.
+func collectOTLP(rs *tracev1.ResourceSpans, collect func(*Span)) {
+	resource := map[string]any{}
+	addOTLPAttributes(resource, rs.GetResource().GetAttributes())
+	for _, ss := range rs.GetScopeSpans() {
+		for _, s := range ss.GetSpans() {
+			span := &Span{
+				Name: s.GetName(),
+				Context: SpanContext{
+					TraceID: TraceID(hex.EncodeToString(s.GetTraceId())),
+					SpanID:  SpanID(hex.EncodeToString(s.GetSpanId())),
+				},
+				StartTime:  time.Unix(0, int64(s.GetStartTimeUnixNano())),
+				EndTime:    time.Unix(0, int64(s.GetEndTimeUnixNano())),
+				Attributes: maps.Clone(resource),
+				Status:     Status{Code: StatusUnset, Description: s.GetStatus().GetMessage()},
+			}
+			if len(s.GetParentSpanId()) > 0 {
+				parent := SpanID(hex.EncodeToString(s.GetParentSpanId()))
+				span.ParentID = &parent
+			}
+			addOTLPAttributes(span.Attributes, s.GetAttributes())
+			switch s.GetStatus().GetCode() {
+			case tracev1.Status_STATUS_CODE_ERROR:
+				span.Status.Code = StatusError
+			case tracev1.Status_STATUS_CODE_OK:
+				span.Status.Code = StatusOK
+			}
+			collect(span)
+		}
+	}
+}
+
+func addOTLPAttributes(m map[string]any, attrs []*otlpcommon.KeyValue) {
+	for _, kv := range attrs {
+		if kv.GetValue() != nil {
+			m[kv.GetKey()] = otel.ValueOf(kv.GetValue())
+		}
+	}
+}
+
 // collect calls collect() on each *Span.
 func (r *tempoResponse) collect(collect func(*Span)) {
 	for _, tt := range r.Traces {
@@ -3,10 +3,19 @@
 package trace
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -119,3 +128,131 @@ func TestUnmarshal(t *testing.T) {
 	}
 	assert.Equal(t, want, spans)
 }
+
+func TestTempoStore_Get(t *testing.T) {
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tempo/api/search", r.URL.Path)
+		assert.Equal(t, "tenant1", r.Header.Get("X-Scope-OrgID"))
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"traces":[{"traceID":"ab12","rootServiceName":"s","rootTraceName":"n",
+"spanSets":[{"spans":[{"spanID":"1","startTimeUnixNano":"1","durationNanos":"1"}]}]}]}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL + "/tempo")
+	s, err := NewTempoStore(u, "tenant1", server.Client())
+	require.NoError(t, err)
+	r := result.New(Class{})
+	require.NoError(t, s.Get(context.Background(), Query(`{resource.service.name="s"}`), nil, r))
+	assert.Equal(t, defaultSelect(`{resource.service.name="s"}`), got.Get("q"))
+	require.Len(t, r.List(), 1)
+	assert.Equal(t, SpanContext{TraceID: "ab12", SpanID: "1"}, r.List()[0].(*Span).Context)
+
+	require.NoError(t, s.Get(context.Background(), Query(`ab12, cd34`), nil, result.New(Class{})))
+	assert.Equal(t, defaultSelect(`{trace:id="ab12"||trace:id="cd34"}`), got.Get("q"))
+}
+
+func TestTempoStore_Get_trace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/search":
+			_, _ = w.Write([]byte(`{"traces":[{"traceID":"ab12","spanSets":[{"spans":[{"spanID":"01"},{"spanID":"02"}]}]}]}`))
+		case "/api/traces/ab12":
+			_, _ = w.Write([]byte(`{"batches":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"svc"}}]},
+"scopeSpans":[{"spans":[
+{"traceId":"qxI=","spanId":"AQ==","name":"root","startTimeUnixNano":"1000","endTimeUnixNano":"5000",
+ "status":{"code":"STATUS_CODE_ERROR","message":"boom"}},
+{"traceId":"qxI=","spanId":"Ag==","parentSpanId":"AQ==","name":"child","startTimeUnixNano":"2000","endTimeUnixNano":"3000",
+ "attributes":[{"key":"k8s.pod.name","value":{"stringValue":"p"}}]}]}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	s, err := NewTempoStore(u, "", server.Client())
+	require.NoError(t, err)
+	for _, q := range []TraceQuery{`{resource.k8s.pod.name="p"}`, `ab12`} {
+		t.Run(string(q), func(t *testing.T) {
+			r := result.New(TraceClass{})
+			require.NoError(t, s.Get(context.Background(), q, nil, r))
+			require.Len(t, r.List(), 1)
+			tr := r.List()[0].(*Trace)
+			assert.Equal(t, "ab12 svc: root spans=2 errors=1 duration=4µs", tr.String())
+			parent := SpanID("01")
+			assert.Equal(t, &Span{
+				Name:       "child",
+				Context:    SpanContext{TraceID: "ab12", SpanID: "02"},
+				ParentID:   &parent,
+				StartTime:  time.Unix(0, 2000),
+				EndTime:    time.Unix(0, 3000),
+				Attributes: map[string]any{"service.name": "svc", "k8s.pod.name": "p"},
+				Status:     Status{Code: StatusUnset},
+			}, tr.Roots[0].Children[0].Span)
+			assert.Equal(t, Status{Code: StatusError, Description: "boom"}, tr.Roots[0].Span.Status)
+		})
+	}
+}
+
+func TestTempoStore_Get_traceLimit(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		traces []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id, ok := strings.CutPrefix(r.URL.Path, "/api/traces/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		traces = append(traces, id)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"batches":[{"scopeSpans":[{"spans":[{"spanId":"AQ==","name":"root"}]}]}]}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	s, err := NewTempoStore(u, "", server.Client())
+	require.NoError(t, err)
+	require.NoError(t, s.Get(context.Background(), TraceQuery(`ab12, cd34, ef56`), &korrel8r.Constraint{Limit: new(2)}, result.New(TraceClass{})))
+	assert.ElementsMatch(t, []string{"ab12", "cd34"}, traces, "only fetch traces up to the limit")
+
+	traces = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, s.Get(ctx, TraceQuery(`ab12, cd34`), nil, result.New(TraceClass{})), context.Canceled)
+	assert.Empty(t, traces)
+}
+
+func TestTempoStore_Get_traceParallel(t *testing.T) {
+	ids := []string{"ab12", "cd34", "ef56"}
+	var (
+		n   atomic.Int32
+		all = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id, _ := strings.CutPrefix(r.URL.Path, "/api/traces/")
+		if n.Add(1) == int32(len(ids)) {
+			close(all)
+		}
+		select { // Only respond when all traces are being fetched in parallel.
+		case <-all:
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(`{"batches":[{"scopeSpans":[{"spans":[{"traceId":"` + id + `","spanId":"AQ==","name":"root"}]}]}]}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	s, err := NewTempoStore(u, "", server.Client())
+	require.NoError(t, err)
+	r := result.New(TraceClass{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, s.Get(ctx, TraceQuery(strings.Join(ids, ",")), nil, r))
+	assert.Len(t, r.List(), len(ids))
+}
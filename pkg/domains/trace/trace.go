@@ -8,29 +8,39 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"golang.org/x/sync/errgroup"
 )
 
-var _ = impl.AssertDomainTypes(Domain, Object(nil), Class{}, Query(""), &stackStore{})
+var (
+	_ = impl.AssertDomainTypes(Domain, Object(nil), Class{}, Query(""), &stackStore{})
+	_ = impl.AssertDomainTypes(Domain, Object(nil), Class{}, Query(""), &jaegerStore{})
+	_ = impl.AssertDomainTypes(Domain, TraceObject(nil), TraceClass{}, TraceQuery(""), &stackStore{})
+)
 
 //go:embed doc.md
 var description string
 
-var Domain = domain{Domain: impl.NewDomain("trace", description, Class{})}
+var Domain = domain{Domain: impl.NewDomain("trace", description, Class{}, TraceClass{})}
 
 type domain struct{ *impl.Domain }
 
 func (d domain) Query(s string) (korrel8r.Query, error) {
-	_, s, err := impl.ParseQuery(d, s)
+	c, s, err := impl.ParseQuery(d, s)
 	if err != nil {
 		return nil, err
 	}
+	if c == (TraceClass{}) {
+		return TraceQuery(s), nil
+	}
 	return Query(s), nil
 }
 
@@ -38,6 +48,7 @@ const (
 	StoreKeyTempo       = "tempo"
 	StoreKeyTempoStack  = "tempoStack"
 	StoreKeyTempoTenant = "tenant"
+	StoreKeyJaeger      = "jaeger"
 )
 
 func (domain) Store(s any) (korrel8r.Store, error) {
@@ -45,20 +56,34 @@ func (domain) Store(s any) (korrel8r.Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	hc, err := k8s.NewHTTPClient(cs)
+	var key string
+	for _, k := range []string{StoreKeyTempo, StoreKeyTempoStack, StoreKeyJaeger} {
+		if cs[k] != "" {
+			if key != "" {
+				return nil, fmt.Errorf("can't set both %v and %v URLs", key, k)
+			}
+			key = k
+		}
+	}
+	if key == "" {
+		return nil, fmt.Errorf("must set one of %v, %v or %v URL", StoreKeyTempo, StoreKeyTempoStack, StoreKeyJaeger)
+	}
+	u, err := url.Parse(cs[key])
 	if err != nil {
 		return nil, err
 	}
-	tempoStack := cs[StoreKeyTempoStack]
-	if tempoStack == "" {
-		return nil, fmt.Errorf("must set tempoStack URL")
-	}
-	u, err := url.Parse(tempoStack)
+	hc, err := k8s.NewHTTPClient(cs)
 	if err != nil {
 		return nil, err
 	}
-
-	return NewTempoStackStore(u, hc)
+	switch key {
+	case StoreKeyTempo:
+		return NewTempoStore(u, cs[StoreKeyTempoTenant], hc)
+	case StoreKeyJaeger:
+		return NewJaegerStore(u, hc)
+	default:
+		return NewTempoStackStore(u, hc)
+	}
 }
 
 type Class struct{}
@@ -113,17 +138,34 @@ type Status struct {
 //
 // Span: [https://opentelemetry.io/docs/concepts/signals/traces]
 type Span struct {
-	Name       string         `json:"name"`             // Name of span.
-	Context    SpanContext    `json:"context"`          // Context identifying the span.
-	ParentID   *SpanID        `json:"spanID,omitempty"` // ParentID span ID of parent span, nil for root span.
-	StartTime  time.Time      `json:"startTime"`        // StartTime for span
-	EndTime    time.Time      `json:"endtime"`          // EndTime for span
-	Attributes map[string]any `json:"attributes"`       // Attribute map .
+	Name       string         `json:"name"`               // Name of span.
+	Context    SpanContext    `json:"context"`            // Context identifying the span.
+	ParentID   *SpanID        `json:"parentID,omitempty"` // ParentID span ID of parent span, nil for root span.
+	StartTime  time.Time      `json:"startTime"`          // StartTime for span
+	EndTime    time.Time      `json:"endtime"`            // EndTime for span
+	Attributes map[string]any `json:"attributes"`         // Attribute map .
 	Status     Status         `json:"status"`
 
 	// TODO OTEL links, events not yet supported.
 }
 
+// UnmarshalJSON also accepts "spanID" for the parent span ID, used by korrel8r 0.11 and earlier.
+func (s *Span) UnmarshalJSON(data []byte) error {
+	type span Span // Without methods, avoids recursion.
+	var v struct {
+		span
+		OldParentID *SpanID `json:"spanID,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = Span(v.span)
+	if s.ParentID == nil {
+		s.ParentID = v.OldParentID
+	}
+	return nil
+}
+
 // Duration is shorthand for
 //
 //	s.EndTime.Sub(s.StartTime)
@@ -139,12 +181,61 @@ func (q Query) Class() korrel8r.Class { return Class{} }
 func (q Query) Data() string          { return string(q) }
 func (q Query) String() string        { return korrel8r.QueryString(q) }
 
+var traceIDList = regexp.MustCompile(`^[0-9a-fA-F]+( *, *[0-9a-fA-F]+)*$`)
+
+// TraceIDs returns the trace IDs for a trace-id list query, nil for a TraceQL query.
+func (q Query) TraceIDs() []TraceID {
+	s := strings.TrimSpace(string(q))
+	if !traceIDList.MatchString(s) {
+		return nil
+	}
+	var ids []TraceID
+	for id := range strings.SplitSeq(s, ",") {
+		ids = append(ids, TraceID(strings.TrimSpace(id)))
+	}
+	return ids
+}
+
+// maxTraceFetches is the maximum number of concurrent trace-by-ID requests for one query.
+const maxTraceFetches = 8
+
+// fetchTraces calls fetch for each trace ID, with up to maxTraceFetches calls in parallel.
+// collect is called with the results in ID order when all fetches succeed.
+// Returns the first error, remaining fetches are canceled.
+func fetchTraces[T any](ctx context.Context, ids []TraceID, fetch func(context.Context, TraceID) (T, error), collect func(T)) error {
+	results := make([]T, len(ids))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxTraceFetches)
+	for i, id := range ids {
+		g.Go(func() (err error) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			results[i], err = fetch(ctx, id)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	for _, r := range results {
+		collect(r)
+	}
+	return nil
+}
+
 // NewTempoStackStore returns a store that uses a TempoStack observatorium-style URLs.
 func NewTempoStackStore(base *url.URL, h *http.Client) (korrel8r.Store, error) {
 	return &stackStore{store: store{client: newClient(h, base)}}, nil
 }
 
-// TODO removed NewPlainTempoStore, not used initially. Restore if required.
+// NewTempoStore returns a store for a plain Tempo server with HTTP API at base.
+// If tenant is not empty it is sent in the X-Scope-OrgID header.
+func NewTempoStore(base *url.URL, tenant string, h *http.Client) (korrel8r.Store, error) {
+	c := newClient(h, base.JoinPath("api/search"))
+	c.tenant = tenant
+	return &store{client: c}, nil
+}
 
 type store struct {
 	*client
@@ -152,15 +243,16 @@ type store struct {
 }
 
 func (store) Domain() korrel8r.Domain { return Domain }
-
-type stackStore struct{ store }
-
-func (stackStore) Domain() korrel8r.Domain { return Domain }
-func (s *stackStore) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+func (s *store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	if q, ok := query.(TraceQuery); ok {
+		return s.getTraces(ctx, q, c, func(t *Trace) { result.Append(t) })
+	}
 	q, err := impl.TypeAssert[Query](query)
 	if err != nil {
 		return err
 	}
-
-	return s.GetStack(ctx, q.Data(), c, func(s *Span) { result.Append(s) })
+	return s.client.get(ctx, q.traceQL(), c, func(s *Span) { result.Append(s) })
 }
+
+// stackStore uses the TempoStack tenant API, the search URL is provided directly.
+type stackStore struct{ store }
@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
+	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/domains/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TODO tempo limits number of traces, not spans. Remove ClusterSetup when fixed.
@@ -17,3 +20,31 @@ var fixture = domain.Fixture{
 
 func TestTraceDomain(t *testing.T)     { fixture.Test(t) }
 func BenchmarTraceDomain(b *testing.B) { fixture.Benchmark(b) }
+
+func TestQuery_TraceIDs(t *testing.T) {
+	assert.Equal(t, []trace.TraceID{"a7880cc2", "B7880CC2"}, trace.Query("a7880cc2, B7880CC2").TraceIDs())
+	assert.Nil(t, trace.Query(`{resource.service.name="x"}`).TraceIDs())
+	assert.Nil(t, trace.Query(`{}`).TraceIDs())
+}
+
+func TestSpan_UnmarshalJSON(t *testing.T) {
+	o, err := trace.Class{}.Unmarshal([]byte(`{"name":"x","context":{"traceID":"ab12","spanID":"s2"},"spanID":"s1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, trace.SpanID("s1"), *o.(*trace.Span).ParentID, "old parent ID field")
+	assert.Equal(t, trace.SpanID("s2"), o.(*trace.Span).Context.SpanID)
+
+	o, err = trace.Class{}.Unmarshal([]byte(`{"name":"x","context":{"traceID":"ab12","spanID":"s2"},"parentID":"s1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, trace.SpanID("s1"), *o.(*trace.Span).ParentID)
+
+	o, err = trace.Class{}.Unmarshal([]byte(`{"name":"x","context":{"traceID":"ab12","spanID":"s2"}}`))
+	require.NoError(t, err)
+	assert.Nil(t, o.(*trace.Span).ParentID)
+}
+
+func TestDomain_Store(t *testing.T) {
+	_, err := trace.Domain.Store(config.Store{})
+	assert.ErrorContains(t, err, "must set one of")
+	_, err = trace.Domain.Store(config.Store{trace.StoreKeyTempo: "http://a", trace.StoreKeyJaeger: "http://b"})
+	assert.ErrorContains(t, err, "can't set both tempo and jaeger")
+}
@@ -0,0 +1,187 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package trace
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/otel"
+)
+
+const traceName = "trace"
+
+// TraceClass represents a complete trace with spans arranged as a tree. There is only a single trace class, named "trace".
+type TraceClass struct{}
+
+func (c TraceClass) Domain() korrel8r.Domain { return Domain }
+func (c TraceClass) Name() string            { return traceName }
+func (c TraceClass) String() string          { return korrel8r.ClassString(c) }
+
+func (c TraceClass) Unmarshal(b []byte) (korrel8r.Object, error) {
+	return impl.UnmarshalAs[TraceObject](b)
+}
+
+func (c TraceClass) Preview(o korrel8r.Object) string {
+	return impl.Preview(o, func(o TraceObject) string { return o.String() })
+}
+
+func (c TraceClass) ID(o korrel8r.Object) any {
+	if t, _ := o.(TraceObject); t != nil {
+		return t.TraceID
+	}
+	return nil
+}
+
+// TraceObject is a complete trace, passed as *Trace when used as a korrel8r.Object.
+type TraceObject = *Trace
+
+// Trace is a complete trace: all the spans with the same trace ID, arranged by parent/child relationship.
+type Trace struct {
+	TraceID TraceID `json:"traceID"`
+	// Roots are spans without a parent. There is more than one root if some parent spans are missing.
+	Roots     []*SpanNode `json:"roots"`
+	StartTime time.Time   `json:"startTime"`
+	EndTime   time.Time   `json:"endTime"`
+	SpanCount int         `json:"spanCount"`
+	// CriticalPath is the chain of spans that determines the duration of the trace, in start time order.
+	CriticalPath []SpanID `json:"criticalPath,omitempty"`
+	// ErrorSpans are the spans with error status, in start time order.
+	ErrorSpans []SpanID `json:"errorSpans,omitempty"`
+}
+
+// SpanNode is a span with its child spans, in start time order.
+type SpanNode struct {
+	Span     *Span       `json:"span"`
+	Children []*SpanNode `json:"children,omitempty"`
+}
+
+// NewTrace arranges spans with the same trace ID as a Trace.
+// Duplicate spans are ignored. Returns nil if there are no spans.
+func NewTrace(spans []*Span) *Trace {
+	if len(spans) == 0 {
+		return nil
+	}
+	t := &Trace{TraceID: spans[0].Context.TraceID}
+	nodes := map[SpanID]*SpanNode{}
+	var all []*SpanNode
+	for _, s := range spans {
+		if nodes[s.Context.SpanID] == nil {
+			n := &SpanNode{Span: s}
+			nodes[s.Context.SpanID] = n
+			all = append(all, n)
+		}
+	}
+	byStart := func(a, b *SpanNode) int { return a.Span.StartTime.Compare(b.Span.StartTime) }
+	slices.SortStableFunc(all, byStart)
+	for _, n := range all {
+		if p := n.Span.ParentID; p != nil && nodes[*p] != nil && *p != n.Span.Context.SpanID {
+			nodes[*p].Children = append(nodes[*p].Children, n)
+		} else {
+			t.Roots = append(t.Roots, n)
+		}
+		if t.SpanCount == 0 || n.Span.StartTime.Before(t.StartTime) {
+			t.StartTime = n.Span.StartTime
+		}
+		if n.Span.EndTime.After(t.EndTime) {
+			t.EndTime = n.Span.EndTime
+		}
+		if n.Span.Status.Code == StatusError {
+			t.ErrorSpans = append(t.ErrorSpans, n.Span.Context.SpanID)
+		}
+		t.SpanCount++
+	}
+	// The critical path starts from the root that finishes last.
+	var root *SpanNode
+	for _, n := range t.Roots {
+		if root == nil || n.Span.EndTime.After(root.Span.EndTime) {
+			root = n
+		}
+	}
+	if root != nil {
+		path := criticalPath(root, root.Span.EndTime, map[*SpanNode]bool{})
+		slices.SortStableFunc(path, byStart)
+		for _, n := range path {
+			t.CriticalPath = append(t.CriticalPath, n.Span.Context.SpanID)
+		}
+	}
+	return t
+}
+
+// criticalPath returns n and the spans under n that determine when n finishes, up to end.
+// Working backwards from end, the child that finishes last is on the critical path,
+// then the child that finishes last before that child started, and so on.
+func criticalPath(n *SpanNode, end time.Time, seen map[*SpanNode]bool) []*SpanNode {
+	if seen[n] { // Guard against cycles in malformed traces.
+		return nil
+	}
+	seen[n] = true
+	path := []*SpanNode{n}
+	for cursor := end; ; {
+		var next *SpanNode
+		for _, c := range n.Children {
+			if !seen[c] && c.Span.StartTime.Before(cursor) &&
+				(next == nil || minTime(c.Span.EndTime, cursor).After(minTime(next.Span.EndTime, cursor))) {
+				next = c
+			}
+		}
+		if next == nil {
+			return path
+		}
+		path = append(path, criticalPath(next, minTime(next.Span.EndTime, cursor), seen)...)
+		cursor = next.Span.StartTime
+	}
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// Spans returns all spans in the trace, depth first in start time order.
+func (t *Trace) Spans() []*Span {
+	var spans []*Span
+	var walk func([]*SpanNode)
+	walk = func(nodes []*SpanNode) {
+		for _, n := range nodes {
+			spans = append(spans, n.Span)
+			walk(n.Children)
+		}
+	}
+	walk(t.Roots)
+	return spans
+}
+
+// Duration is shorthand for
+//
+//	t.EndTime.Sub(t.StartTime)
+func (t *Trace) Duration() time.Duration { return t.EndTime.Sub(t.StartTime) }
+
+// String returns the service and name of the first root span with summary information.
+func (t *Trace) String() string {
+	var name string
+	if len(t.Roots) > 0 {
+		root := t.Roots[0].Span
+		name = root.Name
+		if service, _ := root.Attributes[otel.AttrServiceName].(string); service != "" {
+			name = service + ": " + name
+		}
+	}
+	return fmt.Sprintf("%v %v spans=%v errors=%v duration=%v", t.TraceID, name, t.SpanCount, len(t.ErrorSpans), t.Duration())
+}
+
+// TraceQuery selects complete traces, the selector has the same forms as [Query].
+// A TraceQL query returns each trace that contains a matching span.
+type TraceQuery string
+
+func (q TraceQuery) Class() korrel8r.Class { return TraceClass{} }
+func (q TraceQuery) Data() string          { return string(q) }
+func (q TraceQuery) String() string        { return korrel8r.QueryString(q) }
+
+// SpanQuery returns a span query with the same selector.
+func (q TraceQuery) SpanQuery() Query { return Query(q) }
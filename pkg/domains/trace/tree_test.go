@@ -0,0 +1,82 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSpan(id, parent string, start, end int, code StatusCode) *Span {
+	t0 := time.Unix(1700000000, 0)
+	s := &Span{
+		Name:       "op-" + id,
+		Context:    SpanContext{TraceID: "ab12", SpanID: SpanID(id)},
+		StartTime:  t0.Add(time.Duration(start) * time.Millisecond),
+		EndTime:    t0.Add(time.Duration(end) * time.Millisecond),
+		Attributes: map[string]any{"service.name": "svc"},
+		Status:     Status{Code: code},
+	}
+	if parent != "" {
+		p := SpanID(parent)
+		s.ParentID = &p
+	}
+	return s
+}
+
+func TestNewTrace(t *testing.T) {
+	spans := []*Span{
+		newTestSpan("e", "a", 95, 99, StatusUnset),
+		newTestSpan("a", "", 0, 100, StatusOK),
+		newTestSpan("b", "a", 25, 40, StatusUnset),
+		newTestSpan("c", "a", 20, 90, StatusUnset),
+		newTestSpan("d", "c", 35, 85, StatusError),
+		newTestSpan("g", "missing", 5, 10, StatusError),
+		newTestSpan("d", "c", 35, 85, StatusError), // Duplicate
+	}
+	tr := NewTrace(spans)
+	require.NotNil(t, tr)
+	assert.Equal(t, TraceID("ab12"), tr.TraceID)
+	assert.Equal(t, 6, tr.SpanCount)
+	assert.Equal(t, 100*time.Millisecond, tr.Duration())
+	require.Len(t, tr.Roots, 2)
+	assert.Equal(t, SpanID("a"), tr.Roots[0].Span.Context.SpanID)
+	assert.Equal(t, SpanID("g"), tr.Roots[1].Span.Context.SpanID)
+	var order []SpanID
+	for _, s := range tr.Spans() {
+		order = append(order, s.Context.SpanID)
+	}
+	assert.Equal(t, []SpanID{"a", "c", "d", "b", "e", "g"}, order)
+	assert.Equal(t, []SpanID{"a", "c", "d", "e"}, tr.CriticalPath)
+	assert.Equal(t, []SpanID{"g", "d"}, tr.ErrorSpans)
+	assert.Equal(t, "ab12 svc: op-a spans=6 errors=2 duration=100ms", tr.String())
+
+	b, err := json.Marshal(tr)
+	require.NoError(t, err)
+	o, err := TraceClass{}.Unmarshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, tr.CriticalPath, o.(*Trace).CriticalPath)
+	assert.Equal(t, SpanID("a"), *o.(*Trace).Roots[0].Children[0].Span.ParentID)
+
+	assert.Nil(t, NewTrace(nil))
+}
+
+func TestNewTrace_cycle(t *testing.T) {
+	tr := NewTrace([]*Span{newTestSpan("a", "b", 0, 10, StatusUnset), newTestSpan("b", "a", 1, 5, StatusUnset)})
+	assert.Equal(t, 2, tr.SpanCount)
+	assert.Empty(t, tr.Roots)
+	assert.Empty(t, tr.CriticalPath)
+}
+
+func TestDomain_Query_trace(t *testing.T) {
+	q, err := Domain.Query(`trace:trace:{resource.service.name="x"}`)
+	require.NoError(t, err)
+	assert.Equal(t, TraceQuery(`{resource.service.name="x"}`), q)
+	q, err = Domain.Query(`trace:span:{}`)
+	require.NoError(t, err)
+	assert.Equal(t, Query(`{}`), q)
+}
@@ -10,6 +10,7 @@ package engine
 import (
 	"fmt"
 	"text/template"
+	"time"
 
 	"maps"
 
@@ -105,7 +106,9 @@ func (b *Builder) store(sc config.Store, s korrel8r.Store) *storeHolder {
 	if b.err != nil {
 		return nil
 	}
-	b.e.storeHolders[wrapper.Domain()].Add(wrapper)
+	if b.err = b.e.storeHolders[wrapper.Domain()].Add(wrapper); b.err != nil {
+		return nil
+	}
 	// Store errors don't prevent startup, but check and log a warning.
 	var err error
 	_, err = wrapper.Ensure()
@@ -185,11 +188,22 @@ func (b *Builder) Engine() (*Engine, error) {
 		log.V(1).Info("skipped rules with missing class", "class", class, "rules", rules)
 	}
 	b.e.data = graph.NewData(b.e.rules...)
+	b.queryCaches()
 	e, err := b.e, b.err
 	*b = *Build() // Reset the builder.
 	return e, err
 }
 
+// queryCaches creates result caches for domains with a cache TTL in the tuning section.
+func (b *Builder) queryCaches() {
+	b.e.caches = map[korrel8r.Domain]*queryCache{}
+	for _, d := range b.e.domains.List() {
+		if ttl := time.Duration(b.e.Tuning.QueryCacheTTLFor(d.Name())); ttl > 0 {
+			b.e.caches[d] = newQueryCache(ttl)
+		}
+	}
+}
+
 func (b *Builder) config(c *config.Config) {
 	if b.err != nil {
 		return
@@ -229,7 +243,7 @@ func (b *Builder) configRule(r config.Rule) {
 	if b.err != nil {
 		return
 	}
-	b.rules(rules.NewTemplateRule(start, goal, tmpl, b.e.domains))
+	b.rules(rules.NewPriorityTemplateRule(start, goal, tmpl, b.e.domains, r.Priority))
 }
 
 func (b *Builder) configStatusRule(r config.StatusRule) {
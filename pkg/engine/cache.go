@@ -0,0 +1,56 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/cache"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// queryCache caches the results of store queries for a single domain.
+type queryCache struct {
+	ttl     time.Duration
+	results *cache.TTL[string, []korrel8r.Object]
+}
+
+func newQueryCache(ttl time.Duration) *queryCache {
+	return &queryCache{ttl: ttl, results: cache.NewTTL[string, []korrel8r.Object](ttl)}
+}
+
+// queryKey identifies a query and the constraint fields that affect store results.
+//
+// given is the constraint passed by the caller, c is the same constraint with defaults filled in.
+// Start and End times set in given are used exactly.
+// Times filled in by [korrel8r.Constraint.Default] are truncated to round, if round > 0,
+// so repeated searches with a moving "now" time window share keys.
+func queryKey(q korrel8r.Query, given, c *korrel8r.Constraint, round time.Duration) string {
+	key := func(set, t time.Time) int64 {
+		switch {
+		case !set.IsZero():
+			return set.UnixNano()
+		case t.IsZero():
+			return 0
+		default:
+			return t.Truncate(round).UnixNano()
+		}
+	}
+	return fmt.Sprintf("%v|%v|%v|%v", q.String(), c.GetLimit(), key(given.GetStart(), c.GetStart()), key(given.GetEnd(), c.GetEnd()))
+}
+
+// key for a query and constraint, defaulted times are truncated to the cache TTL.
+func (qc *queryCache) key(q korrel8r.Query, given, c *korrel8r.Constraint) string {
+	return queryKey(q, given, c, qc.ttl)
+}
+
+func (qc *queryCache) Get(q korrel8r.Query, given, c *korrel8r.Constraint) ([]korrel8r.Object, bool) {
+	return qc.results.Get(qc.key(q, given, c))
+}
+
+func (qc *queryCache) Put(q korrel8r.Query, given, c *korrel8r.Constraint, objects []korrel8r.Object) {
+	qc.results.Put(qc.key(q, given, c), objects)
+}
+
+func (qc *queryCache) Clear() { qc.results.Clear() }
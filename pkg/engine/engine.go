@@ -15,6 +15,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
 	"github.com/korrel8r/korrel8r/pkg/status"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -32,6 +33,8 @@ type Engine struct {
 	rules         []korrel8r.Rule
 	statuses      map[string][]status.Rule // Keyed by class.String()
 	data          *graph.Data              // Immutable rule graph data, built once.
+	caches        map[korrel8r.Domain]*queryCache
+	inflight      inflight // Store queries in progress, keyed by query and constraint.
 
 	// Tuning parameters
 	Tuning config.Tuning
@@ -130,14 +133,47 @@ func (e *Engine) StatusRulesFor(c korrel8r.Class) []status.Rule { return e.statu
 func (e *Engine) Graph() *graph.Graph { return e.data.FullGraph() }
 
 // Get results for query from all stores for the query domain.
-func (e *Engine) Get(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, result korrel8r.Appender) (err error) {
-	count := 0
-	constraint = constraint.Default()
+// If result caching is enabled for the domain (see [config.Tuning]), cached results may be returned.
+// Concurrent calls with the same query and constraint share a single store query.
+// The shared query is canceled when all the callers sharing it are canceled,
+// or at the earliest deadline of those callers.
+func (e *Engine) Get(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, result korrel8r.Appender) error {
+	given := constraint
+	c := ptr.Deref(given) // Don't modify the caller's constraint.
+	constraint = c.Default()
 	domain := query.Class().Domain().Name()
 	ss := e.storeHolders[query.Class().Domain()]
 	if len(ss.stores) == 0 {
 		return fmt.Errorf("no stores found for domain %v", domain)
 	}
+	attrs := metric.WithAttributes(attribute.String("domain", domain))
+	qc := e.caches[query.Class().Domain()]
+	if qc != nil {
+		if objects, ok := qc.Get(query, given, constraint); ok {
+			metricCacheHits.Add(ctx, 1, attrs)
+			log.V(5).Info("Get from cache", "count", len(objects), "query", query, "constraint", constraint)
+			result.Append(objects...)
+			return nil
+		}
+		metricCacheMisses.Add(ctx, 1, attrs)
+	}
+	objects, shared, err := e.inflight.do(ctx, queryKey(query, given, constraint, 0), func(ctx context.Context) ([]korrel8r.Object, error) {
+		objects, err := e.storeGet(ctx, ss, query, constraint)
+		if err == nil && qc != nil {
+			qc.Put(query, given, constraint, objects)
+		}
+		return objects, err
+	})
+	if shared {
+		metricStoreCoalesced.Add(ctx, 1, attrs)
+		log.V(5).Info("Get shared with concurrent call", "query", query, "constraint", constraint)
+	}
+	result.Append(objects...)
+	return err
+}
+
+// storeGet queries the stores and records metrics.
+func (e *Engine) storeGet(ctx context.Context, ss *storeHolders, query korrel8r.Query, constraint *korrel8r.Constraint) (objects []korrel8r.Object, err error) {
 	start := time.Now()
 	defer func() {
 		latency := time.Since(start)
@@ -146,20 +182,35 @@ func (e *Engine) Get(ctx context.Context, query korrel8r.Query, constraint *korr
 			status = "error"
 		}
 		attrs := metric.WithAttributes(
-			attribute.String("domain", domain),
+			attribute.String("domain", ss.Domain().Name()),
 			attribute.String("status", status))
 		metricStoreQueries.Add(ctx, 1, attrs)
 		metricStoreQueryDuration.Record(ctx, latency.Seconds(), attrs)
 		if err != nil {
 			log.V(2).Info("Get failed", "error", err, "query", query, "constraint", constraint, "latency", latency)
 		} else {
-			log.V(5).Info("Get", "count", count, "query", query, "constraint", constraint, "latency", latency)
+			log.V(5).Info("Get", "count", len(objects), "query", query, "constraint", constraint, "latency", latency)
 		}
 	}()
-	return ss.Get(ctx, query, constraint, korrel8r.AppenderFunc(func(o ...korrel8r.Object) {
-		count += len(o)
-		result.Append(o...)
+	err = ss.Get(ctx, query, constraint, korrel8r.AppenderFunc(func(o ...korrel8r.Object) {
+		objects = append(objects, o...)
 	}))
+	return objects, err
+}
+
+// FlushCache removes cached query results for the given domains, or for all domains if none are given.
+func (e *Engine) FlushCache(domains ...korrel8r.Domain) {
+	if len(domains) == 0 {
+		for _, qc := range e.caches {
+			qc.Clear()
+		}
+		return
+	}
+	for _, d := range domains {
+		if qc := e.caches[d]; qc != nil {
+			qc.Clear()
+		}
+	}
 }
 
 // NewTemplate returns a template set up with options and funcs for this engine.
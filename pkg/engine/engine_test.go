@@ -5,7 +5,12 @@ package engine_test
 import (
 	"context"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -196,8 +201,288 @@ func TestEngine_LabelersFor(t *testing.T) {
 	assert.Empty(t, e.StatusRulesFor(b))
 }
 
+func TestEngine_RulePriority(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	e, err := engine.Build().Domains(d).Stores(mock.NewStore(d)).
+		Config(config.Configs{{
+			Rules: []config.Rule{
+				{
+					Name:     "high",
+					Start:    config.ClassSpec{Domain: "mock", Classes: []string{"a"}},
+					Goal:     config.ClassSpec{Domain: "mock", Classes: []string{"b"}},
+					Result:   config.ResultSpec{Query: `mock:b:x`},
+					Priority: 10,
+				},
+				{
+					Name:   "default",
+					Start:  config.ClassSpec{Domain: "mock", Classes: []string{"a"}},
+					Goal:   config.ClassSpec{Domain: "mock", Classes: []string{"b"}},
+					Result: config.ResultSpec{Query: `mock:b:y`},
+				},
+			},
+		}}).Engine()
+	require.NoError(t, err)
+	assert.Equal(t, 10, korrel8r.RulePriority(e.Rule("high")))
+	assert.Equal(t, 0, korrel8r.RulePriority(e.Rule("default")))
+}
+
 // Mock object has a name and a timestamp.
 type obj struct {
 	Name string
 	Time time.Time
 }
+
+func TestEngine_QueryCache(t *testing.T) {
+	d := mock.NewDomain("mock", "a")
+	a := d.Class("a")
+	for _, x := range []struct {
+		name   string
+		tuning *config.Tuning
+		want   int // Store calls for two identical Get calls.
+	}{
+		{"no cache", nil, 2},
+		{"cache", &config.Tuning{QueryCacheTTL: config.Duration(time.Hour)}, 1},
+		{"domain cache", &config.Tuning{QueryCacheTTLs: map[string]config.Duration{"mock": config.Duration(time.Hour)}}, 1},
+		{"domain disabled", &config.Tuning{QueryCacheTTL: config.Duration(time.Hour), QueryCacheTTLs: map[string]config.Duration{"mock": 0}}, 2},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			calls := 0
+			s := mock.NewStore(d)
+			s.AddLookup(func(korrel8r.Query) ([]korrel8r.Object, error) { calls++; return nil, nil })
+			e, err := engine.Build().Stores(s).Tuning(x.tuning).Engine()
+			require.NoError(t, err)
+			q := mock.NewQuery(a, "x", 1, 2)
+			end := time.Now()
+			for range 2 {
+				var r mock.Result
+				require.NoError(t, e.Get(context.Background(), q, &korrel8r.Constraint{End: &end}, &r))
+				assert.Equal(t, []korrel8r.Object{1, 2}, r.List())
+			}
+			assert.Equal(t, x.want, calls)
+		})
+	}
+
+	t.Run("flush and key", func(t *testing.T) {
+		calls := 0
+		s := mock.NewStore(d)
+		s.AddLookup(func(korrel8r.Query) ([]korrel8r.Object, error) { calls++; return nil, nil })
+		e, err := engine.Build().Stores(s).Tuning(&config.Tuning{QueryCacheTTL: config.Duration(time.Hour)}).Engine()
+		require.NoError(t, err)
+		q := mock.NewQuery(a, "x", 1, 2)
+		end := time.Now()
+		get := func(c *korrel8r.Constraint) {
+			t.Helper()
+			require.NoError(t, e.Get(context.Background(), q, c, &mock.Result{}))
+		}
+		get(&korrel8r.Constraint{End: &end})
+		get(&korrel8r.Constraint{End: &end, QueryLimit: new(99)}) // Traversal limits don't affect the key.
+		assert.Equal(t, 1, calls)
+		get(&korrel8r.Constraint{End: &end, Limit: new(1)}) // Different limit.
+		assert.Equal(t, 2, calls)
+		e.FlushCache()
+		get(&korrel8r.Constraint{End: &end})
+		assert.Equal(t, 3, calls)
+		e.FlushCache(d)
+		get(&korrel8r.Constraint{End: &end})
+		assert.Equal(t, 4, calls)
+		get(&korrel8r.Constraint{End: new(end.Add(time.Second))}) // Explicit times are exact, not rounded to the TTL.
+		assert.Equal(t, 5, calls)
+		get(nil)
+		get(&korrel8r.Constraint{}) // Default times are rounded to the TTL.
+		assert.Equal(t, 6, calls)
+	})
+}
+
+func TestEngine_GetShared(t *testing.T) {
+	d := mock.NewDomain("mock", "a")
+	a := d.Class("a")
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	s := mock.NewStore(d)
+	s.AddLookup(func(korrel8r.Query) ([]korrel8r.Object, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return nil, nil
+	})
+	e, err := engine.Build().Stores(s).Engine()
+	require.NoError(t, err)
+	q := mock.NewQuery(a, "x", 1, 2)
+	end := time.Now()
+
+	const n = 5
+	results := make([]mock.Result, n)
+	var wg sync.WaitGroup
+	get := func(i int) {
+		wg.Go(func() {
+			assert.NoError(t, e.Get(context.Background(), q, &korrel8r.Constraint{End: &end}, &results[i]))
+		})
+	}
+	get(0)
+	<-started
+	for i := 1; i < n; i++ {
+		get(i)
+	}
+	time.Sleep(100 * time.Millisecond) // Let the other calls join the first.
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+	for _, r := range results {
+		assert.Equal(t, []korrel8r.Object{1, 2}, r.List())
+	}
+
+	// Different constraints are not shared.
+	require.NoError(t, e.Get(context.Background(), q, &korrel8r.Constraint{End: &end, Limit: new(1)}, &mock.Result{}))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestEngine_StoreStrategy(t *testing.T) {
+	d := mock.NewDomain("mock", "a")
+	q := mock.NewQuery(d.Class("a"), "q")
+	dir := t.TempDir()
+	file := func(name string, result string) string {
+		f := filepath.Join(dir, name+".yaml")
+		require.NoError(t, os.WriteFile(f, fmt.Appendf(nil, "%q: %v", q.String(), result), 0o644))
+		return f
+	}
+	bad := config.Store{"domain": "mock", "mockData": filepath.Join(dir, "missing")}
+	empty := config.Store{"domain": "mock", "mockData": file("empty", `[]`)}
+	one := config.Store{"domain": "mock", "mockData": file("one", `[one]`)}
+	two := config.Store{"domain": "mock", "mockData": file("two", `[two]`)}
+	slow := config.Store{"domain": "mock", "mockData": file("slow", `[slow]`), mock.StoreKeyDelay: "1m"}
+	with := func(sc config.Store, kv ...string) config.Store {
+		sc = maps.Clone(sc)
+		for i := 0; i < len(kv); i += 2 {
+			sc[kv[i]] = kv[i+1]
+		}
+		return sc
+	}
+	for _, x := range []struct {
+		name   string
+		stores []config.Store
+		want   []korrel8r.Object
+		err    string
+	}{
+		{"all", []config.Store{one, bad, two}, list[korrel8r.Object]("one", "two"), ""},
+		{"all failed", []config.Store{bad}, nil, "Get failed"},
+		{"first", []config.Store{with(bad, "strategy", "first"), empty, one, two}, list[korrel8r.Object]("one"), ""},
+		{"fallback primary", []config.Store{with(one, "strategy", "fallback"), two}, list[korrel8r.Object]("one"), ""},
+		{"fallback", []config.Store{with(bad, "strategy", "fallback"), empty, two}, nil, ""},
+		{"fallback all failed", []config.Store{with(bad, "strategy", "fallback")}, nil, "Get failed"},
+		{"fastest", []config.Store{with(slow, "strategy", "fastest"), bad, two}, list[korrel8r.Object]("two"), ""},
+		{"timeout", []config.Store{with(slow, "strategy", "fallback", "timeout", "10ms"), one}, list[korrel8r.Object]("one"), ""},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			e, err := engine.Build().Domains(d).StoreConfigs(x.stores...).Engine()
+			require.NoError(t, err)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			var r mock.Result
+			err = e.Get(ctx, q, nil, &r)
+			if x.err != "" {
+				assert.ErrorContains(t, err, x.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, x.want, r.List())
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := engine.Build().Domains(d).StoreConfigs(with(one, "strategy", "nonsense")).Engine()
+		assert.ErrorContains(t, err, `invalid store strategy for domain mock: "nonsense"`)
+		_, err = engine.Build().Domains(d).StoreConfigs(with(one, "strategy", "first"), with(two, "strategy", "fastest")).Engine()
+		assert.ErrorContains(t, err, `conflicting store strategy for domain mock: "first" and "fastest"`)
+		_, err = engine.Build().Domains(d).StoreConfigs(with(one, "timeout", "soon")).Engine()
+		assert.ErrorContains(t, err, `invalid store timeout "soon"`)
+	})
+}
+
+func TestEngine_GetSharedCancel(t *testing.T) {
+	d := mock.NewDomain("mock", "a")
+	s := mock.NewStore(d)
+	s.Delay = 300 * time.Millisecond
+	e, err := engine.Build().Stores(s).Engine()
+	require.NoError(t, err)
+	q := mock.NewQuery(d.Class("a"), "x", 1, 2)
+	end := time.Now()
+	constraint := (&korrel8r.Constraint{End: &end}).Default() // Get does not modify a complete constraint.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { assert.ErrorIs(t, e.Get(ctx, q, constraint, &mock.Result{}), context.Canceled) })
+	time.Sleep(50 * time.Millisecond) // Let the first call start the store query.
+	var r mock.Result
+	wg.Go(func() { assert.NoError(t, e.Get(context.Background(), q, constraint, &r)) })
+	time.Sleep(50 * time.Millisecond) // Let the second call join the first.
+	cancel()
+	wg.Wait()
+	assert.Equal(t, []korrel8r.Object{1, 2}, r.List())
+}
+
+// blockStore blocks in Get until ctx is done, and sends the ctx error to stopped.
+type blockStore struct {
+	*mock.Store
+	started, stopped chan error
+}
+
+func (s *blockStore) Get(ctx context.Context, _ korrel8r.Query, _ *korrel8r.Constraint, _ korrel8r.Appender) error {
+	s.started <- nil
+	<-ctx.Done()
+	s.stopped <- ctx.Err()
+	return ctx.Err()
+}
+
+func newBlockEngine(t *testing.T) (*engine.Engine, *blockStore, korrel8r.Query, *korrel8r.Constraint) {
+	t.Helper()
+	d := mock.NewDomain("mock", "a")
+	s := &blockStore{Store: mock.NewStore(d), started: make(chan error, 1), stopped: make(chan error, 1)}
+	e, err := engine.Build().Domains(d).Stores(s).Engine()
+	require.NoError(t, err)
+	end := time.Now()
+	return e, s, mock.NewQuery(d.Class("a"), "x"), (&korrel8r.Constraint{End: &end}).Default()
+}
+
+func receive(t *testing.T, ch chan error) error {
+	t.Helper()
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout")
+		return nil
+	}
+}
+
+func TestEngine_GetSharedCancelAll(t *testing.T) {
+	e, s, q, constraint := newBlockEngine(t)
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { assert.ErrorIs(t, e.Get(ctx1, q, constraint, &mock.Result{}), context.Canceled) })
+	require.NoError(t, receive(t, s.started))
+	wg.Go(func() { assert.ErrorIs(t, e.Get(ctx2, q, constraint, &mock.Result{}), context.Canceled) })
+	time.Sleep(50 * time.Millisecond) // Let the second call join the first.
+	cancel1()
+	select {
+	case <-s.stopped:
+		t.Fatal("store query canceled while a caller is waiting")
+	case <-time.After(50 * time.Millisecond):
+	}
+	cancel2()
+	assert.ErrorIs(t, receive(t, s.stopped), context.Canceled, "store query canceled with the last caller")
+	wg.Wait()
+}
+
+func TestEngine_GetSharedDeadline(t *testing.T) {
+	e, s, q, constraint := newBlockEngine(t)
+	var wg sync.WaitGroup
+	wg.Go(func() { assert.ErrorIs(t, e.Get(context.Background(), q, constraint, &mock.Result{}), context.DeadlineExceeded) })
+	require.NoError(t, receive(t, s.started))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	wg.Go(func() { assert.ErrorIs(t, e.Get(ctx, q, constraint, &mock.Result{}), context.DeadlineExceeded) })
+	assert.Error(t, receive(t, s.stopped), "store query stopped at the earliest caller deadline")
+	wg.Wait()
+}
@@ -0,0 +1,108 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// inflight shares store queries in progress between concurrent callers with the same key.
+//
+// A shared query has its own context, it is not canceled when the caller that started it is canceled.
+// It is canceled when all waiting callers are done, or at the earliest deadline of the callers.
+type inflight struct {
+	mu    sync.Mutex
+	calls map[string]*sharedGet
+}
+
+// sharedGet is a store query shared by concurrent callers.
+type sharedGet struct {
+	done    chan struct{} // Closed when objects and err are set.
+	objects []korrel8r.Object
+	err     error
+
+	// Guarded by inflight.mu
+	cancel   context.CancelCauseFunc
+	waiters  int         // Number of callers waiting for the result.
+	deadline time.Time   // Earliest deadline of the callers, zero if none.
+	timer    *time.Timer // Cancels the query at deadline.
+}
+
+// wait adds a waiting caller with ctx, the shared deadline is reduced to the ctx deadline if it is earlier.
+// Must be called with inflight.mu held.
+func (c *sharedGet) wait(ctx context.Context) {
+	c.waiters++
+	d, ok := ctx.Deadline()
+	if !ok || (!c.deadline.IsZero() && !d.Before(c.deadline)) {
+		return
+	}
+	c.deadline = d
+	if c.timer == nil {
+		c.timer = time.AfterFunc(time.Until(d), func() { c.cancel(context.DeadlineExceeded) })
+	} else {
+		c.timer.Reset(time.Until(d))
+	}
+}
+
+// stop the query, must be called with inflight.mu held.
+func (c *sharedGet) stop(cause error) {
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	c.cancel(cause)
+}
+
+// do calls get once for concurrent calls with the same key, and waits for the result or for ctx to be done.
+// Returns shared == true if the call joined a query started by another caller.
+func (f *inflight) do(ctx context.Context, key string, get func(context.Context) ([]korrel8r.Object, error)) (objects []korrel8r.Object, shared bool, err error) {
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls = map[string]*sharedGet{}
+	}
+	c := f.calls[key]
+	shared = c != nil
+	if c == nil {
+		var getCtx context.Context
+		c = &sharedGet{done: make(chan struct{})}
+		getCtx, c.cancel = context.WithCancelCause(context.WithoutCancel(ctx))
+		f.calls[key] = c
+		go func() {
+			objects, err := get(getCtx)
+			if err != nil && getCtx.Err() != nil {
+				err = context.Cause(getCtx) // Report deadline exceeded rather than canceled.
+			}
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			c.objects, c.err = objects, err
+			close(c.done)
+			f.forget(key, c)
+			c.stop(context.Canceled)
+		}()
+	}
+	c.wait(ctx)
+	f.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.objects, shared, c.err
+	case <-ctx.Done():
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if c.waiters--; c.waiters == 0 {
+			f.forget(key, c) // Later callers start a new query.
+			c.stop(context.Canceled)
+		}
+		return nil, shared, ctx.Err()
+	}
+}
+
+// forget the call for key if it is c, must be called with mu held.
+func (f *inflight) forget(key string, c *sharedGet) {
+	if f.calls[key] == c {
+		delete(f.calls, key)
+	}
+}
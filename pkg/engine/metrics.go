@@ -14,4 +14,12 @@ var (
 	metricStoreQueryDuration, _ = engineMeter.Float64Histogram("engine.store.query.duration",
 		metric.WithDescription("Store query duration in seconds"),
 		metric.WithUnit("s"))
+	metricStoreCoalesced, _ = engineMeter.Int64Counter("engine.store.coalesced", metric.WithDescription("Store queries shared with an identical concurrent query"))
+	metricCacheHits, _      = engineMeter.Int64Counter("engine.cache.hits", metric.WithDescription("Store queries answered from the result cache"))
+	metricCacheMisses, _    = engineMeter.Int64Counter("engine.cache.misses", metric.WithDescription("Store queries not found in the result cache"))
+
+	metricBackendQueries, _       = engineMeter.Int64Counter("engine.store.backend.queries", metric.WithDescription("Queries to individual stores of a domain"))
+	metricBackendQueryDuration, _ = engineMeter.Float64Histogram("engine.store.backend.query.duration",
+		metric.WithDescription("Individual store query duration in seconds"),
+		metric.WithUnit("s"))
 )
@@ -13,11 +13,14 @@ import (
 	"strconv"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/unique"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 var (
@@ -36,15 +39,20 @@ type storeHolder struct {
 	Store    korrel8r.Store // Store client. Nil if store needs to be re-created.
 	LastErr  error          // Last non-nil error connecting to the store.
 	ErrCount int            // Count of errors connecting to the store.
+	Timeout  time.Duration  // Timeout for each Get, no timeout if 0.
 	Engine   *Engine
 
 	domain korrel8r.Domain // Must be a method to fit Store interface.
+	name   string          // Name for metrics and logs, set by [storeHolders.Add].
 }
 
 // wrap wraps a [config.Store] or a [korrel8r.Store] as a *[storeHolder]
 // Exactly one of sc and s must be non-nil.
 func wrap(e *Engine, sc config.Store, s korrel8r.Store) (*storeHolder, error) {
-	var d korrel8r.Domain
+	var (
+		d       korrel8r.Domain
+		timeout time.Duration
+	)
 	if s != nil {
 		d = s.Domain()
 	} else {
@@ -53,8 +61,14 @@ func wrap(e *Engine, sc config.Store, s korrel8r.Store) (*storeHolder, error) {
 		if err != nil {
 			return nil, err
 		}
+		if t := sc[config.StoreKeyTimeout]; t != "" {
+			timeout, err = time.ParseDuration(t)
+			if err != nil {
+				return nil, fmt.Errorf("invalid store %v %q: %w", config.StoreKeyTimeout, t, err)
+			}
+		}
 	}
-	return &storeHolder{Engine: e, Original: sc, Expanded: nil, Store: s, domain: d}, nil
+	return &storeHolder{Engine: e, Original: sc, Expanded: nil, Store: s, Timeout: timeout, domain: d}, nil
 }
 
 func (s *storeHolder) Domain() korrel8r.Domain { return s.domain }
@@ -77,6 +91,11 @@ func (s *storeHolder) Get(ctx context.Context, q korrel8r.Query, constraint *kor
 	if _, err := s.ensure(); err != nil {
 		return err
 	}
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
 	err = s.Store.Get(ctx, q, constraint, result)
 	if err != nil {
 		s.RecordError(err)
@@ -137,40 +156,84 @@ func (s *storeHolder) ensure() (korrel8r.Store, error) {
 	return s.Store, err
 }
 
-// storeHolders contains multiple store wrappers storeHolders and iterates over them in Get.
+// storeHolders contains multiple store wrappers storeHolders and queries them in Get,
+// using the domain's store strategy.
 type storeHolders struct {
-	domain korrel8r.Domain
-	stores []*storeHolder
+	domain   korrel8r.Domain
+	stores   []*storeHolder
+	strategy string
 }
 
 func newStoreHolders(d korrel8r.Domain) *storeHolders {
 	return &storeHolders{
-		domain: d,
-		stores: []*storeHolder{},
+		domain:   d,
+		stores:   []*storeHolder{},
+		strategy: config.StoreStrategyAll,
 	}
 }
 
 func (ss *storeHolders) Domain() korrel8r.Domain { return ss.domain }
 
-func (ss *storeHolders) Add(newStore *storeHolder) {
+// Add a store, returns an error if the store sets an invalid or conflicting strategy.
+func (ss *storeHolders) Add(newStore *storeHolder) error {
 	// Check for duplicate configuration
 	if newStore.Original != nil && slices.ContainsFunc(ss.stores,
 		func(s *storeHolder) bool { return reflect.DeepEqual(s.Original, newStore.Original) }) {
-		return // Ignore duplicates
+		return nil // Ignore duplicates
+	}
+	if strategy := newStore.Original[config.StoreKeyStrategy]; strategy != "" {
+		switch {
+		case !slices.Contains(strategies, strategy):
+			return fmt.Errorf("invalid store %v for domain %v: %q", config.StoreKeyStrategy, ss.domain.Name(), strategy)
+		case strategy != ss.strategy && slices.ContainsFunc(ss.stores, func(s *storeHolder) bool { return s.Original[config.StoreKeyStrategy] != "" }):
+			return fmt.Errorf("conflicting store %v for domain %v: %q and %q", config.StoreKeyStrategy, ss.domain.Name(), ss.strategy, strategy)
+		}
+		ss.strategy = strategy
+	}
+	newStore.name = newStore.Original[config.StoreKeyName]
+	if newStore.name == "" {
+		newStore.name = strconv.Itoa(len(ss.stores))
 	}
 	ss.stores = append(ss.stores, newStore)
+	return nil
 }
 
+var strategies = []string{config.StoreStrategyAll, config.StoreStrategyFirst, config.StoreStrategyFastest, config.StoreStrategyFallback}
+
 func (ss *storeHolders) Get(ctx context.Context, q korrel8r.Query, constraint *korrel8r.Constraint, result korrel8r.Appender) error {
 	errs := unique.NewList[string]()
 	ok := false
-	for _, s := range ss.stores {
-		// Iterate over stores and accumulate all results.
-		err := s.Get(ctx, q, constraint, result)
-		if err != nil {
-			errs.Add(err.Error())
+	switch ss.strategy {
+	case config.StoreStrategyFirst, config.StoreStrategyFallback:
+		// Try stores in order, stop when the strategy is satisfied.
+		for _, s := range ss.stores {
+			objects, err := ss.get(ctx, s, q, constraint)
+			if err != nil {
+				errs.Add(err.Error())
+				continue
+			}
+			ok = true
+			result.Append(objects...)
+			if ss.strategy == config.StoreStrategyFallback || len(objects) > 0 {
+				break
+			}
+		}
+	case config.StoreStrategyFastest:
+		objects, err := ss.fastest(ctx, q, constraint, errs)
+		if err == nil {
+			ok = true
+			result.Append(objects...)
+		}
+	default:
+		for _, s := range ss.stores {
+			// Iterate over stores and accumulate all results.
+			objects, err := ss.get(ctx, s, q, constraint)
+			if err != nil {
+				errs.Add(err.Error())
+			}
+			result.Append(objects...)
+			ok = (err == nil) || ok // Remember if any call succeeds.
 		}
-		ok = (err == nil) || ok // Remember if any call succeeds.
 	}
 	if ok { // If any call succeeded, this is a success
 		if len(errs.List) > 0 {
@@ -181,6 +244,61 @@ func (ss *storeHolders) Get(ctx context.Context, q korrel8r.Query, constraint *k
 	return fmt.Errorf("Get failed: %v", errs.List)
 }
 
+// fastest queries all stores concurrently, returns the first successful result and cancels the others.
+// Errors from stores that fail before the first success are added to errs.
+func (ss *storeHolders) fastest(ctx context.Context, q korrel8r.Query, constraint *korrel8r.Constraint, errs *unique.List[string]) ([]korrel8r.Object, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type response struct {
+		objects []korrel8r.Object
+		err     error
+	}
+	responses := make(chan response, len(ss.stores)) // Buffered, late responses are dropped.
+	for _, s := range ss.stores {
+		go func() {
+			objects, err := ss.get(ctx, s, q, constraint)
+			responses <- response{objects, err}
+		}()
+	}
+	var err error
+	for range ss.stores {
+		r := <-responses
+		if r.err == nil {
+			return r.objects, nil
+		}
+		err = r.err
+		errs.Add(r.err.Error())
+	}
+	return nil, err
+}
+
+// get queries a single store and records per-store metrics.
+func (ss *storeHolders) get(ctx context.Context, s *storeHolder, q korrel8r.Query, constraint *korrel8r.Constraint) (objects []korrel8r.Object, err error) {
+	start := time.Now()
+	err = s.Get(ctx, q, constraint, korrel8r.AppenderFunc(func(o ...korrel8r.Object) {
+		objects = append(objects, o...)
+	}))
+	status := "ok"
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		status = "timeout"
+	case errors.Is(err, context.Canceled):
+		status = "canceled"
+	case err != nil:
+		status = "error"
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("domain", ss.domain.Name()),
+		attribute.String("store", s.name),
+		attribute.String("status", status))
+	metricBackendQueries.Add(ctx, 1, attrs)
+	metricBackendQueryDuration.Record(ctx, time.Since(start).Seconds(), attrs)
+	if err != nil {
+		log.V(3).Info("Store get failed", "domain", ss.domain.Name(), "store", s.name, "error", err, "query", q)
+	}
+	return objects, err
+}
+
 // Configs returns the expanded configurations for each store.
 func (ss *storeHolders) Configs() (ret []config.Store) {
 	for _, s := range ss.stores {
@@ -0,0 +1,54 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package traverse
+
+import (
+	"fmt"
+
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// EventType identifies the kind of progress reported by an [Event].
+type EventType string
+
+const (
+	// EventNode means new objects were added to the result for Class. Count is the number of new objects.
+	EventNode EventType = "node"
+	// EventLine means a rule was followed along Line, producing Query for the goal Class.
+	EventLine EventType = "line"
+	// EventQuery means Query was executed for Class, Count is the number of objects returned.
+	// Line is the line that produced the query, or nil for start queries.
+	EventQuery EventType = "query"
+	// EventError means executing Query failed for all stores of Class with error Err.
+	EventError EventType = "error"
+)
+
+// Event reports incremental progress during a traversal.
+// Fields that are not relevant to the Type are left empty.
+type Event struct {
+	Type  EventType
+	Class korrel8r.Class
+	Line  *graph.Line
+	Query korrel8r.Query
+	Count int
+	Err   error
+}
+
+func (e Event) String() string {
+	switch e.Type {
+	case EventNode:
+		return fmt.Sprintf("%v %v[%v]", e.Type, e.Class, e.Count)
+	case EventLine:
+		return fmt.Sprintf("%v %v", e.Type, e.Line)
+	case EventQuery:
+		return fmt.Sprintf("%v %v[%v]", e.Type, e.Query, e.Count)
+	default:
+		return fmt.Sprintf("%v %v: %v", e.Type, e.Query, e.Err)
+	}
+}
+
+// Listener is called with events as a traversal progresses.
+// Calls are serialized, but may come from different goroutines.
+// A Listener should return quickly, it delays the traversal while it runs.
+type Listener func(Event)
@@ -0,0 +1,48 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package traverse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// Refresh re-executes queries recorded by a previous search, with a new constraint.
+//
+// Rules are not applied and rule paths are not re-computed, so Refresh is much cheaper than a new search.
+// The returned graph has a node for each class of the queries, containing the new results. It has no lines.
+func Refresh(ctx context.Context, e *engine.Engine, queries []korrel8r.Query, constraint *korrel8r.Constraint) (*graph.Graph, error) {
+	log.V(2).Info("Refresh search", "queries", len(queries), "constraint", constraint)
+	data := e.Graph().Data
+	t := newTraverser(e, data.EmptyGraph(), constraint, nil)
+	for _, q := range queries {
+		w := t.workers[q.Class()]
+		if w == nil {
+			n := data.NodeFor(q.Class())
+			if n == nil {
+				return nil, fmt.Errorf("class not found in rule graph: %v", q.Class())
+			}
+			n = n.Copy()
+			t.graph.AddNode(n)
+			w = t.newWorker(n)
+		}
+		w.inbox.Add(ctx, queryLine{Query: q})
+	}
+	var busy sync.WaitGroup
+	for _, w := range t.workers {
+		busy.Go(func() { w.Run(ctx) }) // Workers have no rules, Run only executes queries.
+	}
+	busy.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if t.constraint.HasBudget() {
+		t.graph.Usage = t.budget.usage()
+	}
+	return t.graph, nil
+}
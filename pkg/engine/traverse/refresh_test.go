@@ -0,0 +1,47 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package traverse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefresh(t *testing.T) {
+	b := mock.NewBuilder("d")
+	results := map[string][]korrel8r.Object{}
+	s := mock.NewStore(b.Domain("d"))
+	s.AddLookup(func(q korrel8r.Query) ([]korrel8r.Object, error) { return results[q.String()], nil })
+	var applied int
+	e, err := engine.Build().Rules(
+		b.Rule("ab", "d:a", "d:b", func(korrel8r.Object) ([]korrel8r.Query, error) { applied++; return nil, nil }),
+		b.Rule("bc", "d:b", "d:c", func(korrel8r.Object) ([]korrel8r.Query, error) { applied++; return nil, nil }),
+	).Stores(s).Engine()
+	require.NoError(t, err)
+	queries := []korrel8r.Query{b.Query("d:b", "x"), b.Query("d:b", "y"), b.Query("d:c", "z")}
+
+	results = map[string][]korrel8r.Object{"d:b:x": {1, 2}, "d:b:y": {3}, "d:c:z": {4}}
+	g, err := Refresh(context.Background(), e, queries, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"d:b[1,2,3]", "d:c[4]"}, g.NodeStrings(true))
+	assert.Empty(t, g.LineStrings())
+
+	results = map[string][]korrel8r.Object{"d:b:x": {2, 5}}
+	g, err = Refresh(context.Background(), e, queries, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"d:b[2,5]", "d:c"}, g.NodeStrings(true))
+	n := g.NodeFor(b.Class("d:b"))
+	require.NotNil(t, n)
+	assert.Equal(t, 2, n.Queries.Get(queries[0]))
+	assert.Equal(t, 0, n.Queries.Get(queries[1]))
+	assert.Zero(t, applied, "rules must not be applied")
+
+	_, err = Refresh(context.Background(), e, []korrel8r.Query{b.Query("d:nosuch", "x")}, nil)
+	assert.EqualError(t, err, "class not found in rule graph: d:nosuch")
+}
@@ -0,0 +1,355 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package traverse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/korrel8r/korrel8r/pkg/unique"
+)
+
+// Reverse finds objects of the start classes that lead to the goal objects when rules are followed forward.
+//
+// The goal objects are goal.Objects and the results of goal.Queries.
+// Candidate start objects are the results of the candidates queries.
+// If there are no candidates queries, candidates are found by a goal search from the goal objects
+// back to the start classes; this relies on rules in the reverse direction, which most rule sets have.
+//
+// Candidates are checked by applying rules forward along the rule paths to the goal class,
+// in a single pass shared by all candidates.
+// A generated goal query matches if it is one of goal.Queries, or if it returns one of the goal objects.
+// A candidate is accepted if it leads to a matching goal query.
+// Queries are counted against the budget in goal.Constraint, including those of the backwards search.
+//
+// Returns a graph of the forward rule paths from the accepted start objects to the goal.
+// The goal node contains only the goal objects that were found.
+func Reverse(ctx context.Context, e *engine.Engine, goal Start, starts []korrel8r.Class, candidates []korrel8r.Query) (*graph.Graph, error) {
+	log.V(2).Info("Reverse search", "goal", goal, "starts", starts, "candidates", candidates, "constraint", goal.Constraint)
+	goalClasses := []korrel8r.Class{goal.Class}
+	r := &reverser{
+		constraint:  goal.Constraint,
+		e:           e,
+		budget:      newBudget(goal.Constraint),
+		queries:     map[string]*queryResult{},
+		reached:     map[korrel8r.Class]*reachedObjects{},
+		goalIDs:     unique.NewSet[any](),
+		goalClass:   goal.Class,
+		explain:     goal.Explain,
+		lineQueries: map[lineQuery]unique.Set[int]{},
+	}
+
+	// Collect goal objects, goal queries match by definition.
+	for _, o := range goal.Objects {
+		r.goalIDs.Add(korrel8r.ObjectID(goal.Class, o))
+	}
+	for _, q := range goal.Queries {
+		qr := r.get(ctx, q)
+		qr.match = true
+		for _, o := range qr.objects {
+			r.goalIDs.Add(korrel8r.ObjectID(goal.Class, o))
+		}
+	}
+	if len(r.goalIDs) == 0 {
+		return nil, errors.Join(append([]error{fmt.Errorf("no goal objects found for %v", goal.Class)}, r.errs...)...)
+	}
+
+	// Find start classes with forward rule paths to the goal.
+	full := e.Graph()
+	lines := unique.NewSet[*graph.Line]()
+	var reachable []korrel8r.Class
+	for _, c := range starts {
+		paths, err := full.GoalPaths(c, goalClasses)
+		if err != nil {
+			return nil, err
+		}
+		before := len(lines)
+		paths.EachLine(func(l *graph.Line) { lines.Add(l) })
+		if len(lines) > before {
+			reachable = append(reachable, c)
+		}
+	}
+	if len(reachable) == 0 {
+		return nil, fmt.Errorf("no rule paths from %v to %v", starts, goal.Class)
+	}
+	g := full.Select(func(l *graph.Line) bool { return lines.Has(l) })
+
+	// Collect candidate start objects.
+	if len(candidates) > 0 {
+		for _, q := range candidates {
+			if !slices.Contains(reachable, q.Class()) {
+				log.V(2).Info("Reverse search: no rule path from candidate", "query", q)
+				continue
+			}
+			for _, o := range r.get(ctx, q).objects {
+				r.addCandidate(q.Class(), o)
+			}
+		}
+	} else {
+		back, err := Goals(ctx, e, Start{Class: goal.Class, Objects: r.goalObjects(goal), Constraint: goal.Constraint}, reachable)
+		if err != nil {
+			return nil, err
+		}
+		if back.Usage != nil {
+			r.budget.queries.Add(int64(back.Usage.Queries))
+			r.budget.objects.Add(int64(back.Usage.Objects))
+			r.budget.bytes.Add(int64(back.Usage.Bytes))
+		}
+		for _, c := range reachable {
+			if n := back.NodeFor(c); n != nil {
+				for _, o := range n.Result.List() {
+					r.addCandidate(c, o)
+				}
+			}
+		}
+	}
+
+	// Apply rules forward from all candidates.
+	if err := r.forward(ctx, g); err != nil {
+		return nil, err
+	}
+	accepted := r.accepted()
+	log.V(3).Info("Reverse search: accepted start objects", "starts", len(accepted), "candidates", len(r.candidates))
+	if len(accepted) == 0 && len(r.errs) > 0 {
+		return nil, errors.Join(r.errs...)
+	}
+	if len(r.errs) > 0 {
+		log.V(1).Info("Reverse search: errors", "errors", errors.Join(r.errs...))
+	}
+	r.fill(g, accepted)
+	g.RemoveEmpty()
+	g.RemoveEmptyGoalPaths(goalClasses)
+	if goal.Constraint.HasBudget() {
+		g.Usage = r.budget.usage()
+	}
+	return g, nil
+}
+
+// reverser holds the state of a reverse search.
+type reverser struct {
+	constraint  *korrel8r.Constraint
+	e           *engine.Engine
+	budget      budget
+	errs        []error
+	goalClass   korrel8r.Class
+	goalIDs     unique.Set[any]
+	explain     bool
+	candidates  []candidate
+	queries     map[string]*queryResult            // Results of queries, by query string.
+	reached     map[korrel8r.Class]*reachedObjects // Objects reached from candidates, by class.
+	lineQueries map[lineQuery]unique.Set[int]      // Candidates leading to each query generated by a line.
+}
+
+// candidate start object.
+type candidate struct {
+	class  korrel8r.Class
+	object korrel8r.Object
+}
+
+type queryResult struct {
+	query   korrel8r.Query
+	objects []korrel8r.Object
+	match   bool // Query is one of the goal queries.
+}
+
+type lineQuery struct {
+	line  *graph.Line
+	query string
+}
+
+// reachedObjects are the objects of a class reached from candidates, in order reached.
+type reachedObjects struct {
+	list []*reachedObject
+	byID map[any]*reachedObject
+}
+
+type reachedObject struct {
+	object     korrel8r.Object
+	origins    unique.Set[int] // Indices of candidates that lead to the object.
+	provenance graph.Provenance
+}
+
+// add origins to the object, returns true if there are new origins.
+func (ro *reachedObjects) add(c korrel8r.Class, o korrel8r.Object, origins unique.Set[int], p graph.Provenance) bool {
+	id := korrel8r.ObjectID(c, o)
+	r := ro.byID[id]
+	if r == nil {
+		r = &reachedObject{object: o, origins: unique.NewSet[int](), provenance: p}
+		ro.byID[id] = r
+		ro.list = append(ro.list, r)
+	}
+	before := len(r.origins)
+	for i := range origins {
+		r.origins.Add(i)
+	}
+	return len(r.origins) > before
+}
+
+func (r *reverser) reachedFor(c korrel8r.Class) *reachedObjects {
+	ro := r.reached[c]
+	if ro == nil {
+		ro = &reachedObjects{byID: map[any]*reachedObject{}}
+		r.reached[c] = ro
+	}
+	return ro
+}
+
+func (r *reverser) addCandidate(c korrel8r.Class, o korrel8r.Object) {
+	ro := r.reachedFor(c)
+	if ro.byID[korrel8r.ObjectID(c, o)] != nil {
+		return // Duplicate
+	}
+	ro.add(c, o, unique.NewSet(len(r.candidates)), graph.Provenance{})
+	r.candidates = append(r.candidates, candidate{class: c, object: o})
+}
+
+// goalObjects returns the goal objects: goal.Objects and results of goal.Queries.
+func (r *reverser) goalObjects(goal Start) []korrel8r.Object {
+	objects := result.New(goal.Class)
+	objects.Append(goal.Objects...)
+	for _, q := range goal.Queries {
+		objects.Append(r.queries[q.String()].objects...)
+	}
+	return objects.List()
+}
+
+// get returns the results of a query, each query is only executed once.
+// Queries are not executed once the budget is exhausted.
+func (r *reverser) get(ctx context.Context, q korrel8r.Query) *queryResult {
+	if qr := r.queries[q.String()]; qr != nil {
+		return qr
+	}
+	qr := &queryResult{query: q}
+	r.queries[q.String()] = qr
+	if !r.budget.reserve() {
+		log.V(2).Info("Reverse search budget exhausted", "query", q)
+		return qr
+	}
+	results := result.New(q.Class())
+	if err := r.e.Get(ctx, q, r.constraint, results); err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%v: %w", q, err))
+	}
+	qr.objects = results.List()
+	r.budget.spend(qr.objects)
+	return qr
+}
+
+// forward applies rules along the lines of g from the reached objects, until no new objects or origins are found.
+func (r *reverser) forward(ctx context.Context, g *graph.Graph) error {
+	var pending []korrel8r.Class
+	for _, c := range r.candidates {
+		if !slices.Contains(pending, c.class) {
+			pending = append(pending, c.class)
+		}
+	}
+	for len(pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c := pending[0]
+		pending = pending[1:]
+		n := g.NodeFor(c)
+		if n == nil {
+			continue
+		}
+		g.EachLineFrom(n, func(l *graph.Line) {
+			goal := l.Goal().Class
+			changed := false
+			for _, ro := range slices.Clone(r.reachedFor(c).list) {
+				queries, err := l.Rule.Apply(ro.object)
+				log.V(4).Info("Rule applied", "name", l.Rule.Name(), "start", c, "error", err, "queries", len(queries))
+				for _, q := range queries {
+					if q.Class() != goal {
+						continue
+					}
+					lq := lineQuery{line: l, query: q.String()}
+					if r.lineQueries[lq] == nil {
+						r.lineQueries[lq] = unique.NewSet[int]()
+					}
+					for i := range ro.origins {
+						r.lineQueries[lq].Add(i)
+					}
+					qr := r.get(ctx, q)
+					for _, o := range qr.objects {
+						p := graph.Provenance{Query: q, Line: l, From: ro.object}
+						changed = r.reachedFor(goal).add(goal, o, ro.origins, p) || changed
+					}
+				}
+			}
+			if changed && !slices.Contains(pending, goal) {
+				pending = append(pending, goal)
+			}
+		})
+	}
+	return nil
+}
+
+// accepted returns the set of candidates that lead to a matching goal query.
+func (r *reverser) accepted() unique.Set[int] {
+	accepted := unique.NewSet[int]()
+	for lq, origins := range r.lineQueries {
+		if lq.line.Goal().Class != r.goalClass {
+			continue
+		}
+		qr := r.queries[lq.query]
+		if qr.match || slices.ContainsFunc(qr.objects, r.isGoal) {
+			for i := range origins {
+				accepted.Add(i)
+			}
+		}
+	}
+	return accepted
+}
+
+func (r *reverser) isGoal(o korrel8r.Object) bool {
+	return r.goalIDs.Has(korrel8r.ObjectID(r.goalClass, o))
+}
+
+// fill g with the objects and queries reached from accepted candidates.
+// The goal node only contains goal objects.
+func (r *reverser) fill(g *graph.Graph, accepted unique.Set[int]) {
+	fromAccepted := func(origins unique.Set[int]) bool {
+		for i := range origins {
+			if accepted.Has(i) {
+				return true
+			}
+		}
+		return false
+	}
+	for c, ro := range r.reached {
+		n := g.NodeFor(c)
+		if n == nil {
+			continue
+		}
+		for _, o := range ro.list {
+			if !fromAccepted(o.origins) || (c == r.goalClass && !r.isGoal(o.object)) {
+				continue
+			}
+			n.Result.Append(o.object)
+			if r.explain {
+				if n.Provenance == nil {
+					n.Provenance = map[any]graph.Provenance{}
+				}
+				n.Provenance[korrel8r.ObjectID(c, o.object)] = o.provenance
+			}
+		}
+	}
+	for lq, origins := range r.lineQueries {
+		if !fromAccepted(origins) {
+			continue
+		}
+		qr := r.queries[lq.query]
+		count := len(qr.objects)
+		if lq.line.Goal().Class == r.goalClass {
+			count = len(slices.DeleteFunc(slices.Clone(qr.objects), func(o korrel8r.Object) bool { return !r.isGoal(o) }))
+		}
+		lq.line.Queries.Set(qr.query, count)
+		lq.line.Goal().Queries.Set(qr.query, count)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package traverse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverse(t *testing.T) {
+	b := mock.NewBuilder("d")
+	e, err := engine.Build().Rules(
+		// Forward: start object N leads to goal objects N+10 and 99.
+		b.Rule("sg", "d:s", "d:g", func(start korrel8r.Object) ([]korrel8r.Query, error) {
+			return []korrel8r.Query{b.Query("d:g", fmt.Sprintf("sg/%v", start), start.(int)+10, 99)}, nil
+		}),
+		// Reverse: goal objects lead to candidates, only some of which lead back to the goal.
+		b.Rule("gs", "d:g", "d:s", b.Query("d:s", "gs", 1, 2)),
+		b.Rule("xy", "d:x", "d:y", nil),
+	).Stores(b.Store("d", nil)).Engine()
+	require.NoError(t, err)
+	goal := Start{Class: b.Class("d:g"), Objects: []korrel8r.Object{11}}
+
+	t.Run("search back", func(t *testing.T) {
+		g, err := Reverse(context.Background(), e, goal, b.Classes("d:s"), nil)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"d:s[1]", "d:g[11]"}, g.NodeStrings(true))
+		assert.Equal(t, []string{"sg(d:s->d:g)"}, g.LineStrings())
+	})
+
+	t.Run("candidates", func(t *testing.T) {
+		candidates := []korrel8r.Query{b.Query("d:s", "all", 0, 1, 3)}
+		goal := Start{Class: b.Class("d:g"), Queries: []korrel8r.Query{b.Query("d:g", "goals", 11, 13)}}
+		g, err := Reverse(context.Background(), e, goal, b.Classes("d:s"), candidates)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"d:s[1,3]", "d:g[11,13]"}, g.NodeStrings(true))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		goal := Start{Class: b.Class("d:g"), Objects: []korrel8r.Object{19}}
+		g, err := Reverse(context.Background(), e, goal, b.Classes("d:s"), nil)
+		require.NoError(t, err)
+		assert.Empty(t, g.NodeStrings(true))
+	})
+
+	t.Run("shared goal", func(t *testing.T) {
+		goal := Start{Class: b.Class("d:g"), Objects: []korrel8r.Object{99}}
+		g, err := Reverse(context.Background(), e, goal, b.Classes("d:s"), nil)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"d:s[1,2]", "d:g[99]"}, g.NodeStrings(true))
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		candidates := []korrel8r.Query{b.Query("d:s", "all", 0, 1, 3)}
+		goal := Start{Class: b.Class("d:g"), Objects: []korrel8r.Object{11}, Constraint: &korrel8r.Constraint{MaxQueries: new(2)}}
+		g, err := Reverse(context.Background(), e, goal, b.Classes("d:s"), candidates)
+		require.NoError(t, err)
+		assert.Empty(t, g.NodeStrings(true))
+		assert.Equal(t, &graph.Usage{Queries: 2, Objects: 5, Exhausted: true}, g.Usage)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		candidates := []korrel8r.Query{b.Query("d:s", "bad", errors.New("failed"))}
+		_, err := Reverse(context.Background(), e, goal, b.Classes("d:s"), candidates)
+		assert.ErrorContains(t, err, "failed")
+	})
+
+	t.Run("no path", func(t *testing.T) {
+		_, err := Reverse(context.Background(), e, goal, b.Classes("d:x"), nil)
+		assert.EqualError(t, err, "no rule paths from [d:x] to d:g")
+	})
+
+	t.Run("no goal", func(t *testing.T) {
+		_, err := Reverse(context.Background(), e, Start{Class: b.Class("d:g")}, b.Classes("d:s"), nil)
+		assert.EqualError(t, err, "no goal objects found for d:g")
+	})
+}
@@ -8,16 +8,28 @@
 //  2. A worker is created for each class node. Workers own disjoint data and communicate via query channels.
 //  3. Each iteration runs concurrently:
 //     a. Workers process inbox queries by calling stores (engine.Get) to collect objects.
+//     Within one worker's inbox, queries from higher priority rules (see [korrel8r.Prioritizer]) are processed first.
+//     Priority does not order queries for different workers, they run concurrently.
 //     b. Workers apply correlation rules to new objects, producing queries in their outbox.
 //     c. Outboxes are redistributed to inboxes by query class (sequential barrier).
 //  4. Iterations repeat until no worker has work, the depth limit is reached, or the context is cancelled.
 //  5. Empty nodes and lines are pruned from the result graph.
+//
+// [GoalsStream] and [NeighborsStream] report incremental [Event]s to a [Listener]
+// as each worker completes an iteration, before the final graph is available.
 package traverse
 
 import (
+	"cmp"
 	"context"
+	"maps"
+	"math"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/graph"
@@ -29,24 +41,47 @@ import (
 
 // Goals traverses all paths from start objects to all goal classes.
 func Goals(ctx context.Context, e *engine.Engine, start Start, goals []korrel8r.Class) (*graph.Graph, error) {
-	log.V(2).Info("Goal directed search", "start", start, "goals", goals, "constraint", start.Constraint)
-	g, err := e.Graph().GoalPaths(start.Class, goals)
+	return GoalsStream(ctx, e, start, goals, nil)
+}
+
+// GoalsStream is like [Goals] but also calls listen with incremental events during the traversal.
+// listen may be nil.
+func GoalsStream(ctx context.Context, e *engine.Engine, start Start, goals []korrel8r.Class, listen Listener) (*graph.Graph, error) {
+	log.V(2).Info("Goal directed search", "start", start, "goals", goals, "constraint", start.Constraint, "shortestPaths", start.ShortestPaths)
+	var (
+		g   *graph.Graph
+		err error
+	)
+	if start.ShortestPaths > 0 {
+		g, err = e.Graph().ShortestPaths(start.Class, goals, start.ShortestPaths)
+	} else {
+		g, err = e.Graph().GoalPaths(start.Class, goals)
+	}
 	if err != nil {
 		return nil, err
 	}
-	g, err = newTraverser(e, g, start.Constraint).run(ctx, start, -1)
-	g.RemoveEmptyGoalPaths(goals)
+	g, err = newTraverser(e, g, start.Constraint, listen).run(ctx, -1, start)
+	// If the budget is exhausted keep the partial results, even if they did not reach a goal.
+	if g != nil && (g.Usage == nil || !g.Usage.Exhausted) {
+		g.RemoveEmptyGoalPaths(goals)
+	}
 	return g, err
 }
 
 // Neighbors traverses to all neighbors of the start objects, traversing links up to the given depth.
 func Neighbors(ctx context.Context, e *engine.Engine, start Start, depth int) (*graph.Graph, error) {
+	return NeighborsStream(ctx, e, start, depth, nil)
+}
+
+// NeighborsStream is like [Neighbors] but also calls listen with incremental events during the traversal.
+// listen may be nil.
+func NeighborsStream(ctx context.Context, e *engine.Engine, start Start, depth int, listen Listener) (*graph.Graph, error) {
 	log.V(2).Info("Neighbourhood search", "start", start, "depth", depth, "constraint", start.Constraint)
 	g, err := e.Graph().Neighbors(start.Class, depth) // Reduce the graph.
 	if err != nil {
 		return nil, err
 	}
-	return newTraverser(e, g, start.Constraint).run(ctx, start, depth)
+	return newTraverser(e, g, start.Constraint, listen).run(ctx, depth, start)
 }
 
 // Start point information for graph traversal.
@@ -55,6 +90,11 @@ type Start struct {
 	Objects    []korrel8r.Object    // Start objects, must be of Start class.
 	Queries    []korrel8r.Query     // Queries for start objects, must be of Start class.
 	Constraint *korrel8r.Constraint // Constraint to apply during the traversal.
+	// ShortestPaths if > 0 limits a goal search to the ShortestPaths lowest-cost rule paths to each goal.
+	// Ignored by neighbor searches.
+	ShortestPaths int
+	// Explain records [graph.Provenance] for result objects, see [graph.Node.Explain].
+	Explain bool
 }
 
 var log = logging.Log()
@@ -64,6 +104,64 @@ type traverser struct {
 	graph      *graph.Graph
 	workers    map[korrel8r.Class]*worker
 	constraint *korrel8r.Constraint
+	listen     Listener
+	listenLock sync.Mutex // Serialize calls to listen from concurrent workers.
+	budget     budget
+	explain    bool // Record provenance of result objects.
+}
+
+// budget tracks resources used by all workers, enforces the traversal-wide limits in the constraint.
+type budget struct {
+	maxQueries, maxObjects, maxBytes int64
+	queries, objects, bytes          atomic.Int64
+	exhausted                        atomic.Bool
+}
+
+func newBudget(c *korrel8r.Constraint) budget {
+	return budget{
+		maxQueries: int64(c.GetMaxQueries()),
+		maxObjects: int64(c.GetMaxObjects()),
+		maxBytes:   int64(c.GetMaxBytes()),
+	}
+}
+
+// reserve reserves one query, returns false and marks the budget exhausted if any limit has been reached.
+// The query count is reserved atomically, so concurrent workers never exceed the query limit.
+// Object and byte limits are checked before the query, the last query may exceed them.
+func (b *budget) reserve() bool {
+	ok := (b.maxObjects <= 0 || b.objects.Load() < b.maxObjects) &&
+		(b.maxBytes <= 0 || b.bytes.Load() < b.maxBytes)
+	if ok {
+		if n := b.queries.Add(1); b.maxQueries > 0 && n > b.maxQueries {
+			b.queries.Add(-1)
+			ok = false
+		}
+	}
+	if !ok {
+		b.exhausted.Store(true)
+	}
+	return ok
+}
+
+// spend records the result objects of a reserved query.
+func (b *budget) spend(result []korrel8r.Object) {
+	b.objects.Add(int64(len(result)))
+	if b.maxBytes > 0 { // Only serialize if we need to count bytes.
+		for _, o := range result {
+			if data, err := json.Marshal(o); err == nil {
+				b.bytes.Add(int64(len(data)))
+			}
+		}
+	}
+}
+
+func (b *budget) usage() *graph.Usage {
+	return &graph.Usage{
+		Queries:   int(b.queries.Load()),
+		Objects:   int(b.objects.Load()),
+		Bytes:     int(b.bytes.Load()),
+		Exhausted: b.exhausted.Load(),
+	}
 }
 
 // A worker gets queries from a store, applies rules and sends new queries to other workers.
@@ -76,6 +174,7 @@ type worker struct {
 	ruleAttrs     map[korrel8r.Rule]metric.MeasurementOption       // Pre-computed metric attributes per rule.
 	inbox, outbox queryBox                                         // Incoming and outgoing queries
 	processed     int                                              // Count of node.Result already processed
+	events        []Event                                          // Events collected during Run, if there is a listener.
 }
 
 type queryBox map[korrel8r.Query]queryLine
@@ -89,14 +188,35 @@ func (qb queryBox) Add(ctx context.Context, ql queryLine) bool {
 	return true
 }
 
+// Sorted returns queries in the order they should be executed: start queries first,
+// then by decreasing rule priority, then by query string so the order is repeatable.
+// The order only applies to the queries of one worker, workers run concurrently.
+func (qb queryBox) Sorted() []queryLine {
+	return slices.SortedFunc(maps.Values(qb), func(a, b queryLine) int {
+		if n := cmp.Compare(b.Priority(), a.Priority()); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Query.String(), b.Query.String())
+	})
+}
+
 // queryLine is a query and the graph line associated with it.
 type queryLine struct {
 	Query korrel8r.Query
 	Line  *graph.Line
+	From  korrel8r.Object // Object the Line rule was applied to, only set if explaining.
 }
 
 func (ql queryLine) ID() korrel8r.Query { return ql.Query }
 
+// Priority of the rule that generated the query, start queries have the highest priority.
+func (ql queryLine) Priority() int {
+	if ql.Line == nil {
+		return math.MaxInt
+	}
+	return korrel8r.RulePriority(ql.Line.Rule)
+}
+
 func (ql queryLine) MetricAttributes() metric.MeasurementOption {
 	queryAttr := attribute.String("query", ql.Query.String())
 	if ql.Line != nil {
@@ -105,29 +225,46 @@ func (ql queryLine) MetricAttributes() metric.MeasurementOption {
 	return metric.WithAttributes(queryAttr)
 }
 
-func newTraverser(e *engine.Engine, g *graph.Graph, c *korrel8r.Constraint) *traverser {
+func newTraverser(e *engine.Engine, g *graph.Graph, c *korrel8r.Constraint, listen Listener) *traverser {
 	return &traverser{
 		engine:     e,
 		graph:      g,
 		workers:    map[korrel8r.Class]*worker{},
 		constraint: c,
+		listen:     listen,
+		budget:     newBudget(c),
+	}
+}
+
+// emit sends events collected by a worker to the listener.
+func (t *traverser) emit(events []Event) {
+	if t.listen == nil || len(events) == 0 {
+		return
+	}
+	t.listenLock.Lock()
+	defer t.listenLock.Unlock()
+	for _, e := range events {
+		t.listen(e)
 	}
 }
 
 // run starts the search and waits for it to complete.
 // If depth is >= 0 the search is limited to that depth. If depth < 0 there is no depth limit.
-func (t *traverser) run(ctx context.Context, start Start, depth int) (*graph.Graph, error) {
+func (t *traverser) run(ctx context.Context, depth int, starts ...Start) (*graph.Graph, error) {
 
-	// Prime the start worker
-	startNode, err := t.graph.NodeForErr(start.Class)
-	if err != nil {
-		return nil, err
-	}
-	w := t.newWorker(startNode)
-	w.node.Result.Append(start.Objects...)
-	for _, q := range start.Queries {
-		ql := queryLine{Query: q}
-		w.inbox.Add(ctx, ql)
+	// Prime the start workers
+	for _, start := range starts {
+		t.explain = t.explain || start.Explain
+		startNode, err := t.graph.NodeForErr(start.Class)
+		if err != nil {
+			return nil, err
+		}
+		w := t.newWorker(startNode)
+		w.node.Result.Append(start.Objects...)
+		for _, q := range start.Queries {
+			ql := queryLine{Query: q}
+			w.inbox.Add(ctx, ql)
+		}
 	}
 
 	// Create workers for start and goal of all rules in the graph.
@@ -169,7 +306,12 @@ func (t *traverser) run(ctx context.Context, start Start, depth int) (*graph.Gra
 		// Process inboxes concurrently, fill outboxes
 		var busy sync.WaitGroup
 		for _, w := range working {
-			busy.Go(func() { ; w.Run(ctx) })
+			busy.Go(func() {
+				w.Run(ctx)
+				// Report events as soon as this worker is done, don't wait for slower workers.
+				t.emit(w.events)
+				w.events = w.events[:0]
+			})
 		}
 		busy.Wait() // Wait for worker.Run() goroutines to complete.
 
@@ -184,6 +326,9 @@ func (t *traverser) run(ctx context.Context, start Start, depth int) (*graph.Gra
 		}
 	}
 	t.graph.RemoveEmpty()
+	if t.constraint.HasBudget() {
+		t.graph.Usage = t.budget.usage()
+	}
 	return t.graph, nil
 }
 
@@ -224,22 +369,34 @@ func (w *worker) Run(ctx context.Context) {
 	}()
 	// Process queries from inbox, apply status rules per query.
 	statusRules := w.engine.StatusRulesFor(w.node.Class)
-	for _, ql := range w.inbox {
+	for _, ql := range w.inbox.Sorted() {
 		if ctx.Err() != nil {
 			return
 		}
 		if w.overQueryLimit() {
 			break
 		}
+		if !w.budget.reserve() {
+			log.V(2).Info("Traversal budget exhausted", "class", w.node.Class, "query", ql.Query)
+			break
+		}
 		before := len(w.node.Result.List())
 		// Error is logged by engine.Get
-		_ = w.engine.Get(ctx, ql.Query, w.constraint, w.node.Result)
+		err := w.engine.Get(ctx, ql.Query, w.constraint, w.node.Result)
 		result := w.node.Result.List()[before:]
+		w.budget.spend(result)
+		if w.explain {
+			w.provenance(ql, result)
+		}
 		metricQueries.Add(ctx, 1, ql.MetricAttributes())
 		w.node.Queries.Set(ql.Query, len(result))
 		if ql.Line != nil {
 			ql.Line.Queries.Set(ql.Query, len(result))
 		}
+		w.event(Event{Type: EventQuery, Class: w.node.Class, Line: ql.Line, Query: ql.Query, Count: len(result)})
+		if err != nil {
+			w.event(Event{Type: EventError, Class: w.node.Class, Line: ql.Line, Query: ql.Query, Err: err})
+		}
 		statusCounts := map[string]int{}
 		for _, o := range result {
 			for _, r := range statusRules {
@@ -254,6 +411,10 @@ func (w *worker) Run(ctx context.Context) {
 		}
 	}
 
+	if added := len(w.node.Result.List()) - w.processed; added > 0 {
+		w.event(Event{Type: EventNode, Class: w.node.Class, Count: added})
+	}
+
 	// Apply correlation rules to un-processed results, generate queries in outbox.
 	for _, o := range w.node.Result.List()[w.processed:] {
 		for r := range w.rules {
@@ -267,9 +428,31 @@ func (w *worker) Run(ctx context.Context) {
 				if line := w.lines[r][q.Class()]; line != nil {
 					log.V(5).Info("Add line", "line", line, "query", q)
 					ql := queryLine{Query: q, Line: line}
-					w.outbox.Add(ctx, ql)
+					if w.explain {
+						ql.From = o
+					}
+					if w.outbox.Add(ctx, ql) {
+						w.event(Event{Type: EventLine, Class: line.Goal().Class, Line: line, Query: q})
+					}
 				}
 			}
 		}
 	}
 }
+
+// provenance records the origin of new result objects.
+func (w *worker) provenance(ql queryLine, result []korrel8r.Object) {
+	if w.node.Provenance == nil {
+		w.node.Provenance = map[any]graph.Provenance{}
+	}
+	for _, o := range result {
+		w.node.Provenance[korrel8r.ObjectID(w.node.Class, o)] = graph.Provenance{Query: ql.Query, Line: ql.Line, From: ql.From}
+	}
+}
+
+// event records an event to be sent to the listener when Run completes.
+func (w *worker) event(e Event) {
+	if w.listen != nil {
+		w.events = append(w.events, e)
+	}
+}
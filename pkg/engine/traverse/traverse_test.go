@@ -4,6 +4,7 @@ package traverse
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"testing"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
 	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -118,6 +120,15 @@ func TestTraverserTwoPaths(t *testing.T) {
 			assert.ElementsMatch(t, wantNodes, g.NodeStrings(true))
 		}
 	})
+
+	t.Run("ShortestPaths", func(t *testing.T) {
+		start := Start{Class: b.Class("d:a"), Objects: []korrel8r.Object{0}, ShortestPaths: 1}
+		g, err := Goals(context.Background(), e, start, []korrel8r.Class{b.Class(("d:c"))})
+		if assert.NoError(t, err) {
+			assert.ElementsMatch(t, []string{"ac(d:a->d:c)"}, g.LineStrings())
+			assert.ElementsMatch(t, []string{"d:a[0]", "d:c[3]"}, g.NodeStrings(true))
+		}
+	})
 }
 
 func TestTraverserCycle(t *testing.T) {
@@ -327,3 +338,174 @@ func TestTraverserNeighbors(t *testing.T) {
 		})
 	}
 }
+
+func TestTraverserPriority(t *testing.T) {
+	b := mock.NewBuilder("d")
+	a, bc := b.Class("d:a"), b.Class("d:b")
+	e, err := engine.Build().Rules(
+		mock.NewRule("low", []korrel8r.Class{a}, []korrel8r.Class{bc}, b.Query("d:b", "low", 1)).WithPriority(-1),
+		mock.NewRule("mid", []korrel8r.Class{a}, []korrel8r.Class{bc}, b.Query("d:b", "mid", 2)),
+		mock.NewRule("high", []korrel8r.Class{a}, []korrel8r.Class{bc}, b.Query("d:b", "high", 3)).WithPriority(5),
+	).Stores(b.Store("d", nil)).Engine()
+	require.NoError(t, err)
+
+	for _, x := range []struct {
+		queryLimit int
+		want       string
+	}{
+		// Highest priority queries are executed before the limit is reached.
+		{queryLimit: 1, want: "d:b[2,3]"},
+		{queryLimit: 2, want: "d:b[1,2,3]"},
+	} {
+		t.Run(fmt.Sprintf("limit=%v", x.queryLimit), func(t *testing.T) {
+			start := Start{
+				Class:      a,
+				Objects:    []korrel8r.Object{0},
+				Constraint: &korrel8r.Constraint{QueryLimit: &x.queryLimit},
+			}
+			g, err := Goals(context.Background(), e, start, []korrel8r.Class{bc})
+			require.NoError(t, err)
+			assert.Equal(t, x.want, g.NodeFor(bc).String(true))
+		})
+	}
+}
+
+func TestTraverserBudget(t *testing.T) {
+	b := mock.NewBuilder("d")
+	e, err := engine.Build().Rules(
+		b.Rule("ab", "d:a", "d:b", b.Query("d:b", "ab", 1, 2)),
+		b.Rule("bc", "d:b", "d:c", func(start korrel8r.Object) ([]korrel8r.Query, error) {
+			return []korrel8r.Query{b.Query("d:c", fmt.Sprintf("bc/%v", start), start)}, nil
+		}),
+	).Stores(b.Store("d", nil)).Engine()
+	require.NoError(t, err)
+
+	for _, x := range []struct {
+		name       string
+		constraint *korrel8r.Constraint
+		nodes      []string
+		usage      *graph.Usage
+	}{
+		{
+			name:  "no budget",
+			nodes: []string{"d:a[0]", "d:b[1,2]", "d:c[1,2]"},
+		},
+		{
+			name:       "large budget",
+			constraint: &korrel8r.Constraint{MaxQueries: new(10), MaxObjects: new(10), MaxBytes: new(100)},
+			nodes:      []string{"d:a[0]", "d:b[1,2]", "d:c[1,2]"},
+			usage:      &graph.Usage{Queries: 3, Objects: 4, Bytes: 4},
+		},
+		{
+			name:       "max queries",
+			constraint: &korrel8r.Constraint{MaxQueries: new(2)},
+			nodes:      []string{"d:a[0]", "d:b[1,2]", "d:c[1]"},
+			usage:      &graph.Usage{Queries: 2, Objects: 3, Exhausted: true},
+		},
+		{
+			name:       "max objects",
+			constraint: &korrel8r.Constraint{MaxObjects: new(2)},
+			nodes:      []string{"d:a[0]", "d:b[1,2]"}, // Partial results are kept when the budget is exhausted.
+			usage:      &graph.Usage{Queries: 1, Objects: 2, Exhausted: true},
+		},
+		{
+			name:       "max bytes",
+			constraint: &korrel8r.Constraint{MaxBytes: new(3)},
+			nodes:      []string{"d:a[0]", "d:b[1,2]", "d:c[1]"},
+			usage:      &graph.Usage{Queries: 2, Objects: 3, Bytes: 3, Exhausted: true},
+		},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			start := Start{Class: b.Class("d:a"), Objects: []korrel8r.Object{0}, Constraint: x.constraint}
+			g, err := Goals(context.Background(), e, start, b.Classes("d:c"))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, x.nodes, g.NodeStrings(true))
+			assert.Equal(t, x.usage, g.Usage)
+		})
+	}
+}
+
+func TestTraverserGoalsStream(t *testing.T) {
+	b := mock.NewBuilder("d")
+	e, err := engine.Build().Rules(
+		b.Rule("ab", "d:a", "d:b", b.Query("d:b", "ab", 1, 2)),
+		b.Rule("ac", "d:a", "d:c", b.Query("d:c", "ac", errors.New("broken"))),
+	).Stores(b.Store("d", nil)).Engine()
+	require.NoError(t, err)
+
+	var events []string
+	start := Start{Class: b.Class("d:a"), Objects: []korrel8r.Object{0}}
+	g, err := GoalsStream(context.Background(), e, start, b.Classes("d:b", "d:c"), func(e Event) {
+		events = append(events, e.String())
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"d:a[0]", "d:b[1,2]"}, g.NodeStrings(true))
+	assert.ElementsMatch(t, []string{
+		"node d:a[1]",
+		"line ab(d:a->d:b)",
+		"line ac(d:a->d:c)",
+		"query d:b:ab[2]",
+		"node d:b[2]",
+		"query d:c:ac[0]",
+		"error d:c:ac: Get failed: [broken]",
+	}, events)
+}
+
+func TestTraverserExplain(t *testing.T) {
+	b := mock.NewBuilder("d")
+	ab := b.Rule("ab", "d:a", "d:b", b.Query("d:b", "ab", 1, 2))
+	bc := b.Rule("bc", "d:b", "d:c", func(start korrel8r.Object) ([]korrel8r.Query, error) {
+		return []korrel8r.Query{b.Query("d:c", fmt.Sprintf("bc/%v", start), start.(int)*10)}, nil
+	})
+	e, err := engine.Build().Rules(ab, bc).Stores(b.Store("d", nil)).Engine()
+	require.NoError(t, err)
+	startQuery := b.Query("d:a", "start", 0)
+
+	type step struct {
+		class  string
+		object korrel8r.Object
+		rule   string
+		query  string
+		from   korrel8r.Object
+	}
+	steps := func(chain []graph.Step) (got []step) {
+		for _, s := range chain {
+			st := step{class: s.Node.Class.String(), object: s.Object, from: s.From}
+			if s.Line != nil {
+				st.rule = s.Line.Rule.Name()
+			}
+			if s.Query != nil {
+				st.query = s.Query.String()
+			}
+			got = append(got, st)
+		}
+		return got
+	}
+
+	start := Start{Class: b.Class("d:a"), Queries: []korrel8r.Query{startQuery}, Explain: true}
+	g, err := Goals(context.Background(), e, start, b.Classes("d:c"))
+	require.NoError(t, err)
+	c := g.NodeFor(b.Class("d:c"))
+	require.NotNil(t, c)
+	assert.Equal(t, []step{
+		{class: "d:a", object: 0, query: "d:a:start"},
+		{class: "d:b", object: 2, rule: "ab", query: "d:b:ab", from: 0},
+		{class: "d:c", object: 20, rule: "bc", query: "d:c:bc/2", from: 2},
+	}, steps(c.Explain(20)))
+	assert.Nil(t, c.Explain(99))
+
+	// Start objects have a chain with no query.
+	start = Start{Class: b.Class("d:a"), Objects: []korrel8r.Object{0}, Explain: true}
+	g, err = Goals(context.Background(), e, start, b.Classes("d:b"))
+	require.NoError(t, err)
+	assert.Equal(t, []step{
+		{class: "d:a", object: 0},
+		{class: "d:b", object: 1, rule: "ab", query: "d:b:ab", from: 0},
+	}, steps(g.NodeFor(b.Class("d:b")).Explain(1)))
+
+	// No provenance without Explain.
+	start.Explain = false
+	g, err = Goals(context.Background(), e, start, b.Classes("d:b"))
+	require.NoError(t, err)
+	assert.Nil(t, g.NodeFor(b.Class("d:b")).Explain(1))
+}
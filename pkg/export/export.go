@@ -0,0 +1,301 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package export writes correlation result graphs in formats used by graph visualization tools.
+//
+// Node labels show the class, the number of results and the statuses of the results.
+// Edge labels show rule names, if the graph includes rules.
+package export
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
+)
+
+// Format is a graph export format.
+type Format string
+
+const (
+	DOT       Format = "dot"       // Graphviz DOT language.
+	Mermaid   Format = "mermaid"   // Mermaid flowchart.
+	GraphML   Format = "graphml"   // GraphML XML.
+	Cytoscape Format = "cytoscape" // Cytoscape.js elements JSON.
+)
+
+// Formats lists all export formats.
+var Formats = []Format{Cytoscape, DOT, GraphML, Mermaid}
+
+var mimeTypes = map[Format]string{
+	DOT:       "text/vnd.graphviz",
+	Mermaid:   "text/vnd.mermaid",
+	GraphML:   "application/graphml+xml",
+	Cytoscape: "application/vnd.cytoscape+json",
+}
+
+// MIMEType returns the media type for the format.
+func (f Format) MIMEType() string { return mimeTypes[f] }
+
+// ForMIMEType returns the format for a media type, or false if there is none.
+func ForMIMEType(mimeType string) (Format, bool) {
+	for f, m := range mimeTypes {
+		if m == mimeType {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// Write writes graph g to w in format f.
+func Write(w io.Writer, f Format, g *api.Graph) error {
+	switch f {
+	case DOT:
+		return writeDOT(w, g)
+	case Mermaid:
+		return writeMermaid(w, g)
+	case GraphML:
+		return writeGraphML(w, g)
+	case Cytoscape:
+		return writeCytoscape(w, g)
+	default:
+		return fmt.Errorf("invalid graph export format: %q", f)
+	}
+}
+
+// statuses returns the total status counts of all queries for a node, sorted by status.
+func statuses(n *api.Node) []api.StatusCount {
+	counts := map[string]int{}
+	for _, qc := range n.Queries {
+		for _, sc := range qc.Statuses {
+			counts[sc.Status] += ptr.Deref(sc.Count)
+		}
+	}
+	var result []api.StatusCount
+	for _, s := range slices.Sorted(maps.Keys(counts)) {
+		result = append(result, api.StatusCount{Status: s, Count: new(counts[s])})
+	}
+	return result
+}
+
+// statusText returns the node statuses as text, for example "Failed: 1, Running: 2".
+func statusText(n *api.Node) string {
+	var ss []string
+	for _, sc := range statuses(n) {
+		ss = append(ss, fmt.Sprintf("%v: %v", sc.Status, ptr.Deref(sc.Count)))
+	}
+	return strings.Join(ss, ", ")
+}
+
+// labelLines returns the lines of a node label: class, count and statuses.
+func labelLines(n *api.Node) []string {
+	lines := []string{n.Class}
+	if n.Count != nil {
+		lines = append(lines, fmt.Sprintf("count: %v", *n.Count))
+	}
+	if s := statusText(n); s != "" {
+		lines = append(lines, s)
+	}
+	return lines
+}
+
+func ruleNames(e *api.Edge) []string {
+	var names []string
+	for _, r := range e.Rules {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// sorted returns nodes and edges in a predictable order, without modifying g.
+func sorted(g *api.Graph) ([]api.Node, []api.Edge) {
+	nodes := slices.SortedFunc(slices.Values(g.Nodes), func(a, b api.Node) int { return cmp.Compare(a.Class, b.Class) })
+	edges := slices.SortedFunc(slices.Values(g.Edges), func(a, b api.Edge) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.Goal, b.Goal))
+	})
+	return nodes, edges
+}
+
+func writeDOT(w io.Writer, g *api.Graph) error {
+	nodes, edges := sorted(g)
+	b := &strings.Builder{}
+	b.WriteString("digraph korrel8r {\n")
+	for _, n := range nodes {
+		fmt.Fprintf(b, "  %v [label=%v];\n", strconv.Quote(n.Class), strconv.Quote(strings.Join(labelLines(&n), "\n")))
+	}
+	for _, e := range edges {
+		fmt.Fprintf(b, "  %v -> %v", strconv.Quote(e.Start), strconv.Quote(e.Goal))
+		if names := ruleNames(&e); len(names) > 0 {
+			fmt.Fprintf(b, " [label=%v]", strconv.Quote(strings.Join(names, "\n")))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidText escapes text for a quoted Mermaid label.
+var mermaidText = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+
+func writeMermaid(w io.Writer, g *api.Graph) error {
+	nodes, edges := sorted(g)
+	// Class names are not valid Mermaid identifiers, use generated IDs.
+	ids := map[string]string{}
+	b := &strings.Builder{}
+	b.WriteString("flowchart LR\n")
+	for i, n := range nodes {
+		ids[n.Class] = fmt.Sprintf("n%v", i)
+		lines := labelLines(&n)
+		for j := range lines {
+			lines[j] = mermaidText.Replace(lines[j])
+		}
+		fmt.Fprintf(b, "  %v[\"%v\"]\n", ids[n.Class], strings.Join(lines, "<br/>"))
+	}
+	for _, e := range edges {
+		start, goal := ids[e.Start], ids[e.Goal]
+		if start == "" || goal == "" {
+			return fmt.Errorf("edge has no node: %v -> %v", e.Start, e.Goal)
+		}
+		fmt.Fprintf(b, "  %v -->", start)
+		if names := ruleNames(&e); len(names) > 0 {
+			for j := range names {
+				names[j] = mermaidText.Replace(names[j])
+			}
+			fmt.Fprintf(b, "|\"%v\"|", strings.Join(names, "<br/>"))
+		}
+		fmt.Fprintf(b, " %v\n", goal)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data,omitempty"`
+}
+
+func writeGraphML(w io.Writer, g *api.Graph) error {
+	nodes, edges := sorted(g)
+	gml := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{ID: "count", For: "node", AttrName: "count", AttrType: "int"},
+			{ID: "statuses", For: "node", AttrName: "statuses", AttrType: "string"},
+			{ID: "rules", For: "edge", AttrName: "rules", AttrType: "string"},
+		},
+	}
+	gml.Graph.EdgeDefault = "directed"
+	for _, n := range nodes {
+		gn := graphMLNode{ID: n.Class, Data: []graphMLData{{Key: "label", Value: strings.Join(labelLines(&n), "\n")}}}
+		if n.Count != nil {
+			gn.Data = append(gn.Data, graphMLData{Key: "count", Value: strconv.Itoa(*n.Count)})
+		}
+		if s := statusText(&n); s != "" {
+			gn.Data = append(gn.Data, graphMLData{Key: "statuses", Value: s})
+		}
+		gml.Graph.Nodes = append(gml.Graph.Nodes, gn)
+	}
+	for _, e := range edges {
+		ge := graphMLEdge{Source: e.Start, Target: e.Goal}
+		if names := ruleNames(&e); len(names) > 0 {
+			ge.Data = []graphMLData{{Key: "rules", Value: strings.Join(names, ", ")}}
+		}
+		gml.Graph.Edges = append(gml.Graph.Edges, ge)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(gml); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// cytoscapeElements is the Cytoscape.js elements JSON format.
+type cytoscapeElements struct {
+	Nodes []cytoscapeElement `json:"nodes"`
+	Edges []cytoscapeElement `json:"edges"`
+}
+
+type cytoscapeElement struct {
+	Data cytoscapeData `json:"data"`
+}
+
+type cytoscapeData struct {
+	ID       string            `json:"id"`
+	Label    string            `json:"label,omitempty"`
+	Source   string            `json:"source,omitempty"`
+	Target   string            `json:"target,omitempty"`
+	Count    *int              `json:"count,omitempty"`
+	Statuses []api.StatusCount `json:"statuses,omitempty"`
+	Rules    []string          `json:"rules,omitempty"`
+}
+
+func writeCytoscape(w io.Writer, g *api.Graph) error {
+	nodes, edges := sorted(g)
+	elements := cytoscapeElements{Nodes: []cytoscapeElement{}, Edges: []cytoscapeElement{}}
+	for _, n := range nodes {
+		elements.Nodes = append(elements.Nodes, cytoscapeElement{Data: cytoscapeData{
+			ID:       n.Class,
+			Label:    strings.Join(labelLines(&n), "\n"),
+			Count:    n.Count,
+			Statuses: statuses(&n),
+		}})
+	}
+	for _, e := range edges {
+		names := ruleNames(&e)
+		elements.Edges = append(elements.Edges, cytoscapeElement{Data: cytoscapeData{
+			ID:     e.Start + "->" + e.Goal,
+			Label:  strings.Join(names, "\n"),
+			Source: e.Start,
+			Target: e.Goal,
+			Rules:  names,
+		}})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Elements cytoscapeElements `json:"elements"`
+	}{elements})
+}
@@ -0,0 +1,121 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph() *api.Graph {
+	return &api.Graph{
+		Nodes: []api.Node{
+			{Class: "k8s:Pod.v1", Count: new(3), Queries: []api.QueryCount{
+				{Query: "k8s:Pod.v1:{a}", Count: new(2), Statuses: []api.StatusCount{{Status: "Running", Count: new(2)}}},
+				{Query: "k8s:Pod.v1:{b}", Count: new(1), Statuses: []api.StatusCount{{Status: "Failed", Count: new(1)}}},
+			}},
+			{Class: "alert:alert", Count: new(1)},
+		},
+		Edges: []api.Edge{{Start: "alert:alert", Goal: "k8s:Pod.v1", Rules: []api.Rule{{Name: "AlertToPod"}}}},
+	}
+}
+
+func write(t *testing.T, f Format, g *api.Graph) string {
+	t.Helper()
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, f, g))
+	return b.String()
+}
+
+func TestDOT(t *testing.T) {
+	assert.Equal(t, `digraph korrel8r {
+  "alert:alert" [label="alert:alert\ncount: 1"];
+  "k8s:Pod.v1" [label="k8s:Pod.v1\ncount: 3\nFailed: 1, Running: 2"];
+  "alert:alert" -> "k8s:Pod.v1" [label="AlertToPod"];
+}
+`, write(t, DOT, testGraph()))
+}
+
+func TestMermaid(t *testing.T) {
+	assert.Equal(t, `flowchart LR
+  n0["alert:alert<br/>count: 1"]
+  n1["k8s:Pod.v1<br/>count: 3<br/>Failed: 1, Running: 2"]
+  n0 -->|"AlertToPod"| n1
+`, write(t, Mermaid, testGraph()))
+}
+
+func TestMermaid_multipleRules(t *testing.T) {
+	g := testGraph()
+	g.Edges[0].Rules = append(g.Edges[0].Rules, api.Rule{Name: "Alert<Pod>"})
+	assert.Equal(t, `flowchart LR
+  n0["alert:alert<br/>count: 1"]
+  n1["k8s:Pod.v1<br/>count: 3<br/>Failed: 1, Running: 2"]
+  n0 -->|"AlertToPod<br/>Alert#lt;Pod#gt;"| n1
+`, write(t, Mermaid, g))
+}
+
+func TestMermaid_badEdge(t *testing.T) {
+	g := testGraph()
+	g.Edges = append(g.Edges, api.Edge{Start: "alert:alert", Goal: "log:application"})
+	assert.Error(t, Write(&bytes.Buffer{}, Mermaid, g))
+}
+
+func TestGraphML(t *testing.T) {
+	out := write(t, GraphML, testGraph())
+	var gml graphML
+	require.NoError(t, xml.Unmarshal([]byte(out), &gml))
+	assert.Equal(t, "directed", gml.Graph.EdgeDefault)
+	assert.Equal(t, []graphMLNode{
+		{ID: "alert:alert", Data: []graphMLData{{"label", "alert:alert\ncount: 1"}, {"count", "1"}}},
+		{ID: "k8s:Pod.v1", Data: []graphMLData{
+			{"label", "k8s:Pod.v1\ncount: 3\nFailed: 1, Running: 2"}, {"count", "3"}, {"statuses", "Failed: 1, Running: 2"}}},
+	}, gml.Graph.Nodes)
+	assert.Equal(t, []graphMLEdge{
+		{Source: "alert:alert", Target: "k8s:Pod.v1", Data: []graphMLData{{"rules", "AlertToPod"}}},
+	}, gml.Graph.Edges)
+}
+
+func TestCytoscape(t *testing.T) {
+	out := write(t, Cytoscape, testGraph())
+	assert.JSONEq(t, `{"elements": {
+  "nodes": [
+    {"data": {"id": "alert:alert", "label": "alert:alert\ncount: 1", "count": 1}},
+    {"data": {"id": "k8s:Pod.v1", "label": "k8s:Pod.v1\ncount: 3\nFailed: 1, Running: 2", "count": 3,
+      "statuses": [{"status": "Failed", "count": 1}, {"status": "Running", "count": 2}]}}
+  ],
+  "edges": [
+    {"data": {"id": "alert:alert->k8s:Pod.v1", "label": "AlertToPod", "source": "alert:alert", "target": "k8s:Pod.v1", "rules": ["AlertToPod"]}}
+  ]
+}}`, out)
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(out), &v))
+}
+
+func TestWrite_empty(t *testing.T) {
+	for _, f := range Formats {
+		t.Run(string(f), func(t *testing.T) {
+			assert.NotEmpty(t, write(t, f, &api.Graph{}))
+		})
+	}
+	assert.Equal(t, "{\n  \"elements\": {\n    \"nodes\": [],\n    \"edges\": []\n  }\n}\n", write(t, Cytoscape, &api.Graph{}))
+}
+
+func TestWrite_invalid(t *testing.T) {
+	assert.Error(t, Write(&bytes.Buffer{}, "nonesuch", &api.Graph{}))
+}
+
+func TestForMIMEType(t *testing.T) {
+	for _, f := range Formats {
+		got, ok := ForMIMEType(f.MIMEType())
+		assert.True(t, ok)
+		assert.Equal(t, f, got)
+	}
+	_, ok := ForMIMEType("application/json")
+	assert.False(t, ok)
+}
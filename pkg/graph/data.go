@@ -124,6 +124,18 @@ type Node struct {
 	Class   korrel8r.Class
 	Result  result.Result // Accumulate incoming query results.
 	Queries Queries       // All queries leading to this node.
+	// Provenance of result objects keyed by [korrel8r.ObjectID], only recorded if requested.
+	Provenance map[any]Provenance
+}
+
+// Provenance records how a result object was first found during a traversal.
+type Provenance struct {
+	// Query that returned the object, nil for start objects.
+	Query korrel8r.Query
+	// Line that generated the query, nil for start queries.
+	Line *Line
+	// From is the object in the Line start node that the rule was applied to, nil for start queries.
+	From korrel8r.Object
 }
 
 // Copy returns a new Node with the same identity but fresh mutable state.
@@ -237,3 +249,39 @@ func (e Edge) EachLine(visit func(*Line)) {
 		visit(lines.Line().(*Line))
 	}
 }
+
+// Step in a provenance chain, see [Node.Explain].
+type Step struct {
+	Node   *Node
+	Object korrel8r.Object
+	Provenance
+}
+
+// Explain returns the provenance chain for a result object of this node.
+// The chain starts with the start object or query and ends with o.
+// Returns nil if no provenance was recorded for o.
+func (n *Node) Explain(o korrel8r.Object) (chain []Step) {
+	type key struct {
+		n  *Node
+		id any
+	}
+	visited := map[key]bool{} // Guard against cycles.
+	for n != nil && o != nil {
+		id := korrel8r.ObjectID(n.Class, o)
+		if visited[key{n, id}] {
+			break
+		}
+		visited[key{n, id}] = true
+		p, ok := n.Provenance[id]
+		if !ok && len(chain) == 0 {
+			return nil
+		}
+		chain = append(chain, Step{Node: n, Object: o, Provenance: p})
+		if p.Line == nil {
+			break
+		}
+		n, o = p.Line.Start(), p.From
+	}
+	slices.Reverse(chain)
+	return chain
+}
@@ -29,6 +29,15 @@ type Graph struct {
 	*multi.DirectedGraph
 	GraphAttrs, NodeAttrs, EdgeAttrs Attrs
 	Data                             *Data
+	// Usage of the traversal budget, nil if the traversal had no budget.
+	Usage *Usage
+}
+
+// Usage records resources used by a traversal, see [korrel8r.Constraint.HasBudget].
+type Usage struct {
+	Queries, Objects, Bytes int
+	// Exhausted is true if the traversal skipped queries because the budget was used up.
+	Exhausted bool
 }
 
 // New empty graph based on Data
@@ -163,6 +172,21 @@ func (g *Graph) DOTAttributers() (graph, node, edge encoding.Attributer) {
 // GoalPaths returns a new rules sub-graph of nodes on a path to the goal class.
 // Includes k-shortest paths with cost <= shortest+1.
 func (g *Graph) GoalPaths(start korrel8r.Class, goals []korrel8r.Class) (*Graph, error) {
+	return g.goalPaths(start, goals, -1, 1)
+}
+
+// ShortestPaths returns a new rules sub-graph with only the k lowest-cost paths to each goal class.
+// The cost of a path is the sum of [Graph.Weight] for its edges.
+// Includes all lines on the selected paths, k must be > 0.
+func (g *Graph) ShortestPaths(start korrel8r.Class, goals []korrel8r.Class, k int) (*Graph, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid number of shortest paths: %v", k)
+	}
+	return g.goalPaths(start, goals, k, math.Inf(1))
+}
+
+// goalPaths includes up to k paths (all if k < 0) with cost <= shortest+cost.
+func (g *Graph) goalPaths(start korrel8r.Class, goals []korrel8r.Class, k int, cost float64) (*Graph, error) {
 	u, err := g.NodeForErr(start)
 	if err != nil {
 		return nil, err
@@ -174,8 +198,7 @@ func (g *Graph) GoalPaths(start korrel8r.Class, goals []korrel8r.Class) (*Graph,
 		if err != nil {
 			return nil, err
 		}
-		// Find shortest paths, and shortest+1 paths
-		paths := path.YenKShortestPaths(g, -1, 1, u, v)
+		paths := path.YenKShortestPaths(g, k, cost, u, v)
 		for _, path := range paths {
 			for i := 1; i < len(path); i++ {
 				lines := g.Lines(path[i-1].ID(), path[i].ID())
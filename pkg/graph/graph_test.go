@@ -84,6 +84,74 @@ func TestGoalPaths(t *testing.T) {
 	}
 }
 
+func TestShortestPaths(t *testing.T) {
+	b := mock.NewBuilder("d")
+	r := b.Rule
+	// Paths to d:
+	// a-(b1,b2)-c-d cost 3
+	// a-x-y-z-d cost 4
+	// a-x-y-z-e-d cost 5
+	g := NewData(
+		r("ab", "d:a", "d:b", nil),
+		r("bc1", "d:b", "d:c", nil),
+		r("bc2", "d:b", "d:c", nil),
+		r("cd", "d:c", "d:d", nil),
+		r("ax", "d:a", "d:x", nil),
+		r("xy", "d:x", "d:y", nil),
+		r("yz", "d:y", "d:z", nil),
+		r("zd", "d:z", "d:d", nil),
+		r("ze", "d:z", "d:e", nil),
+		r("ed", "d:e", "d:d", nil),
+		// Wildcard rule has a high weight, path a-w-d cost 5+1
+		r("aw", "d:a", []string{"d:w", "d:p", "d:q", "d:r", "d:s"}, nil),
+		r("wd", "d:w", "d:d", nil),
+	).FullGraph()
+
+	for _, x := range []struct {
+		k            int
+		lines, nodes []string
+	}{
+		{
+			k:     1,
+			lines: []string{"ab(d:a->d:b)", "bc1(d:b->d:c)", "bc2(d:b->d:c)", "cd(d:c->d:d)"},
+			nodes: []string{"d:a", "d:b", "d:c", "d:d"},
+		},
+		{
+			k: 2,
+			lines: []string{
+				"ab(d:a->d:b)", "bc1(d:b->d:c)", "bc2(d:b->d:c)", "cd(d:c->d:d)",
+				"ax(d:a->d:x)", "xy(d:x->d:y)", "yz(d:y->d:z)", "zd(d:z->d:d)"},
+			nodes: []string{"d:a", "d:b", "d:c", "d:d", "d:x", "d:y", "d:z"},
+		},
+		{
+			k: 3,
+			lines: []string{
+				"ab(d:a->d:b)", "bc1(d:b->d:c)", "bc2(d:b->d:c)", "cd(d:c->d:d)",
+				"ax(d:a->d:x)", "xy(d:x->d:y)", "yz(d:y->d:z)", "zd(d:z->d:d)", "ze(d:z->d:e)", "ed(d:e->d:d)"},
+			nodes: []string{"d:a", "d:b", "d:c", "d:d", "d:e", "d:x", "d:y", "d:z"},
+		},
+		{
+			k: 4,
+			lines: []string{
+				"ab(d:a->d:b)", "bc1(d:b->d:c)", "bc2(d:b->d:c)", "cd(d:c->d:d)",
+				"ax(d:a->d:x)", "xy(d:x->d:y)", "yz(d:y->d:z)", "zd(d:z->d:d)", "ze(d:z->d:e)", "ed(d:e->d:d)",
+				"aw(d:a->d:w)", "wd(d:w->d:d)"},
+			nodes: []string{"d:a", "d:b", "d:c", "d:d", "d:e", "d:w", "d:x", "d:y", "d:z"},
+		},
+	} {
+		t.Run(fmt.Sprintf("k=%v", x.k), func(t *testing.T) {
+			sub, err := g.ShortestPaths(b.Class("d:a"), b.Classes("d:d"), x.k)
+			if assert.NoError(t, err) {
+				assert.ElementsMatch(t, x.lines, sub.LineStrings(), "%#v", sub.LineStrings())
+				assert.ElementsMatch(t, x.nodes, sub.NodeStrings(true), "%#v", sub.NodeStrings(true))
+			}
+		})
+	}
+
+	_, err := g.ShortestPaths(b.Class("d:a"), b.Classes("d:d"), 0)
+	assert.EqualError(t, err, "invalid number of shortest paths: 0")
+}
+
 func TestTwoPaths(t *testing.T) {
 	b := mock.NewBuilder("d")
 	r := b.Rule
@@ -18,6 +18,12 @@ type Constraint struct {
 	Start *time.Time `json:"start,omitempty"`
 	// End ignore data after this time (RFC 3339)
 	End *time.Time `json:"end,omitempty"`
+	// MaxQueries limits the total number of store queries for a whole traversal.
+	MaxQueries *int `json:"maxQueries,omitempty"`
+	// MaxObjects limits the total number of objects retrieved for a whole traversal.
+	MaxObjects *int `json:"maxObjects,omitempty"`
+	// MaxBytes limits the total size of JSON-serialized objects retrieved for a whole traversal.
+	MaxBytes *int `json:"maxBytes,omitempty"`
 }
 
 func (c *Constraint) String() string {
@@ -97,3 +103,52 @@ func (c *Constraint) GetEnd() time.Time {
 	}
 	return time.Time{}
 }
+
+// GetMaxQueries returns the traversal query budget or 0, safe to call with c == nil
+func (c *Constraint) GetMaxQueries() int {
+	if c != nil && c.MaxQueries != nil {
+		return *c.MaxQueries
+	}
+	return 0
+}
+
+// GetMaxObjects returns the traversal object budget or 0, safe to call with c == nil
+func (c *Constraint) GetMaxObjects() int {
+	if c != nil && c.MaxObjects != nil {
+		return *c.MaxObjects
+	}
+	return 0
+}
+
+// GetMaxBytes returns the traversal byte budget or 0, safe to call with c == nil
+func (c *Constraint) GetMaxBytes() int {
+	if c != nil && c.MaxBytes != nil {
+		return *c.MaxBytes
+	}
+	return 0
+}
+
+// HasBudget is true if any traversal-wide budget is set, safe to call with c == nil
+func (c *Constraint) HasBudget() bool {
+	return c.GetMaxQueries() > 0 || c.GetMaxObjects() > 0 || c.GetMaxBytes() > 0
+}
+
+// WithTimeRange returns c with its time interval narrowed to start and end, nil start or end are ignored.
+// Returns c unchanged if start and end are both nil, otherwise returns a modified copy of c.
+// Safe to call with c == nil.
+func (c *Constraint) WithTimeRange(start, end *time.Time) *Constraint {
+	if start == nil && end == nil {
+		return c
+	}
+	var c2 Constraint
+	if c != nil {
+		c2 = *c
+	}
+	if start != nil && (c2.Start == nil || start.After(*c2.Start)) {
+		c2.Start = start
+	}
+	if end != nil && (c2.End == nil || end.Before(*c2.End)) {
+		c2.End = end
+	}
+	return &c2
+}
@@ -75,3 +75,24 @@ func TestConstraint_GetEnd(t *testing.T) {
 	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
 	assert.Equal(t, end, (&Constraint{End: &end}).GetEnd())
 }
+
+func TestConstraint_WithTimeRange(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1, t2, t3 := t0.Add(time.Hour), t0.Add(2*time.Hour), t0.Add(3*time.Hour)
+	c := &Constraint{Start: &t1, End: &t2}
+	assert.Same(t, c, c.WithTimeRange(nil, nil))
+	assert.Nil(t, (*Constraint)(nil).WithTimeRange(nil, nil))
+	// Narrower range replaces the constraint range.
+	got := (&Constraint{Start: &t0, End: &t3}).WithTimeRange(&t1, &t2)
+	assert.Equal(t, t1, *got.Start)
+	assert.Equal(t, t2, *got.End)
+	// Wider range does not extend the constraint range, c is not modified.
+	got = c.WithTimeRange(&t0, &t3)
+	assert.Equal(t, t1, *got.Start)
+	assert.Equal(t, t2, *got.End)
+	assert.NotSame(t, c, got)
+	// Nil constraint.
+	got = (*Constraint)(nil).WithTimeRange(&t1, nil)
+	assert.Equal(t, t1, *got.Start)
+	assert.Nil(t, got.End)
+}
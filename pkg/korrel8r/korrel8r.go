@@ -16,6 +16,8 @@ package korrel8r
 
 import (
 	"context"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
 )
 
 // Domain is the entry-point to a package implementing a korrel8r domain.
@@ -69,6 +71,16 @@ type IDer interface {
 	ID(Object) any
 }
 
+// ObjectID returns a comparable identifier for an object of class c.
+// Uses the class ID if c implements [IDer], the JSON serialization of the object otherwise.
+func ObjectID(c Class, o Object) any {
+	if ider, ok := c.(IDer); ok {
+		return ider.ID(o)
+	}
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
 // Store is a source of signal data that can be queried.
 //
 // Must be implemented by a korrel8r domain.
@@ -143,3 +155,36 @@ type Rule interface {
 	// Name is a short, unique, human-readable name to identify the rule.
 	Name() string
 }
+
+// Prioritizer is optionally implemented by [Rule] implementations to control traversal order.
+//
+// Queries for the same goal class generated by rules with a higher priority are executed
+// before those with a lower priority.
+// Priority only breaks ties within one class, it does not order queries for different classes.
+// The default priority for rules that do not implement Prioritizer is 0.
+// A negative priority can be used for rules that generate expensive or low-value queries.
+type Prioritizer interface {
+	Priority() int
+}
+
+// RulePriority returns the priority of a rule if it implements [Prioritizer], 0 otherwise.
+func RulePriority(r Rule) int {
+	if p, ok := r.(Prioritizer); ok {
+		return p.Priority()
+	}
+	return 0
+}
+
+// Templater is optionally implemented by [Rule] implementations that generate queries from a text template.
+type Templater interface {
+	// Template returns the text of the template.
+	Template() string
+}
+
+// RuleTemplate returns the template text of a rule if it implements [Templater], "" otherwise.
+func RuleTemplate(r Rule) string {
+	if t, ok := r.(Templater); ok {
+		return t.Template()
+	}
+	return ""
+}
@@ -3,14 +3,17 @@
 package mcp
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/korrel8r/korrel8r/pkg/api"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
@@ -39,16 +42,62 @@ func NewClientForHandler(handler http.Handler) *Client {
 }
 
 // handlerTransport is an http.RoundTripper that calls an http.Handler directly.
+// The response body is streamed through a pipe, so streaming responses (e.g. SSE)
+// are delivered as they are written.
 type handlerTransport struct {
 	handler http.Handler
 }
 
 func (t *handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	w := httptest.NewRecorder()
-	t.handler.ServeHTTP(w, req)
-	return w.Result(), nil
+	pr, pw := io.Pipe()
+	w := &pipeResponseWriter{header: http.Header{}, req: req, body: pw, bodyReader: pr, resp: make(chan *http.Response, 1)}
+	go func() {
+		defer func() {
+			w.WriteHeader(http.StatusOK) // In case the handler wrote nothing.
+			_ = pw.Close()
+		}()
+		t.handler.ServeHTTP(w, req)
+	}()
+	return <-w.resp, nil
 }
 
+// pipeResponseWriter is an http.ResponseWriter that returns the response as soon as the header is written,
+// and writes the body to a pipe.
+type pipeResponseWriter struct {
+	header     http.Header
+	req        *http.Request
+	body       *io.PipeWriter
+	bodyReader *io.PipeReader
+	resp       chan *http.Response
+	once       sync.Once
+}
+
+func (w *pipeResponseWriter) Header() http.Header { return w.header }
+
+func (w *pipeResponseWriter) WriteHeader(code int) {
+	w.once.Do(func() {
+		w.resp <- &http.Response{
+			Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+			StatusCode:    code,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        w.header.Clone(),
+			Body:          w.bodyReader,
+			ContentLength: -1,
+			Request:       w.req,
+		}
+	})
+}
+
+func (w *pipeResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
+
+// Flush implements http.Flusher, writes to the pipe are not buffered.
+func (w *pipeResponseWriter) Flush() { w.WriteHeader(http.StatusOK) }
+
 func (c *Client) ListDomains(ctx context.Context) ([]api.Domain, error) {
 	var domains []api.Domain
 	if err := c.get(ctx, "/domains", &domains); err != nil {
@@ -77,6 +126,14 @@ func (c *Client) Help(ctx context.Context, domain string) (string, error) {
 	return h.Documentation, nil
 }
 
+func (c *Client) ListRules(ctx context.Context) ([]api.RuleDefinition, error) {
+	var rules []api.RuleDefinition
+	if err := c.get(ctx, "/rules", &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
 func (c *Client) GraphNeighbors(ctx context.Context, params api.Neighbors) (*api.Graph, error) {
 	var g api.Graph
 	if err := c.post(ctx, "/graphs/neighbors", params, &g); err != nil {
@@ -93,6 +150,70 @@ func (c *Client) GraphGoals(ctx context.Context, params api.Goals) (*api.Graph,
 	return &g, nil
 }
 
+// GraphDiff compares two graphs, running searches to get the graphs if necessary.
+func (c *Client) GraphDiff(ctx context.Context, params api.Diff) (*api.GraphDiff, error) {
+	var d api.GraphDiff
+	if err := c.post(ctx, "/graphs/diff", params, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GraphGoalsStream runs a goal search using the streaming endpoint.
+// listen is called for each progress event, the result graph is returned when the search is complete.
+func (c *Client) GraphGoalsStream(ctx context.Context, params api.Goals, listen func(api.GraphEvent)) (*api.Graph, error) {
+	return c.graphStream(ctx, "/graphs/goals/stream", params, listen)
+}
+
+// GraphNeighborsStream runs a neighbors search using the streaming endpoint.
+// listen is called for each progress event, the result graph is returned when the search is complete.
+func (c *Client) GraphNeighborsStream(ctx context.Context, params api.Neighbors, listen func(api.GraphEvent)) (*api.Graph, error) {
+	return c.graphStream(ctx, "/graphs/neighbors/stream", params, listen)
+}
+
+func (c *Client) graphStream(ctx context.Context, path string, params any, listen func(api.GraphEvent)) (*api.Graph, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, path, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("korrel8r: %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, body)
+	}
+	r := bufio.NewReader(resp.Body)
+	for {
+		line, err := r.ReadString('\n')
+		if data, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), "data: "); ok {
+			var ev api.GraphEvent
+			if err := json.Unmarshal([]byte(data), &ev); err != nil {
+				return nil, err
+			}
+			switch {
+			case ev.Type == api.GraphEventTypeGraph:
+				if ev.Graph == nil {
+					ev.Graph = &api.Graph{}
+				}
+				return ev.Graph, nil
+			case ev.Type == api.GraphEventTypeError && ev.Query == "":
+				return nil, fmt.Errorf("korrel8r: %s %s: %s", req.Method, req.URL.Path, ev.Error)
+			case listen != nil:
+				listen(ev)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("korrel8r: %s %s: stream ended without a result", req.Method, req.URL.Path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 func (c *Client) GetObjects(ctx context.Context, query string, constraint *api.Constraint) ([]json.RawMessage, error) {
 	u := "/objects?query=" + url.QueryEscape(query)
 	if constraint != nil {
@@ -128,6 +249,47 @@ func (c *Client) ShowInConsole(ctx context.Context, update *api.Console) error {
 	return c.put(ctx, "/console/events", update, nil)
 }
 
+func (c *Client) ListSavedSearches(ctx context.Context) ([]api.SavedSearch, error) {
+	var list []api.SavedSearch
+	if err := c.get(ctx, "/searches", &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (c *Client) GetSavedSearch(ctx context.Context, name string) (*api.SavedSearch, error) {
+	var s api.SavedSearch
+	if err := c.get(ctx, "/searches/"+url.PathEscape(name), &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (c *Client) SaveSearch(ctx context.Context, search api.SavedSearch) (*api.SavedSearch, error) {
+	var s api.SavedSearch
+	if err := c.put(ctx, "/searches/"+url.PathEscape(search.Name), search, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (c *Client) DeleteSavedSearch(ctx context.Context, name string) (*api.SavedSearch, error) {
+	var s api.SavedSearch
+	if err := c.send(ctx, http.MethodDelete, "/searches/"+url.PathEscape(name), nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// RunSavedSearch runs a saved search with a fresh time window.
+func (c *Client) RunSavedSearch(ctx context.Context, name string) (*api.Graph, error) {
+	var g api.Graph
+	if err := c.get(ctx, "/searches/"+url.PathEscape(name)+"/graph", &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
 func (c *Client) get(ctx context.Context, path string, result any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+api.BasePath+path, nil)
 	if err != nil {
@@ -145,16 +307,25 @@ func (c *Client) put(ctx context.Context, path string, body, result any) error {
 }
 
 func (c *Client) send(ctx context.Context, method, path string, body, result any) error {
-	b, err := json.Marshal(body)
+	req, err := c.newRequest(ctx, method, path, body)
 	if err != nil {
 		return err
 	}
+	return c.do(req, result)
+}
+
+// newRequest creates a request with a JSON body.
+func (c *Client) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
 	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+api.BasePath+path, bytes.NewReader(b))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	return c.do(req, result)
+	return req, nil
 }
 
 func (c *Client) do(req *http.Request, result any) error {
@@ -6,6 +6,8 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"reflect"
 	"time"
@@ -41,8 +43,19 @@ type ListDomainClassesResult struct {
 
 type NeighborParams = api.Neighbors
 type GoalParams = api.Goals
+type DiffParams = api.Diff
 type ShowInConsoleParams = api.Console
 
+type SaveSearchParams = api.SavedSearch
+
+type SearchNameParams struct {
+	Name string `json:"name" jsonschema:"Name of the saved search"`
+}
+
+type ListSavedSearchesResult struct {
+	Searches []api.SavedSearch `json:"searches" jsonschema:"List of saved searches"`
+}
+
 type ObjectsParams struct {
 	Query      string          `json:"query" jsonschema:"Query string in the form 'domain:class:selector'. Use 'help' to learn query syntax for each domain."`
 	Constraint *api.Constraint `json:"constraint,omitempty" jsonschema:"Optional constraint to limit results by time range and/or count."`
@@ -72,6 +85,26 @@ If the user refers to a console:
      (e.g. "find logs for this pod", "what alerts fired for this deployment?").
    - Use create_neighbors_graph for open-ended exploration
      (e.g. "what is related to this pod?", "show me everything connected to these traces").
+4. Set a budget in start.constraint (maxQueries, maxObjects, maxBytes) to limit the cost of a search.
+   The graph 'usage' field reports the budget used, and 'exhausted' if the search was cut short.
+5. Use diff_graphs to find what changed: compare the same search over different time windows,
+   or compare graphs from previous searches.
+
+## Saved search tools
+
+- Use list_saved_searches to find searches saved by the user or shared by others.
+- Use run_saved_search to run a saved search by name over a time window ending now.
+- Use save_search to save a search the user wants to repeat or share, delete_saved_search to remove it.
+
+## Resources and prompts
+
+Resources provide context without tool calls:
+- korrel8r://domains and korrel8r://domains/{domain}: domain documentation, the same as 'help'.
+- korrel8r://rules: correlation rules with their start classes, goal classes and query templates.
+- korrel8r://graphs: graphs returned by recent searches in this session, read each graph by its URI.
+
+Prompts start common investigations: investigate_pod ("why is this pod failing?")
+and namespace_changes ("what changed in this namespace?").
 
 `
 
@@ -81,7 +114,14 @@ const (
 	ListDomainClasses    = "list_domain_classes"
 	CreateGoalsGraph     = "create_goals_graph"
 	CreateNeighborsGraph = "create_neighbors_graph"
+	DiffGraphs           = "diff_graphs"
 	GetObjects           = "get_objects"
+	// Saved search tools.
+	ListSavedSearches = "list_saved_searches"
+	GetSavedSearch    = "get_saved_search"
+	SaveSearch        = "save_search"
+	DeleteSavedSearch = "delete_saved_search"
+	RunSavedSearch    = "run_saved_search"
 	// Console tools, only work in sessions with a connected console.
 	GetConsole    = "get_console"
 	ShowInConsole = "show_in_console"
@@ -92,6 +132,7 @@ type Server struct {
 	client *Client
 	log    logr.Logger
 	tools  []*mcp.Tool
+	graphs *graphStore
 }
 
 func (s *Server) AllTools() []*mcp.Tool { return s.tools }
@@ -106,9 +147,12 @@ func NewServer(client *Client, version string, log logr.Logger) *Server {
 			}),
 		client: client,
 		log:    log,
+		graphs: newGraphStore(maxRecentGraphs),
 	}
 	s.tools = AddTools(s.Server, s.client)
-	s.AddReceivingMiddleware(s.logger)
+	s.addResources()
+	s.addPrompts()
+	s.AddReceivingMiddleware(s.logger, s.recordGraphs)
 	return s
 }
 
@@ -150,7 +194,7 @@ Use this first to discover available domains, then use list_domain_classes to ex
 		Description: `
 List the classes in a domain.
 A class represents objects with a specific structure within a domain.
-Some domains have a single class (e.g. metric:metric), others like k8s have many classes.
+Some domains have a single class (e.g. alert:alert), others like k8s have many classes.
 Use 'help' to get more details about a domain and its classes and queries.
 
 Class names are used in queries and as goal parameters. The full class name is "domain:class".
@@ -207,7 +251,17 @@ depth 2-3 typically reaches related signals like logs, metrics, and alerts.
 `,
 	},
 		func(ctx context.Context, req *mcp.CallToolRequest, input NeighborParams) (*mcp.CallToolResult, *api.Graph, error) {
-			g, err := client.GraphNeighbors(ctx, input)
+			var (
+				g   *api.Graph
+				err error
+			)
+			if listen := progressListener(ctx, req); listen != nil {
+				ctx, cancel := detach(ctx)
+				defer cancel()
+				g, err = client.GraphNeighborsStream(ctx, input, listen)
+			} else {
+				g, err = client.GraphNeighbors(ctx, input)
+			}
 			if err != nil {
 				return nil, nil, err
 			}
@@ -228,6 +282,8 @@ Use this for targeted investigation: "find logs related to this pod" or "what al
 The start parameter uses queries in "domain:class:selector" format.
 Use 'help' to learn the class and query syntax for each domain.
 Goals are full class names, e.g. ["log:application"], ["alert:alert", "metric:metric"].
+Set shortestPaths (e.g. 1 to 3) to follow only the lowest-cost rule paths to each goal.
+This is faster and returns a smaller graph when the full search is slow or too broad.
 
 Example: to find logs for a crashing pod, use:
   start: {"queries": ["k8s:Pod:{\"namespace\":\"myapp\",\"name\":\"web-0\"}"]}
@@ -235,13 +291,45 @@ Example: to find logs for a crashing pod, use:
 `,
 	},
 		func(ctx context.Context, req *mcp.CallToolRequest, input GoalParams) (*mcp.CallToolResult, *api.Graph, error) {
-			g, err := client.GraphGoals(ctx, input)
+			var (
+				g   *api.Graph
+				err error
+			)
+			if listen := progressListener(ctx, req); listen != nil {
+				ctx, cancel := detach(ctx)
+				defer cancel()
+				g, err = client.GraphGoalsStream(ctx, input, listen)
+			} else {
+				g, err = client.GraphGoals(ctx, input)
+			}
 			if err != nil {
 				return nil, nil, err
 			}
 			return nil, g, nil
 		})
 
+	addTool(&tools, server, &mcp.Tool{
+		Name: DiffGraphs,
+		Description: `
+Compare two correlation graphs to find what changed, for example "now" compared to "an hour ago".
+
+Each of 'before' and 'after' sets exactly one of:
+- graph: a graph returned by a previous search.
+- search: a search to run, with the same parameters as create_goals_graph ('goals') or create_neighbors_graph ('neighbors').
+  To compare time windows, use the same search with a different start.constraint (start, end) for before and after.
+
+Returns nodes and edges that were added or removed, and nodes with changed result counts,
+query counts or status counts (e.g. more pods in a Failed status).
+`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input DiffParams) (*mcp.CallToolResult, *api.GraphDiff, error) {
+			d, err := client.GraphDiff(ctx, input)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, d, nil
+		})
+
 	addTool(&tools, server, &mcp.Tool{
 		Name: GetObjects,
 		Description: `
@@ -317,9 +405,141 @@ Use 'help' to learn the class and query syntax for each domain.
 			return nil, nil, nil
 		})
 
+	addTool(&tools, server, &mcp.Tool{
+		Name: ListSavedSearches,
+		Description: `
+List saved correlation searches.
+Returns searches saved in this session first, followed by global searches shared by all sessions.
+Use run_saved_search to run a search by name.
+`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, *ListSavedSearchesResult, error) {
+			list, err := client.ListSavedSearches(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, &ListSavedSearchesResult{Searches: list}, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name: GetSavedSearch,
+		Description: `
+Get a saved correlation search by name, including its search parameters.
+A search saved in this session hides a global search with the same name.
+`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input SearchNameParams) (*mcp.CallToolResult, *api.SavedSearch, error) {
+			s, err := client.GetSavedSearch(ctx, input.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, s, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name: SaveSearch,
+		Description: `
+Save a correlation search under a name, so it can be run again later with run_saved_search.
+Replaces an existing search with the same name and scope.
+
+- search: set exactly one of 'goals' or 'neighbors', with the same parameters as create_goals_graph or create_neighbors_graph.
+- window: duration of the time window for each run, e.g. "1h". Each run searches the window ending at the time it runs.
+- scope: "session" (default) keeps the search for this session only, "global" shares it with all users.
+  Only the user that saved a global search can replace it.
+
+Use 'help' to learn the class and query syntax for each domain.
+`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input SaveSearchParams) (*mcp.CallToolResult, *api.SavedSearch, error) {
+			s, err := client.SaveSearch(ctx, input)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, s, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name: DeleteSavedSearch,
+		Description: `
+Delete a saved correlation search by name, returns the deleted search.
+Deletes the search saved in this session if there is one, otherwise the global search.
+Only the user that saved a global search can delete it.
+`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input SearchNameParams) (*mcp.CallToolResult, *api.SavedSearch, error) {
+			s, err := client.DeleteSavedSearch(ctx, input.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, s, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name: RunSavedSearch,
+		Description: `
+Run a saved correlation search by name, using a time window that ends now.
+Returns a graph in the same form as create_goals_graph or create_neighbors_graph.
+Use list_saved_searches to find the names of saved searches.
+`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input SearchNameParams) (*mcp.CallToolResult, *api.Graph, error) {
+			g, err := client.RunSavedSearch(ctx, input.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, g, nil
+		})
+
 	return tools
 }
 
+// progressListener returns a listener that sends MCP progress notifications for search events.
+// Returns nil if the client did not ask for progress.
+func progressListener(ctx context.Context, req *mcp.CallToolRequest) func(api.GraphEvent) {
+	token := req.Params.GetProgressToken()
+	if token == nil || req.Session == nil {
+		return nil
+	}
+	progress := 0.0
+	return func(ev api.GraphEvent) {
+		progress++
+		_ = req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Message:       progressMessage(ev),
+		})
+	}
+}
+
+// detach returns a context for a streaming search that is not limited by the deadline of ctx.
+// The server limits streaming searches with its own timeout.
+// The search is still canceled if ctx is canceled before its deadline.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			cancel()
+		}
+	})
+	return detached, func() { stop(); cancel() }
+}
+
+// progressMessage describes a search progress event for an MCP progress notification.
+func progressMessage(ev api.GraphEvent) string {
+	switch ev.Type {
+	case api.GraphEventTypeNode:
+		return fmt.Sprintf("found %v new objects for %v", ev.Count, ev.Class)
+	case api.GraphEventTypeLine:
+		return fmt.Sprintf("rule %v: %v -> %v", ev.Rule, ev.Start, ev.Class)
+	case api.GraphEventTypeQuery:
+		return fmt.Sprintf("query returned %v objects: %v", ev.Count, ev.Query)
+	case api.GraphEventTypeError:
+		return fmt.Sprintf("query failed: %v: %v", ev.Query, ev.Error)
+	default:
+		return string(ev.Type)
+	}
+}
+
 // ServeStdio runs an MCP server, it returns when the client disconnects or the context is canceled.
 func (s *Server) ServeStdio(ctx context.Context) error {
 	return s.Run(ctx, &mcp.StdioTransport{})
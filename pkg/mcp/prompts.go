@@ -0,0 +1,112 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Prompt names.
+const (
+	InvestigatePod   = "investigate_pod"
+	NamespaceChanges = "namespace_changes"
+)
+
+// addPrompts adds prompts for common investigations to the server.
+func (s *Server) addPrompts() {
+	s.AddPrompt(&mcp.Prompt{
+		Name:        InvestigatePod,
+		Title:       "Why is this pod failing?",
+		Description: "Investigate a failing pod using the logs, events and alerts correlated with it.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "namespace", Description: "Namespace of the pod.", Required: true},
+			{Name: "name", Description: "Name of the pod.", Required: true},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		namespace, name := args["namespace"], args["name"]
+		if namespace == "" || name == "" {
+			return nil, fmt.Errorf("%v: namespace and name are required", InvestigatePod)
+		}
+		query, err := k8sQuery("Pod", map[string]string{"namespace": namespace, "name": name})
+		if err != nil {
+			return nil, err
+		}
+		return userPrompt(fmt.Sprintf("Why is pod %v/%v failing?", namespace, name), fmt.Sprintf(`
+Find out why pod %[1]q in namespace %[2]q is failing.
+
+1. Use %[3]v with start query %[4]v
+   and goals ["log:application", "log:infrastructure", "event:event", "alert:alert"].
+   Set start.constraint.limit to keep the results small.
+2. Check the statuses on the graph nodes, for example pods in a Failed or Pending status.
+3. Use %[5]v with the queries from the graph to read warning events, firing alerts and recent error logs.
+   Use a constraint to limit the number of objects.
+4. Explain the most likely cause of the failure, with the evidence for it, and suggest how to fix it.
+
+Read the %[6]v resource for query syntax if you need to write new queries.
+`, name, namespace, CreateGoalsGraph, query, GetObjects, DomainsURI+"/k8s")), nil
+	})
+
+	s.AddPrompt(&mcp.Prompt{
+		Name:        NamespaceChanges,
+		Title:       "What changed in this namespace?",
+		Description: "Compare the resources and signals correlated with a namespace over two consecutive time windows.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "namespace", Description: "Namespace to investigate.", Required: true},
+			{Name: "window", Description: `Duration of each time window, e.g. "30m" or "2h". Default "1h".`},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		namespace := args["namespace"]
+		if namespace == "" {
+			return nil, fmt.Errorf("%v: namespace is required", NamespaceChanges)
+		}
+		window := time.Hour
+		if w := args["window"]; w != "" {
+			var err error
+			if window, err = time.ParseDuration(w); err != nil || window <= 0 {
+				return nil, fmt.Errorf("%v: invalid window: %q", NamespaceChanges, w)
+			}
+		}
+		query, err := k8sQuery("Pod", map[string]string{"namespace": namespace})
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		middle, start := now.Add(-window), now.Add(-2*window)
+		return userPrompt(fmt.Sprintf("What changed in namespace %v?", namespace), fmt.Sprintf(`
+Find out what changed in namespace %[1]q in the last %[2]v, compared to the %[2]v before that.
+
+1. Use %[3]v with a 'neighbors' search for both 'before' and 'after':
+   start query %[4]v with depth 2.
+   - before: start.constraint {"start": %[5]q, "end": %[6]q}
+   - after: start.constraint {"start": %[6]q, "end": %[7]q}
+2. Look at added and removed nodes, and nodes with changed counts or statuses
+   (for example new Failed pods, new warning events or new alerts).
+3. Use %[8]v to read the objects behind the most important changes.
+4. Summarize what changed and which changes are likely to be problems.
+`, namespace, window, DiffGraphs, query, start.Format(time.RFC3339), middle.Format(time.RFC3339), now.Format(time.RFC3339), GetObjects)), nil
+	})
+}
+
+// k8sQuery returns a k8s domain query string for class with a JSON selector.
+func k8sQuery(class string, selector map[string]string) (string, error) {
+	b, err := json.Marshal(selector)
+	if err != nil {
+		return "", err
+	}
+	return "k8s:" + class + ":" + string(b), nil
+}
+
+func userPrompt(description, text string) *mcp.GetPromptResult {
+	return &mcp.GetPromptResult{
+		Description: description,
+		Messages:    []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: strings.TrimSpace(text)}}},
+	}
+}
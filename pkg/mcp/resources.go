@@ -0,0 +1,238 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Resource URIs and URI templates.
+const (
+	DomainsURI         = "korrel8r://domains"
+	DomainsURITemplate = DomainsURI + "/{domain}"
+	RulesURI           = "korrel8r://rules"
+	GraphsURI          = "korrel8r://graphs"
+	GraphsURITemplate  = GraphsURI + "/{id}"
+)
+
+const (
+	markdownMIMEType = "text/markdown"
+	jsonMIMEType     = "application/json"
+)
+
+// maxRecentGraphs is the number of recent graphs kept for the graphs resource in each session.
+const maxRecentGraphs = 20
+
+// graphTools are the tools that return a result graph, their results are kept as recent graphs.
+var graphTools = []string{CreateGoalsGraph, CreateNeighborsGraph, RunSavedSearch}
+
+// RecentGraph describes a result graph returned by a recent tool call.
+type RecentGraph struct {
+	URI   string    `json:"uri" jsonschema:"URI to read the graph as a resource."`
+	Tool  string    `json:"tool" jsonschema:"Name of the tool that returned the graph."`
+	Time  time.Time `json:"time" jsonschema:"Time the graph was returned."`
+	Nodes int       `json:"nodes" jsonschema:"Number of nodes in the graph."`
+	Edges int       `json:"edges" jsonschema:"Number of edges in the graph."`
+}
+
+type recentGraph struct {
+	RecentGraph
+	graph json.RawMessage
+}
+
+// graphStore keeps the most recent graphs returned by tool calls, separately for each session.
+// Graphs are only visible to the session that created them.
+type graphStore struct {
+	mu       sync.Mutex
+	sessions map[string][]*recentGraph // Graphs by session ID, oldest first.
+	size     int                       // Maximum number of graphs per session.
+}
+
+func newGraphStore(size int) *graphStore {
+	return &graphStore{sessions: map[string][]*recentGraph{}, size: size}
+}
+
+// add a graph returned by tool in session, returns true if it is the first graph for the session.
+func (gs *graphStore) add(session, tool string, graph json.RawMessage) (bool, error) {
+	var g api.Graph
+	if err := json.Unmarshal(graph, &g); err != nil {
+		return false, err
+	}
+	rg := &recentGraph{
+		RecentGraph: RecentGraph{
+			URI:   GraphsURI + "/" + rand.Text(),
+			Tool:  tool,
+			Time:  time.Now(),
+			Nodes: len(g.Nodes),
+			Edges: len(g.Edges),
+		},
+		graph: graph,
+	}
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	graphs, ok := gs.sessions[session]
+	graphs = append(graphs, rg)
+	if n := len(graphs) - gs.size; n > 0 {
+		graphs = slices.Delete(graphs, 0, n)
+	}
+	gs.sessions[session] = graphs
+	return !ok, nil
+}
+
+// list graphs for a session, most recent first.
+func (gs *graphStore) list(session string) []RecentGraph {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	list := []RecentGraph{}
+	for _, rg := range slices.Backward(gs.sessions[session]) {
+		list = append(list, rg.RecentGraph)
+	}
+	return list
+}
+
+// get a graph for a session by URI, nil if not found.
+func (gs *graphStore) get(session, uri string) json.RawMessage {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	for _, rg := range gs.sessions[session] {
+		if rg.URI == uri {
+			return rg.graph
+		}
+	}
+	return nil
+}
+
+// remove all graphs for a session.
+func (gs *graphStore) remove(session string) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	delete(gs.sessions, session)
+}
+
+// sessionID returns the ID of a session, "" if there is none.
+func sessionID(ss *mcp.ServerSession) string {
+	if ss == nil {
+		return ""
+	}
+	return ss.ID()
+}
+
+// addResources adds korrel8r resources to the server.
+func (s *Server) addResources() {
+	s.AddResource(&mcp.Resource{
+		URI:         DomainsURI,
+		Name:        "domains",
+		Title:       "Domain documentation",
+		Description: "Documentation for all domains, including class names, query syntax and examples.",
+		MIMEType:    markdownMIMEType,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		doc, err := s.client.Help(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		return textResource(req.Params.URI, markdownMIMEType, doc), nil
+	})
+
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: DomainsURITemplate,
+		Name:        "domain",
+		Title:       "Domain documentation",
+		Description: "Documentation for a single domain, including class names, query syntax and examples. Use list_domains for domain names.",
+		MIMEType:    markdownMIMEType,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		domain, ok := strings.CutPrefix(req.Params.URI, DomainsURI+"/")
+		if !ok || domain == "" {
+			return nil, mcp.ResourceNotFoundError(req.Params.URI)
+		}
+		doc, err := s.client.Help(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		return textResource(req.Params.URI, markdownMIMEType, doc), nil
+	})
+
+	s.AddResource(&mcp.Resource{
+		URI:         RulesURI,
+		Name:        "rules",
+		Title:       "Correlation rules",
+		Description: "Correlation rules loaded by korrel8r: name, start classes, goal classes and query template of each rule.",
+		MIMEType:    jsonMIMEType,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		rules, err := s.client.ListRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResource(req.Params.URI, rules)
+	})
+
+	s.AddResource(&mcp.Resource{
+		URI:         GraphsURI,
+		Name:        "graphs",
+		Title:       "Recent graphs",
+		Description: "Graphs returned by recent correlation searches in this session, most recent first. Read a graph by its URI.",
+		MIMEType:    jsonMIMEType,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return jsonResource(req.Params.URI, s.graphs.list(sessionID(req.Session)))
+	})
+
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: GraphsURITemplate,
+		Name:        "graph",
+		Title:       "Recent graph",
+		Description: "A graph returned by a recent correlation search in this session.",
+		MIMEType:    jsonMIMEType,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		g := s.graphs.get(sessionID(req.Session), req.Params.URI)
+		if g == nil {
+			return nil, mcp.ResourceNotFoundError(req.Params.URI)
+		}
+		return textResource(req.Params.URI, jsonMIMEType, string(g)), nil
+	})
+}
+
+func textResource(uri, mimeType, text string) *mcp.ReadResourceResult {
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: mimeType, Text: text}}}
+}
+
+func jsonResource(uri string, v any) (*mcp.ReadResourceResult, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return textResource(uri, jsonMIMEType, string(b)), nil
+}
+
+// recordGraphs is middleware to keep graphs returned by tool calls for the graphs resource.
+func (s *Server) recordGraphs(handler mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := handler(ctx, method, req)
+		r, ok := result.(*mcp.CallToolResult)
+		if err != nil || !ok || r.IsError || r.StructuredContent == nil {
+			return result, err
+		}
+		if call, ok := req.(*mcp.CallToolRequest); ok && slices.Contains(graphTools, call.Params.Name) {
+			id := sessionID(call.Session)
+			b, err := json.Marshal(r.StructuredContent)
+			first := false
+			if err == nil {
+				first, err = s.graphs.add(id, call.Params.Name, b)
+			}
+			if err != nil {
+				s.log.V(1).Info("Cannot record graph", "tool", call.Params.Name, "error", err)
+			}
+			if first && call.Session != nil {
+				go func() { _ = call.Session.Wait(); s.graphs.remove(id) }() // Forget graphs when the session ends.
+			}
+		}
+		return result, nil
+	}
+}
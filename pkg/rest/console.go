@@ -20,21 +20,25 @@ func ConsoleOK(e *engine.Engine, c *api.Console) error {
 		c.View = q.String()
 	}
 	if c.Search != nil {
-		var start *api.Start
-		switch {
-		case c.Search.Goals != nil && c.Search.Neighbors == nil:
-			start = &c.Search.Goals.Start
-			if _, err := e.Classes(c.Search.Goals.Goals); err != nil {
-				return err
-			}
-		case c.Search.Neighbors != nil && c.Search.Goals == nil:
-			start = &c.Search.Neighbors.Start
-		default:
-			return fmt.Errorf("search must have exactly one of .goals or .neighbors")
-		}
-		if _, err := TraverseStart(e, *start); err != nil {
+		return SearchOK(e, c.Search)
+	}
+	return nil
+}
+
+// SearchOK validates correlation search parameters.
+func SearchOK(e *engine.Engine, s *api.Search) error {
+	var start *api.Start
+	switch {
+	case s.Goals != nil && s.Neighbors == nil:
+		start = &s.Goals.Start
+		if _, err := e.Classes(s.Goals.Goals); err != nil {
 			return err
 		}
+	case s.Neighbors != nil && s.Goals == nil:
+		start = &s.Neighbors.Start
+	default:
+		return fmt.Errorf("search must have exactly one of .goals or .neighbors")
 	}
-	return nil
+	_, err := TraverseStart(e, *start)
+	return err
 }
@@ -0,0 +1,165 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rest
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
+)
+
+// GraphDiff compares two graphs, running searches to get the graphs if necessary.
+// (POST /graphs/diff)
+func (a *API) GraphDiff(c *gin.Context, params GraphDiffParams) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	r := api.Diff{}
+	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
+		return
+	}
+	before := diffGraph(c, s.Engine, "before", r.Before, params.Options)
+	if c.IsAborted() {
+		return
+	}
+	after := diffGraph(c, s.Engine, "after", r.After, params.Options)
+	if c.IsAborted() {
+		return
+	}
+	okResponse(c, DiffGraphs(before, after))
+}
+
+// diffGraph returns the graph for one side of a diff, aborts c on error.
+func diffGraph(c *gin.Context, e *engine.Engine, name string, src api.DiffSource, opts *api.GraphOptions) *api.Graph {
+	if (src.Graph == nil) == (src.Search == nil) {
+		check(c, http.StatusBadRequest, fmt.Errorf("%v: set exactly one of graph or search", name))
+		return nil
+	}
+	if src.Graph != nil {
+		return src.Graph
+	}
+	if !check(c, http.StatusBadRequest, SearchOK(e, src.Search), "%v", name) {
+		return nil
+	}
+	g, err := SearchGraph(c.Request.Context(), e, *src.Search, opts)
+	if !check(c, http.StatusNotFound, err, "%v", name) {
+		return nil
+	}
+	return NewGraph(g, opts)
+}
+
+// DiffGraphs compares two result graphs.
+// Nodes are identified by class, edges by start and goal class, queries by query string.
+// Results are sorted by class, edge classes, query and status.
+func DiffGraphs(before, after *api.Graph) *api.GraphDiff {
+	d := &api.GraphDiff{}
+	beforeNodes, afterNodes := nodeMap(before), nodeMap(after)
+	for _, class := range slices.Sorted(maps.Keys(afterNodes)) {
+		if _, ok := beforeNodes[class]; !ok {
+			d.AddedNodes = append(d.AddedNodes, afterNodes[class])
+		}
+	}
+	for _, class := range slices.Sorted(maps.Keys(beforeNodes)) {
+		b := beforeNodes[class]
+		a, ok := afterNodes[class]
+		if !ok {
+			d.RemovedNodes = append(d.RemovedNodes, b)
+			continue
+		}
+		if nc := nodeChange(&b, &a); nc != nil {
+			d.ChangedNodes = append(d.ChangedNodes, *nc)
+		}
+	}
+	beforeEdges, afterEdges := edgeMap(before), edgeMap(after)
+	for _, k := range slices.SortedFunc(maps.Keys(afterEdges), compareEdgeKey) {
+		if _, ok := beforeEdges[k]; !ok {
+			d.AddedEdges = append(d.AddedEdges, afterEdges[k])
+		}
+	}
+	for _, k := range slices.SortedFunc(maps.Keys(beforeEdges), compareEdgeKey) {
+		if _, ok := afterEdges[k]; !ok {
+			d.RemovedEdges = append(d.RemovedEdges, beforeEdges[k])
+		}
+	}
+	return d
+}
+
+// nodeChange returns the changes between two nodes for the same class, nil if there are none.
+func nodeChange(before, after *api.Node) *api.NodeChange {
+	nc := &api.NodeChange{Class: before.Class, Before: ptr.Deref(before.Count), After: ptr.Deref(after.Count)}
+	beforeQueries, afterQueries := queryCountMap(before), queryCountMap(after)
+	for _, q := range sortedKeys(beforeQueries, afterQueries) {
+		if b, a := beforeQueries[q], afterQueries[q]; b != a {
+			nc.Queries = append(nc.Queries, api.QueryChange{Query: q, Before: b, After: a})
+		}
+	}
+	beforeStatuses, afterStatuses := statusCountMap(before), statusCountMap(after)
+	for _, s := range sortedKeys(beforeStatuses, afterStatuses) {
+		if b, a := beforeStatuses[s], afterStatuses[s]; b != a {
+			nc.Statuses = append(nc.Statuses, api.StatusChange{Status: s, Before: b, After: a})
+		}
+	}
+	if nc.Before == nc.After && len(nc.Queries) == 0 && len(nc.Statuses) == 0 {
+		return nil
+	}
+	return nc
+}
+
+func nodeMap(g *api.Graph) map[string]api.Node {
+	m := map[string]api.Node{}
+	for _, n := range g.Nodes {
+		m[n.Class] = n
+	}
+	return m
+}
+
+func edgeMap(g *api.Graph) map[[2]string]api.Edge {
+	m := map[[2]string]api.Edge{}
+	for _, e := range g.Edges {
+		m[[2]string{e.Start, e.Goal}] = e
+	}
+	return m
+}
+
+func compareEdgeKey(a, b [2]string) int {
+	return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+}
+
+func queryCountMap(n *api.Node) map[string]int {
+	m := map[string]int{}
+	for _, qc := range n.Queries {
+		m[qc.Query] = ptr.Deref(qc.Count)
+	}
+	return m
+}
+
+// statusCountMap returns status counts totaled over the queries of a node.
+func statusCountMap(n *api.Node) map[string]int {
+	m := map[string]int{}
+	for _, qc := range n.Queries {
+		for _, sc := range qc.Statuses {
+			m[sc.Status] += ptr.Deref(sc.Count)
+		}
+	}
+	return m
+}
+
+// sortedKeys returns the sorted union of the keys of two maps.
+func sortedKeys(a, b map[string]int) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
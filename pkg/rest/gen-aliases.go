@@ -6,7 +6,15 @@ package rest
 import "github.com/korrel8r/korrel8r/pkg/api"
 
 type SetConfigParams = api.SetConfigParams
+type FlushCacheParams = api.FlushCacheParams
+type GraphDiffParams = api.GraphDiffParams
 type GraphGoalsParams = api.GraphGoalsParams
+type GraphGoalsStreamParams = api.GraphGoalsStreamParams
 type GraphNeighborsParams = api.GraphNeighborsParams
+type GraphNeighborsStreamParams = api.GraphNeighborsStreamParams
+type GraphRefreshParams = api.GraphRefreshParams
+type GraphReverseParams = api.GraphReverseParams
 type GraphNeighboursParams = api.GraphNeighboursParams
+type CreateGoalsJobParams = api.CreateGoalsJobParams
 type ObjectsParams = api.ObjectsParams
+type RunSearchParams = api.RunSearchParams
@@ -15,6 +15,9 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Flush cached query results.
+	// (DELETE /cache)
+	FlushCache(c *gin.Context, params FlushCacheParams)
 	// Change configuration settings at runtime.
 	// (PUT /config)
 	SetConfig(c *gin.Context, params SetConfigParams)
@@ -36,27 +39,72 @@ type ServerInterface interface {
 	// Get the list of correlation domains.
 	// (GET /domains)
 	ListDomains(c *gin.Context)
+	// Compare two correlation graphs.
+	// (POST /graphs/diff)
+	GraphDiff(c *gin.Context, params GraphDiffParams)
 	// Create a correlation graph from start objects to goal queries.
 	// (POST /graphs/goals)
 	GraphGoals(c *gin.Context, params GraphGoalsParams)
+	// Stream a goal-directed correlation search as server-sent events.
+	// (POST /graphs/goals/stream)
+	GraphGoalsStream(c *gin.Context, params GraphGoalsStreamParams)
 	// Create a neighborhood graph around a start object to a given depth.
 	// (POST /graphs/neighbors)
 	GraphNeighbors(c *gin.Context, params GraphNeighborsParams)
+	// Stream a neighborhood correlation search as server-sent events.
+	// (POST /graphs/neighbors/stream)
+	GraphNeighborsStream(c *gin.Context, params GraphNeighborsStreamParams)
 	// Create a neighborhood graph around a start object to a given depth.
 	// (POST /graphs/neighbours)
 	GraphNeighbours(c *gin.Context, params GraphNeighboursParams)
+	// Re-run the queries of a previous correlation graph with a new constraint.
+	// (POST /graphs/refresh)
+	GraphRefresh(c *gin.Context, params GraphRefreshParams)
+	// Find start objects that lead to a goal object.
+	// (POST /graphs/reverse)
+	GraphReverse(c *gin.Context, params GraphReverseParams)
 	// Get help about all domains.
 	// (GET /help)
 	Help(c *gin.Context)
 	// Get help about a specific domain.
 	// (GET /help/{domain})
 	HelpDomain(c *gin.Context, domain string)
+	// List asynchronous search jobs in this session.
+	// (GET /jobs)
+	ListJobs(c *gin.Context)
+	// Start an asynchronous goal-directed correlation search.
+	// (POST /jobs/goals)
+	CreateGoalsJob(c *gin.Context, params CreateGoalsJobParams)
+	// Cancel and remove an asynchronous search job.
+	// (DELETE /jobs/{job})
+	DeleteJob(c *gin.Context, job string)
+	// Get the status and graph of an asynchronous search job.
+	// (GET /jobs/{job})
+	GetJob(c *gin.Context, job string)
 	// Create a list of goal nodes related to a starting point.
 	// (POST /lists/goals)
 	ListGoals(c *gin.Context)
 	// Execute a query, returns a list of JSON objects.
 	// (GET /objects)
 	Objects(c *gin.Context, params ObjectsParams)
+	// List the correlation rules.
+	// (GET /rules)
+	ListRules(c *gin.Context)
+	// List saved searches.
+	// (GET /searches)
+	ListSearches(c *gin.Context)
+	// Delete a saved search.
+	// (DELETE /searches/{name})
+	DeleteSearch(c *gin.Context, name string)
+	// Get a saved search by name.
+	// (GET /searches/{name})
+	GetSearch(c *gin.Context, name string)
+	// Create or replace a saved search.
+	// (PUT /searches/{name})
+	SaveSearch(c *gin.Context, name string)
+	// Run a saved search and return the correlation graph.
+	// (GET /searches/{name}/graph)
+	RunSearch(c *gin.Context, name string, params RunSearchParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -68,6 +116,33 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// FlushCache operation middleware
+func (siw *ServerInterfaceWrapper) FlushCache(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FlushCacheParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "domain", c.Request.URL.Query(), &params.Domain, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter domain: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FlushCache(c, params)
+}
+
 // SetConfig operation middleware
 func (siw *ServerInterfaceWrapper) SetConfig(c *gin.Context) {
 
@@ -185,6 +260,33 @@ func (siw *ServerInterfaceWrapper) ListDomains(c *gin.Context) {
 	siw.Handler.ListDomains(c)
 }
 
+// GraphDiff operation middleware
+func (siw *ServerInterfaceWrapper) GraphDiff(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GraphDiffParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "options", c.Request.URL.Query(), &params.Options, runtime.BindQueryParameterOptions{Type: "object", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GraphDiff(c, params)
+}
+
 // GraphGoals operation middleware
 func (siw *ServerInterfaceWrapper) GraphGoals(c *gin.Context) {
 
@@ -212,6 +314,33 @@ func (siw *ServerInterfaceWrapper) GraphGoals(c *gin.Context) {
 	siw.Handler.GraphGoals(c, params)
 }
 
+// GraphGoalsStream operation middleware
+func (siw *ServerInterfaceWrapper) GraphGoalsStream(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GraphGoalsStreamParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "options", c.Request.URL.Query(), &params.Options, runtime.BindQueryParameterOptions{Type: "object", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GraphGoalsStream(c, params)
+}
+
 // GraphNeighbors operation middleware
 func (siw *ServerInterfaceWrapper) GraphNeighbors(c *gin.Context) {
 
@@ -239,6 +368,33 @@ func (siw *ServerInterfaceWrapper) GraphNeighbors(c *gin.Context) {
 	siw.Handler.GraphNeighbors(c, params)
 }
 
+// GraphNeighborsStream operation middleware
+func (siw *ServerInterfaceWrapper) GraphNeighborsStream(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GraphNeighborsStreamParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "options", c.Request.URL.Query(), &params.Options, runtime.BindQueryParameterOptions{Type: "object", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GraphNeighborsStream(c, params)
+}
+
 // GraphNeighbours operation middleware
 func (siw *ServerInterfaceWrapper) GraphNeighbours(c *gin.Context) {
 
@@ -266,6 +422,60 @@ func (siw *ServerInterfaceWrapper) GraphNeighbours(c *gin.Context) {
 	siw.Handler.GraphNeighbours(c, params)
 }
 
+// GraphRefresh operation middleware
+func (siw *ServerInterfaceWrapper) GraphRefresh(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GraphRefreshParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "options", c.Request.URL.Query(), &params.Options, runtime.BindQueryParameterOptions{Type: "object", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GraphRefresh(c, params)
+}
+
+// GraphReverse operation middleware
+func (siw *ServerInterfaceWrapper) GraphReverse(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GraphReverseParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "options", c.Request.URL.Query(), &params.Options, runtime.BindQueryParameterOptions{Type: "object", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GraphReverse(c, params)
+}
+
 // Help operation middleware
 func (siw *ServerInterfaceWrapper) Help(c *gin.Context) {
 
@@ -304,6 +514,96 @@ func (siw *ServerInterfaceWrapper) HelpDomain(c *gin.Context) {
 	siw.Handler.HelpDomain(c, domain)
 }
 
+// ListJobs operation middleware
+func (siw *ServerInterfaceWrapper) ListJobs(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListJobs(c)
+}
+
+// CreateGoalsJob operation middleware
+func (siw *ServerInterfaceWrapper) CreateGoalsJob(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateGoalsJobParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "options", c.Request.URL.Query(), &params.Options, runtime.BindQueryParameterOptions{Type: "object", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateGoalsJob(c, params)
+}
+
+// DeleteJob operation middleware
+func (siw *ServerInterfaceWrapper) DeleteJob(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "job" -------------
+	var job string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job", c.Param("job"), &job, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter job: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteJob(c, job)
+}
+
+// GetJob operation middleware
+func (siw *ServerInterfaceWrapper) GetJob(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "job" -------------
+	var job string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job", c.Param("job"), &job, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter job: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetJob(c, job)
+}
+
 // ListGoals operation middleware
 func (siw *ServerInterfaceWrapper) ListGoals(c *gin.Context) {
 
@@ -352,6 +652,143 @@ func (siw *ServerInterfaceWrapper) Objects(c *gin.Context) {
 	siw.Handler.Objects(c, params)
 }
 
+// ListRules operation middleware
+func (siw *ServerInterfaceWrapper) ListRules(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListRules(c)
+}
+
+// ListSearches operation middleware
+func (siw *ServerInterfaceWrapper) ListSearches(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSearches(c)
+}
+
+// DeleteSearch operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSearch(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSearch(c, name)
+}
+
+// GetSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetSearch(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSearch(c, name)
+}
+
+// SaveSearch operation middleware
+func (siw *ServerInterfaceWrapper) SaveSearch(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SaveSearch(c, name)
+}
+
+// RunSearch operation middleware
+func (siw *ServerInterfaceWrapper) RunSearch(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RunSearchParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "options", c.Request.URL.Query(), &params.Options, runtime.BindQueryParameterOptions{Type: "object", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RunSearch(c, name, params)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -379,6 +816,7 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.DELETE(options.BaseURL+"/cache", wrapper.FlushCache)
 	router.PUT(options.BaseURL+"/config", wrapper.SetConfig)
 	router.GET(options.BaseURL+"/console", wrapper.GetConsole)
 	router.PUT(options.BaseURL+"/console", wrapper.SetConsole)
@@ -386,11 +824,26 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.PUT(options.BaseURL+"/console/events", wrapper.ShowInConsole)
 	router.GET(options.BaseURL+"/domain/:domain/classes", wrapper.ListDomainClasses)
 	router.GET(options.BaseURL+"/domains", wrapper.ListDomains)
+	router.POST(options.BaseURL+"/graphs/diff", wrapper.GraphDiff)
 	router.POST(options.BaseURL+"/graphs/goals", wrapper.GraphGoals)
+	router.POST(options.BaseURL+"/graphs/goals/stream", wrapper.GraphGoalsStream)
 	router.POST(options.BaseURL+"/graphs/neighbors", wrapper.GraphNeighbors)
+	router.POST(options.BaseURL+"/graphs/neighbors/stream", wrapper.GraphNeighborsStream)
 	router.POST(options.BaseURL+"/graphs/neighbours", wrapper.GraphNeighbours)
+	router.POST(options.BaseURL+"/graphs/refresh", wrapper.GraphRefresh)
+	router.POST(options.BaseURL+"/graphs/reverse", wrapper.GraphReverse)
 	router.GET(options.BaseURL+"/help", wrapper.Help)
 	router.GET(options.BaseURL+"/help/:domain", wrapper.HelpDomain)
+	router.GET(options.BaseURL+"/jobs", wrapper.ListJobs)
+	router.POST(options.BaseURL+"/jobs/goals", wrapper.CreateGoalsJob)
+	router.DELETE(options.BaseURL+"/jobs/:job", wrapper.DeleteJob)
+	router.GET(options.BaseURL+"/jobs/:job", wrapper.GetJob)
 	router.POST(options.BaseURL+"/lists/goals", wrapper.ListGoals)
 	router.GET(options.BaseURL+"/objects", wrapper.Objects)
+	router.GET(options.BaseURL+"/rules", wrapper.ListRules)
+	router.GET(options.BaseURL+"/searches", wrapper.ListSearches)
+	router.DELETE(options.BaseURL+"/searches/:name", wrapper.DeleteSearch)
+	router.GET(options.BaseURL+"/searches/:name", wrapper.GetSearch)
+	router.PUT(options.BaseURL+"/searches/:name", wrapper.SaveSearch)
+	router.GET(options.BaseURL+"/searches/:name/graph", wrapper.RunSearch)
 }
@@ -5,11 +5,14 @@ package rest
 import (
 	"bytes"
 	"cmp"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
@@ -20,6 +23,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/ptr"
+	"github.com/korrel8r/korrel8r/pkg/unique"
 )
 
 func queryCounts(gq graph.Queries, _ api.GraphOptions) []api.QueryCount {
@@ -62,9 +66,33 @@ func node(n *graph.Node, opts api.GraphOptions) api.Node {
 			node.Result = append(node.Result, j)
 		}
 	}
+	if ptr.Deref(opts.Explain) {
+		for _, o := range n.Result.List() {
+			if chain := n.Explain(o); chain != nil {
+				node.Provenance = append(node.Provenance, provenance(o, chain))
+			}
+		}
+	}
 	return node
 }
 
+func provenance(o korrel8r.Object, chain []graph.Step) api.Provenance {
+	p := api.Provenance{}
+	p.Object, _ = json.Marshal(o)
+	for _, s := range chain {
+		step := api.ProvenanceStep{Class: s.Node.Class.String()}
+		step.Object, _ = json.Marshal(s.Object)
+		if s.Line != nil {
+			step.Rule = s.Line.Rule.Name()
+		}
+		if s.Query != nil {
+			step.Query = s.Query.String()
+		}
+		p.Steps = append(p.Steps, step)
+	}
+	return p
+}
+
 func nodes(g *graph.Graph, opts api.GraphOptions) []api.Node {
 	if g == nil {
 		return nil
@@ -152,7 +180,195 @@ func NewGraph(g *graph.Graph, optsPtr *api.GraphOptions) *api.Graph {
 		return &api.Graph{}
 	}
 	opts := ptr.Deref(optsPtr)
-	return &api.Graph{Nodes: nodes(g, opts), Edges: edges(g, opts)}
+	gr := &api.Graph{Nodes: nodes(g, opts), Edges: edges(g, opts)}
+	if u := g.Usage; u != nil {
+		gr.Usage = &api.Usage{Queries: u.Queries, Objects: u.Objects, Bytes: u.Bytes, Exhausted: u.Exhausted}
+	}
+	return gr
+}
+
+// GraphQueries returns the distinct queries recorded in the nodes of a result graph.
+func GraphQueries(e *engine.Engine, g *api.Graph) ([]korrel8r.Query, error) {
+	var queries []korrel8r.Query
+	seen := map[string]bool{}
+	for _, n := range g.Nodes {
+		for _, qc := range n.Queries {
+			if seen[qc.Query] {
+				continue
+			}
+			seen[qc.Query] = true
+			q, err := e.Query(qc.Query)
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, q)
+		}
+	}
+	if len(queries) == 0 {
+		return nil, errors.New("graph has no queries")
+	}
+	return queries, nil
+}
+
+// NewRefreshGraph returns an api.Graph for a refreshed graph (see [traverse.Refresh]).
+// Nodes include empty results, and a [api.Delta] comparing them to the previous graph.
+// Edges from the previous graph are kept if both ends still have results.
+func NewRefreshGraph(g *graph.Graph, prev *api.Graph, optsPtr *api.GraphOptions) *api.Graph {
+	opts := ptr.Deref(optsPtr)
+	prevNodes := map[string]api.Node{}
+	for _, n := range prev.Nodes {
+		prevNodes[n.Class] = n
+	}
+	gr := NewGraph(g, optsPtr)
+	gr.Nodes = []api.Node{}
+	current := map[string]*graph.Node{}
+	g.EachNode(func(n *graph.Node) {
+		an := node(n, opts)
+		an.Delta = delta(n, prevNodes[an.Class], opts)
+		gr.Nodes = append(gr.Nodes, an)
+		current[an.Class] = n
+	})
+	for _, e := range prev.Edges {
+		start, goal := current[e.Start], current[e.Goal]
+		if start == nil || start.Empty() || goal == nil || goal.Empty() {
+			continue
+		}
+		edge := api.Edge{Start: e.Start, Goal: e.Goal}
+		if ptr.Deref(opts.Rules) {
+			counts := map[string]int{}
+			for q, qc := range goal.Queries {
+				counts[q.String()] = qc.Count
+			}
+			for _, r := range e.Rules {
+				rule := api.Rule{Name: r.Name}
+				for _, qc := range r.Queries {
+					if n := counts[qc.Query]; n > 0 {
+						rule.Queries = append(rule.Queries, api.QueryCount{Query: qc.Query, Count: &n})
+					}
+				}
+				if len(rule.Queries) > 0 {
+					edge.Rules = append(edge.Rules, rule)
+				}
+			}
+		}
+		gr.Edges = append(gr.Edges, edge)
+	}
+	return gr
+}
+
+// delta compares a refreshed node with the previous node for the same class.
+// Objects are compared by [korrel8r.ObjectID].
+func delta(n *graph.Node, prev api.Node, opts api.GraphOptions) *api.Delta {
+	d := &api.Delta{PreviousCount: ptr.Deref(prev.Count)}
+	if len(prev.Result) == 0 {
+		return d // Can't compare objects.
+	}
+	before := map[any]bool{}
+	var beforeKeys []any // In original order, parallel to prev.Result
+	for _, raw := range prev.Result {
+		var k any = string(raw)
+		if o, err := n.Class.Unmarshal(raw); err == nil {
+			k = korrel8r.ObjectID(n.Class, o)
+		}
+		before[k] = true
+		beforeKeys = append(beforeKeys, k)
+	}
+	added, removed := 0, 0
+	after := map[any]bool{}
+	for _, o := range n.Result.List() {
+		k := korrel8r.ObjectID(n.Class, o)
+		after[k] = true
+		if !before[k] {
+			added++
+			if ptr.Deref(opts.Results) {
+				j, _ := json.Marshal(o)
+				d.AddedResult = append(d.AddedResult, j)
+			}
+		}
+	}
+	for i, k := range beforeKeys {
+		if !after[k] {
+			removed++
+			if ptr.Deref(opts.Results) {
+				d.RemovedResult = append(d.RemovedResult, prev.Result[i])
+			}
+		}
+	}
+	d.Added, d.Removed = &added, &removed
+	return d
+}
+
+// NewGraphEvent returns an api.GraphEvent corresponding to a traverse.Event.
+func NewGraphEvent(e traverse.Event) api.GraphEvent {
+	ge := api.GraphEvent{Type: api.GraphEventType(e.Type), Count: e.Count}
+	if e.Class != nil {
+		ge.Class = e.Class.String()
+	}
+	if e.Query != nil {
+		ge.Query = e.Query.String()
+	}
+	if e.Line != nil {
+		ge.Start = e.Line.Start().Class.String()
+		ge.Rule = e.Line.Rule.Name()
+	}
+	if e.Err != nil {
+		ge.Error = e.Err.Error()
+	}
+	return ge
+}
+
+// progressGraph builds a partial api.Graph from traversal events, for running jobs.
+// The graph has nodes with counts and queries, and edges between non-empty nodes, but no results.
+// It is safe for concurrent use.
+type progressGraph struct {
+	mu      sync.Mutex
+	counts  map[string]int
+	queries map[string]map[string]int
+	edges   unique.Set[[2]string]
+}
+
+func newProgressGraph() *progressGraph {
+	return &progressGraph{counts: map[string]int{}, queries: map[string]map[string]int{}, edges: unique.Set[[2]string]{}}
+}
+
+// Listen is a [traverse.Listener] to update the graph.
+func (p *progressGraph) Listen(e traverse.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch e.Type {
+	case traverse.EventNode:
+		p.counts[e.Class.String()] += e.Count
+	case traverse.EventQuery:
+		class := e.Class.String()
+		if p.queries[class] == nil {
+			p.queries[class] = map[string]int{}
+		}
+		p.queries[class][e.Query.String()] = e.Count
+	case traverse.EventLine:
+		p.edges.Add([2]string{e.Line.Start().Class.String(), e.Class.String()})
+	}
+}
+
+// Graph returns the current partial graph.
+func (p *progressGraph) Graph() *api.Graph {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g := &api.Graph{Nodes: []api.Node{}}
+	for _, class := range slices.Sorted(maps.Keys(p.counts)) {
+		n := api.Node{Class: class, Count: new(p.counts[class])}
+		for q, count := range p.queries[class] {
+			if count > 0 {
+				n.Queries = append(n.Queries, api.QueryCount{Query: q, Count: new(count)})
+			}
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+	for e := range p.edges {
+		if p.counts[e[0]] > 0 && p.counts[e[1]] > 0 {
+			g.Edges = append(g.Edges, api.Edge{Start: e[0], Goal: e[1]})
+		}
+	}
+	return Normalize(g).(*api.Graph)
 }
 
 func copyBody(r *http.Request) string {
@@ -237,7 +453,10 @@ func Constraint(c *api.Constraint) *korrel8r.Constraint {
 	if c == nil {
 		return nil
 	}
-	return &korrel8r.Constraint{Limit: c.Limit, QueryLimit: c.QueryLimit, Start: c.Start, End: c.End}
+	return &korrel8r.Constraint{
+		Limit: c.Limit, QueryLimit: c.QueryLimit, Start: c.Start, End: c.End,
+		MaxQueries: c.MaxQueries, MaxObjects: c.MaxObjects, MaxBytes: c.MaxBytes,
+	}
 }
 
 // DomainHelp returns the full description text for domains.
@@ -278,3 +497,24 @@ func ListDomains(e *engine.Engine) []api.Domain {
 	}
 	return domains
 }
+
+// ListRules returns the definitions of the engine's rules.
+func ListRules(e *engine.Engine) []api.RuleDefinition {
+	classNames := func(classes []korrel8r.Class) []api.Class {
+		names := make([]api.Class, len(classes))
+		for i, c := range classes {
+			names[i] = c.String()
+		}
+		return names
+	}
+	rules := []api.RuleDefinition{} // Return [] on empty, not null.
+	for _, r := range e.Rules() {
+		rules = append(rules, api.RuleDefinition{
+			Name:     r.Name(),
+			Start:    classNames(r.Start()),
+			Goal:     classNames(r.Goal()),
+			Template: korrel8r.RuleTemplate(r),
+		})
+	}
+	return rules
+}
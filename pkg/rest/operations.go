@@ -4,12 +4,15 @@
 package rest
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -17,10 +20,14 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
 	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/export"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
 	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/korrel8r/korrel8r/pkg/searches"
 	"github.com/korrel8r/korrel8r/pkg/session"
 	"github.com/korrel8r/korrel8r/pkg/unique"
 )
@@ -30,6 +37,9 @@ var log = logging.Log()
 type API struct {
 	Sessions session.Manager
 	Router   *gin.Engine
+	Searches searches.Store // Global saved searches, shared by all sessions.
+
+	searchesMu sync.Mutex // Serializes owner checks and changes to global searches.
 }
 
 // session returns the per-request Session from the context.
@@ -49,6 +59,7 @@ func New(sessions session.Manager, r *gin.Engine) (*API, error) {
 	a := &API{
 		Sessions: sessions,
 		Router:   r,
+		Searches: searches.NewMemoryStore(),
 	}
 	rg := r.Group(api.BasePath)
 	rg.Use(Metrics(), a.logger)
@@ -94,14 +105,178 @@ func (a *API) ListDomainClasses(c *gin.Context, domain string) {
 }
 
 func (a *API) GraphGoals(c *gin.Context, params GraphGoalsParams) {
-	g, _ := a.goals(c)
-	gr := NewGraph(g, params.Options)
-	okResponse(c, gr)
+	g, _ := a.goals(c, params.Options)
+	okGraph(c, NewGraph(g, params.Options))
+}
+
+// GraphGoalsStream streams traversal progress as SSE events, ending with the result graph.
+// (POST /graphs/goals/stream)
+func (a *API) GraphGoalsStream(c *gin.Context, params GraphGoalsStreamParams) {
+	e, start, goals := a.goalsRequest(c, params.Options)
+	if c.IsAborted() {
+		return
+	}
+	a.graphStream(c, e, params.Options, func(ctx context.Context, listen traverse.Listener) (*graph.Graph, error) {
+		return traverse.GoalsStream(ctx, e, start, goals, listen)
+	})
+}
+
+// GraphNeighborsStream streams traversal progress as SSE events, ending with the result graph.
+// (POST /graphs/neighbors/stream)
+func (a *API) GraphNeighborsStream(c *gin.Context, params GraphNeighborsStreamParams) {
+	e, start, depth := a.neighborsRequest(c, params.Options)
+	if c.IsAborted() {
+		return
+	}
+	a.graphStream(c, e, params.Options, func(ctx context.Context, listen traverse.Listener) (*graph.Graph, error) {
+		return traverse.NeighborsStream(ctx, e, start, depth, listen)
+	})
+}
+
+// graphStream runs search and sends progress as SSE events, ending with the result graph or an error.
+func (a *API) graphStream(c *gin.Context, e *engine.Engine, opts *api.GraphOptions, search func(context.Context, traverse.Listener) (*graph.Graph, error)) {
+	ctx, cancel := streamContext(c, e)
+	defer cancel()
+
+	w := c.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	var sendErr error // Stop sending after the first write error, the client has gone away.
+	send := func(ge api.GraphEvent) {
+		if sendErr == nil {
+			if sendErr = a.sendGraphEvent(w, ge); sendErr != nil {
+				cancel()
+			}
+		}
+	}
+	g, err := search(ctx, func(ev traverse.Event) { send(NewGraphEvent(ev)) })
+	if err != nil {
+		send(api.GraphEvent{Type: api.GraphEventTypeError, Error: err.Error()})
+	} else {
+		send(api.GraphEvent{Type: api.GraphEventTypeGraph, Graph: NewGraph(g, opts)})
+	}
+	if sendErr != nil {
+		log.V(3).Error(sendErr, "Graph SSE error")
+	}
+}
+
+// streamContext returns the context for a streaming search.
+//
+// Like [API.ConsoleEvents], a stream is not limited by the request timeout.
+// It is canceled if the client disconnects, and fails after the jobTimeout tuning setting, like a job.
+func streamContext(c *gin.Context, e *engine.Engine) (context.Context, context.CancelFunc) {
+	reqCtx := c.Request.Context()
+	timeout := cmp.Or(time.Duration(e.Tuning.JobTimeout), session.DefaultJobTimeout)
+	ctx, cancel := context.WithTimeoutCause(context.WithoutCancel(reqCtx), timeout, fmt.Errorf("search timed out after %v", timeout))
+	stop := context.AfterFunc(reqCtx, func() {
+		// Ignore the request timeout, the client may still be connected.
+		// A disconnect after the request timeout is detected by the next failed write.
+		if !errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
+			cancel()
+		}
+	})
+	return ctx, func() { stop(); cancel() }
+}
+
+func (a *API) sendGraphEvent(w gin.ResponseWriter, ge api.GraphEvent) error {
+	b, _ := json.Marshal(ge)
+	if _, err := fmt.Fprintf(w, "event: %v\ndata: %s\n\n", ge.Type, b); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
+
+// CreateGoalsJob starts a goal search job in the session.
+// (POST /jobs/goals)
+func (a *API) CreateGoalsJob(c *gin.Context, params CreateGoalsJobParams) {
+	e, start, goals := a.goalsRequest(c, params.Options)
+	if c.IsAborted() {
+		return
+	}
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	job, err := s.StartJob(c.Request.Context(), func(ctx context.Context, job *session.Job) error {
+		progress := newProgressGraph()
+		job.SetGraph(progress.Graph)
+		g, err := traverse.GoalsStream(ctx, e, start, goals, progress.Listen)
+		if g != nil {
+			gr := NewGraph(g, params.Options)
+			job.SetGraph(func() *api.Graph { return gr })
+		}
+		return err
+	})
+	if errors.Is(err, session.ErrTooManyJobs) {
+		check(c, http.StatusTooManyRequests, err)
+		return
+	}
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	c.JSON(http.StatusAccepted, job.State())
+}
+
+// ListJobs returns the jobs in the session, without graphs.
+// (GET /jobs)
+func (a *API) ListJobs(c *gin.Context) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	jobs := api.Jobs{} // return [] not null for empty
+	for _, j := range s.Jobs() {
+		jobs = append(jobs, j.State())
+	}
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetJob returns the job state with the current graph.
+// (GET /jobs/{job})
+func (a *API) GetJob(c *gin.Context, id string) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	job, err := s.Job(id)
+	if !check(c, http.StatusNotFound, err, "job %v", id) {
+		return
+	}
+	c.JSON(http.StatusOK, jobState(job))
+}
+
+// DeleteJob cancels and removes a job, returns its final state.
+// (DELETE /jobs/{job})
+func (a *API) DeleteJob(c *gin.Context, id string) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	job, err := s.DeleteJob(id)
+	if !check(c, http.StatusNotFound, err, "job %v", id) {
+		return
+	}
+	select { // Wait for the job to stop, unless the request is canceled first.
+	case <-job.Done():
+	case <-c.Request.Context().Done():
+	}
+	c.JSON(http.StatusOK, jobState(job))
+}
+
+func jobState(job *session.Job) api.Job {
+	state := job.State()
+	state.Graph = job.Graph()
+	return state
 }
 
 func (a *API) ListGoals(c *gin.Context) {
 	nodes := []api.Node{} // return [] not null for empty
-	g, goals := a.goals(c)
+	g, goals := a.goals(c, nil)
 	if c.IsAborted() {
 		return
 	}
@@ -115,25 +290,66 @@ func (a *API) ListGoals(c *gin.Context) {
 }
 
 func (a *API) GraphNeighbors(c *gin.Context, params GraphNeighborsParams) {
+	e, start, depth := a.neighborsRequest(c, params.Options)
+	if c.IsAborted() {
+		return
+	}
+	g, err := traverse.Neighbors(c.Request.Context(), e, start, depth)
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	okGraph(c, NewGraph(g, params.Options))
+}
+
+func (a *API) GraphReverse(c *gin.Context, params GraphReverseParams) {
 	session, err := a.session(c)
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
 	e := session.Engine
-	r := api.Neighbors{}
+	r := api.Reverse{}
 	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
 		return
 	}
-	start, err := TraverseStart(e, r.Start)
+	goal, err := TraverseStart(e, r.Goal)
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	goal.Explain = ptr.Deref(ptr.Deref(params.Options).Explain)
+	starts, err := e.Classes(([]string)(r.Starts))
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	candidates, err := e.Queries(([]string)(r.Candidates))
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	g, err := traverse.Reverse(c.Request.Context(), e, goal, starts, candidates)
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	okGraph(c, NewGraph(g, params.Options))
+}
+
+func (a *API) GraphRefresh(c *gin.Context, params GraphRefreshParams) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	e := session.Engine
+	r := api.Refresh{}
+	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
+		return
+	}
+	queries, err := GraphQueries(e, &r.Graph)
 	if !check(c, http.StatusBadRequest, err) {
 		return
 	}
-	g, err := traverse.Neighbors(c.Request.Context(), e, start, r.Depth)
+	g, err := traverse.Refresh(c.Request.Context(), e, queries, Constraint(r.Constraint))
 	if !check(c, http.StatusNotFound, err) {
 		return
 	}
-	gr := NewGraph(g, params.Options)
-	okResponse(c, gr)
+	okGraph(c, NewRefreshGraph(g, &r.Graph, params.Options))
 }
 
 // GraphNeighbours alias for alternate spelling.
@@ -165,6 +381,14 @@ func (a *API) Objects(c *gin.Context, params ObjectsParams) {
 	c.JSON(http.StatusOK, body)
 }
 
+func (a *API) ListRules(c *gin.Context) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	c.JSON(http.StatusOK, ListRules(session.Engine))
+}
+
 func (a *API) SetConfig(c *gin.Context, params SetConfigParams) {
 	if params.Verbose != nil {
 		log.V(1).Info("Config set verbose", "level", *params.Verbose)
@@ -173,28 +397,87 @@ func (a *API) SetConfig(c *gin.Context, params SetConfigParams) {
 	c.JSON(http.StatusOK, params)
 }
 
-// goals is shared between GraphGoals and ListGoals
-func (a *API) goals(c *gin.Context) (*graph.Graph, []korrel8r.Class) {
+func (a *API) FlushCache(c *gin.Context, params FlushCacheParams) {
 	session, err := a.session(c)
 	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	if params.Domain == nil {
+		session.Engine.FlushCache()
+	} else {
+		d, err := session.Engine.Domain(*params.Domain)
+		if !check(c, http.StatusNotFound, err, "domain not found: %s", *params.Domain) {
+			return
+		}
+		session.Engine.FlushCache(d)
+	}
+	c.JSON(http.StatusOK, api.Empty{})
+}
+
+// goals is shared between GraphGoals and ListGoals
+func (a *API) goals(c *gin.Context, opts *api.GraphOptions) (*graph.Graph, []korrel8r.Class) {
+	e, start, goals := a.goalsRequest(c, opts)
+	if c.IsAborted() {
 		return nil, nil
 	}
+	g, err := traverse.Goals(c.Request.Context(), e, start, goals)
+	check(c, http.StatusNotFound, err)
+	return g, goals
+}
+
+// goalsRequest parses a goals request body, shared by goal searches.
+// neighborsRequest parses a neighbors search request, aborts c on error.
+func (a *API) neighborsRequest(c *gin.Context, opts *api.GraphOptions) (*engine.Engine, traverse.Start, int) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return nil, traverse.Start{}, 0
+	}
 	e := session.Engine
-	r := api.Goals{}
+	r := api.Neighbors{}
 	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
-		return nil, nil
+		return nil, traverse.Start{}, 0
 	}
 	start, err := TraverseStart(e, r.Start)
 	if !check(c, http.StatusBadRequest, err) {
-		return nil, nil
+		return nil, traverse.Start{}, 0
 	}
-	goals, err := e.Classes(([]string)(r.Goals))
+	start.Explain = ptr.Deref(ptr.Deref(opts).Explain)
+	return e, start, r.Depth
+}
+
+func (a *API) goalsRequest(c *gin.Context, opts *api.GraphOptions) (*engine.Engine, traverse.Start, []korrel8r.Class) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return nil, traverse.Start{}, nil
+	}
+	e := session.Engine
+	r := api.Goals{}
+	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
+		return nil, traverse.Start{}, nil
+	}
+	start, goals, err := goalsStart(e, r, opts)
 	if !check(c, http.StatusBadRequest, err) {
-		return nil, nil
+		return nil, traverse.Start{}, nil
 	}
-	g, err := traverse.Goals(c.Request.Context(), e, start, goals)
-	check(c, http.StatusNotFound, err)
-	return g, goals
+	return e, start, goals
+}
+
+// goalsStart converts goal search parameters to a traversal start and goal classes.
+func goalsStart(e *engine.Engine, r api.Goals, opts *api.GraphOptions) (traverse.Start, []korrel8r.Class, error) {
+	start, err := TraverseStart(e, r.Start)
+	if err != nil {
+		return traverse.Start{}, nil, err
+	}
+	goals, err := e.Classes(([]string)(r.Goals))
+	if err != nil {
+		return traverse.Start{}, nil, err
+	}
+	if r.ShortestPaths < 0 {
+		return traverse.Start{}, nil, fmt.Errorf("invalid shortestPaths: %v", r.ShortestPaths)
+	}
+	start.ShortestPaths = r.ShortestPaths
+	start.Explain = ptr.Deref(ptr.Deref(opts).Explain)
+	return start, goals, nil
 }
 
 func check(c *gin.Context, code int, err error, format ...any) (ok bool) {
@@ -218,6 +501,29 @@ func okResponse(c *gin.Context, body any) {
 	}
 }
 
+// okGraph sets an OK response with a graph if we were not already aborted.
+// The graph is JSON unless the Accept header asks for an [export.Format].
+func okGraph(c *gin.Context, g *api.Graph) {
+	if c.IsAborted() {
+		return
+	}
+	offered := []string{gin.MIMEJSON}
+	for _, f := range export.Formats {
+		offered = append(offered, f.MIMEType())
+	}
+	mimeType := c.NegotiateFormat(offered...)
+	f, ok := export.ForMIMEType(mimeType)
+	if !ok {
+		c.JSON(http.StatusOK, g)
+		return
+	}
+	var b bytes.Buffer
+	if !check(c, http.StatusInternalServerError, export.Write(&b, f, g)) {
+		return
+	}
+	c.Data(http.StatusOK, mimeType, b.Bytes())
+}
+
 func (a *API) Help(c *gin.Context) {
 	session, err := a.session(c)
 	if !check(c, http.StatusInternalServerError, err) {
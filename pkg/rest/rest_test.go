@@ -24,6 +24,8 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/ptr"
 	"github.com/korrel8r/korrel8r/pkg/session"
@@ -59,6 +61,25 @@ func TestAPI_ListDomainClasses(t *testing.T) {
 	assertDo(t, a, "GET", "/api/v1alpha1/domain/nonexistent/classes", nil, http.StatusNotFound, api.Error{Error: "domain not found: nonexistent: domain not found: nonexistent"})
 }
 
+func TestAPI_ListRules(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	e, err := engine.Build().Domains(d).
+		Rules(mock.NewRule("a-b", list(d.Class("a")), list(d.Class("b")), mock.NewQuery(d.Class("b"), "y"))).
+		Config(config.Configs{{
+			Rules: []config.Rule{{
+				Name:   "b-a",
+				Start:  config.ClassSpec{Domain: "mock", Classes: []string{"b"}},
+				Goal:   config.ClassSpec{Domain: "mock", Classes: []string{"a"}},
+				Result: config.ResultSpec{Query: `mock:a:{{.}}`},
+			}},
+		}}).Engine()
+	require.NoError(t, err)
+	assertDo(t, newTestAPI(t, e), "GET", "/api/v1alpha1/rules", nil, http.StatusOK, []api.RuleDefinition{
+		{Name: "a-b", Start: []api.Class{"mock:a"}, Goal: []api.Class{"mock:b"}},
+		{Name: "b-a", Start: []api.Class{"mock:b"}, Goal: []api.Class{"mock:a"}, Template: "mock:a:{{.}}"},
+	})
+}
+
 func TestAPIListGoals(t *testing.T) {
 	e := testEngine(t)
 	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/lists/goals",
@@ -111,6 +132,253 @@ func TestAPIGraphGoals_rules(t *testing.T) {
 		})
 }
 
+func TestAPIGraphGoals_shortestPaths(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b", "c")
+	a, b, c := d.Class("a"), d.Class("b"), d.Class("c")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:b:b", "b")
+	s.AddQuery("mock:c:ac", "ac")
+	s.AddQuery("mock:c:bc", "bc")
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "b")),
+		mock.NewRule("b-c", list(b), list(c), mock.NewQuery(c, "bc")),
+		mock.NewRule("a-c", list(a), list(c), mock.NewQuery(c, "ac")),
+	).Engine()
+	require.NoError(t, err)
+	req := api.Goals{
+		Start:         api.Start{Class: "mock:a", Objects: []json.RawMessage{[]byte(`"x"`)}},
+		Goals:         []string{"mock:c"},
+		ShortestPaths: 1,
+	}
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/goals", req, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1)},
+				{Class: "mock:c", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:c:ac", Count: ptr.To(1)}}},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:c"}},
+		})
+	req.ShortestPaths = -1
+	rr := newTestAPI(t, e).do(t, "POST", "/api/v1alpha1/graphs/goals", req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+}
+
+func TestAPIGraphNeighbors_budget(t *testing.T) {
+	e := testEngine(t)
+	req := api.Neighbors{
+		Start: api.Start{
+			Queries:    []string{"mock:a:x"},
+			Constraint: &api.Constraint{MaxQueries: ptr.To(1)},
+		},
+		Depth: 2,
+	}
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/neighbors", req, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}}}},
+			Usage: &api.Usage{Queries: 1, Objects: 1, Exhausted: true},
+		})
+}
+
+func TestAPIGraphRefresh(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	results := map[string][]korrel8r.Object{"mock:a:x": {"ax"}, "mock:b:y": {"q", "r"}}
+	s := mock.NewStore(d)
+	s.AddLookup(func(q korrel8r.Query) ([]korrel8r.Object, error) { return results[q.String()], nil })
+	e, err := engine.Build().Domains(d).Stores(s).Rules(mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y"))).Engine()
+	require.NoError(t, err)
+	prev := api.Graph{
+		Nodes: []api.Node{
+			{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}}},
+			{Class: "mock:b", Count: ptr.To(2), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2)}},
+				Result: []api.Object{[]byte(`"p"`), []byte(` "\u0071"`)}}, // Same object as "q", different JSON.
+		},
+		Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b",
+			Rules: []api.Rule{{Name: "a-b", Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2)}}}}}},
+	}
+	a1 := newTestAPI(t, e)
+	assertDo(t, a1, "POST", "/api/v1alpha1/graphs/refresh?rules=true&results=true", api.Refresh{Graph: prev}, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}},
+					Result: []api.Object{[]byte(`"ax"`)}, Delta: &api.Delta{PreviousCount: 1}},
+				{Class: "mock:b", Count: ptr.To(2), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2)}},
+					Result: []api.Object{[]byte(`"q"`), []byte(`"r"`)},
+					Delta: &api.Delta{PreviousCount: 2, Added: ptr.To(1), Removed: ptr.To(1),
+						AddedResult: []api.Object{[]byte(`"r"`)}, RemovedResult: []api.Object{[]byte(`"p"`)}}},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b",
+				Rules: []api.Rule{{Name: "a-b", Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2)}}}}}},
+		})
+
+	// All results vanished from mock:b, the node is kept with a delta but the edge is dropped.
+	delete(results, "mock:b:y")
+	assertDo(t, a1, "POST", "/api/v1alpha1/graphs/refresh", api.Refresh{Graph: prev}, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}},
+					Delta: &api.Delta{PreviousCount: 1}},
+				{Class: "mock:b", Count: ptr.To(0),
+					Delta: &api.Delta{PreviousCount: 2, Added: ptr.To(0), Removed: ptr.To(2)}},
+			},
+		})
+
+	w := a1.do(t, "POST", "/api/v1alpha1/graphs/refresh", api.Refresh{})
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
+
+func TestAPIGraphReverse(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:b:from-x", []korrel8r.Object{"bx"})
+	s.AddQuery("mock:b:from-y", []korrel8r.Object{"by"})
+	s.AddQuery("mock:a:from-bx", []korrel8r.Object{"x", "y"})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), func(o korrel8r.Object) ([]korrel8r.Query, error) {
+			return []korrel8r.Query{mock.NewQuery(b, fmt.Sprintf("from-%v", o))}, nil
+		}),
+		mock.NewRule("b-a", list(b), list(a), func(o korrel8r.Object) ([]korrel8r.Query, error) {
+			return []korrel8r.Query{mock.NewQuery(a, fmt.Sprintf("from-%v", o))}, nil
+		}),
+	).Engine()
+	require.NoError(t, err)
+	req := api.Reverse{
+		Goal:   api.Start{Class: "mock:b", Objects: []json.RawMessage{[]byte(`"bx"`)}},
+		Starts: []string{"mock:a"},
+	}
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/reverse", req, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1)},
+				{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:from-x", Count: ptr.To(1)}}},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+		})
+
+	req.Starts = []string{"mock:nosuch"}
+	w := newTestAPI(t, e).do(t, "POST", "/api/v1alpha1/graphs/reverse", req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
+
+func TestAPIGraphGoals_explain(t *testing.T) {
+	e := testEngine(t)
+	req := api.Goals{
+		Start: api.Start{Queries: []string{"mock:a:x"}},
+		Goals: []string{"mock:b"},
+	}
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/goals?explain=true", req, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}},
+					Provenance: []api.Provenance{{Object: []byte(`"ax"`), Steps: []api.ProvenanceStep{
+						{Class: "mock:a", Object: []byte(`"ax"`), Query: "mock:a:x"},
+					}}}},
+				{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}},
+					Provenance: []api.Provenance{{Object: []byte(`"by"`), Steps: []api.ProvenanceStep{
+						{Class: "mock:a", Object: []byte(`"ax"`), Query: "mock:a:x"},
+						{Class: "mock:b", Object: []byte(`"by"`), Query: "mock:b:y", Rule: "a-b"},
+					}}}},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+		})
+}
+
+func TestAPIGraphGoalsStream(t *testing.T) {
+	e := testEngine(t)
+	a := newTestAPI(t, e)
+	rr := a.do(t, "POST", "/api/v1alpha1/graphs/goals/stream",
+		api.Goals{
+			Start: api.Start{
+				Class:   "mock:a",
+				Objects: []json.RawMessage{[]byte(`"x"`)},
+			},
+			Goals: []string{"mock:b"},
+		})
+	events := graphEvents(t, rr)
+	last := events[len(events)-1]
+	assert.Equal(t, api.GraphEventTypeGraph, last.Type)
+	require.NotNil(t, last.Graph)
+	assert.Len(t, last.Graph.Nodes, 2)
+	assert.ElementsMatch(t, []api.GraphEvent{
+		{Type: api.GraphEventTypeNode, Class: "mock:a", Count: 1},
+		{Type: api.GraphEventTypeLine, Class: "mock:b", Start: "mock:a", Rule: "a-b", Query: "mock:b:y"},
+		{Type: api.GraphEventTypeLine, Class: "mock:b", Start: "mock:a", Rule: "a-none", Query: "mock:b:none"},
+		{Type: api.GraphEventTypeQuery, Class: "mock:b", Start: "mock:a", Rule: "a-b", Query: "mock:b:y", Count: 1},
+		{Type: api.GraphEventTypeQuery, Class: "mock:b", Start: "mock:a", Rule: "a-none", Query: "mock:b:none"},
+		{Type: api.GraphEventTypeNode, Class: "mock:b", Count: 1},
+	}, events[:len(events)-1])
+}
+
+func TestAPIGraphGoalsStream_badRequest(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	rr := a.do(t, "POST", "/api/v1alpha1/graphs/goals/stream", api.Goals{Goals: []string{"mock:b"}})
+	assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+}
+
+func TestAPIGraphGoalsStream_requestTimeout(t *testing.T) {
+	e := testEngine(t)
+	e.Tuning.RequestTimeout = config.Duration(time.Nanosecond)
+	r := ginEngine()
+	sessions := session.NewSingleManager(e)
+	r.Use(session.Middleware(sessions))
+	a, err := New(sessions, r)
+	require.NoError(t, err)
+	// The stream is not limited by the request timeout.
+	rr := (&testAPI{API: a, Router: r}).do(t, "POST", "/api/v1alpha1/graphs/goals/stream",
+		api.Goals{Start: api.Start{Queries: []string{"mock:a:x"}}, Goals: []string{"mock:b"}})
+	events := graphEvents(t, rr)
+	last := events[len(events)-1]
+	require.Equal(t, api.GraphEventTypeGraph, last.Type, last.Error)
+	assert.Len(t, last.Graph.Nodes, 2)
+}
+
+func TestAPIGraphNeighborsStream(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	rr := a.do(t, "POST", "/api/v1alpha1/graphs/neighbors/stream",
+		api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1})
+	events := graphEvents(t, rr)
+	last := events[len(events)-1]
+	assert.Equal(t, api.GraphEventTypeGraph, last.Type)
+	require.NotNil(t, last.Graph)
+	assert.Len(t, last.Graph.Nodes, 2)
+	assert.ElementsMatch(t, []api.GraphEvent{
+		{Type: api.GraphEventTypeQuery, Class: "mock:a", Query: "mock:a:x", Count: 1},
+		{Type: api.GraphEventTypeNode, Class: "mock:a", Count: 1},
+		{Type: api.GraphEventTypeLine, Class: "mock:b", Start: "mock:a", Rule: "a-b", Query: "mock:b:y"},
+		{Type: api.GraphEventTypeLine, Class: "mock:b", Start: "mock:a", Rule: "a-none", Query: "mock:b:none"},
+		{Type: api.GraphEventTypeQuery, Class: "mock:b", Start: "mock:a", Rule: "a-b", Query: "mock:b:y", Count: 1},
+		{Type: api.GraphEventTypeQuery, Class: "mock:b", Start: "mock:a", Rule: "a-none", Query: "mock:b:none"},
+		{Type: api.GraphEventTypeNode, Class: "mock:b", Count: 1},
+	}, events[:len(events)-1])
+
+	rr = a.do(t, "POST", "/api/v1alpha1/graphs/neighbors/stream", api.Neighbors{Depth: 1})
+	assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+}
+
+// graphEvents reads the SSE events from a graph stream response.
+func graphEvents(t *testing.T, rr *httptest.ResponseRecorder) []api.GraphEvent {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	var events []api.GraphEvent
+	scanner := bufio.NewScanner(rr.Body)
+	var name string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if v, ok := strings.CutPrefix(line, "event: "); ok {
+			name = v
+		} else if v, ok := strings.CutPrefix(line, "data: "); ok {
+			var ev api.GraphEvent
+			require.NoError(t, json.Unmarshal([]byte(v), &ev))
+			assert.Equal(t, name, string(ev.Type))
+			events = append(events, ev)
+		}
+	}
+	require.NotEmpty(t, events)
+	return events
+}
+
 func TestAPIGetObjects(t *testing.T) {
 	d := mock.NewDomain("x")
 	s := mock.NewStore(d)
@@ -147,6 +415,34 @@ func TestAPIGetObjects_withConstraint(t *testing.T) {
 	require.Equal(t, `["a1"]`, w.Body.String())
 }
 
+func TestAPIFlushCache(t *testing.T) {
+	d := mock.NewDomain("x")
+	s := mock.NewStore(d)
+	calls := 0
+	s.AddLookup(func(korrel8r.Query) ([]korrel8r.Object, error) { calls++; return []korrel8r.Object{"foo"}, nil })
+	e, err := engine.Build().Domains(d).Stores(s).Tuning(&config.Tuning{QueryCacheTTL: config.Duration(time.Hour)}).Engine()
+	require.NoError(t, err)
+	a := newTestAPI(t, e)
+
+	get := func() {
+		t.Helper()
+		w := a.do(t, "GET", "/api/v1alpha1/objects?query=x:y:foo", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `["foo"]`, w.Body.String())
+	}
+	get()
+	get()
+	assert.Equal(t, 1, calls)
+	assertDo(t, a, "DELETE", "/api/v1alpha1/cache", nil, http.StatusOK, api.Empty{})
+	get()
+	assert.Equal(t, 2, calls)
+	assertDo(t, a, "DELETE", "/api/v1alpha1/cache?domain=x", nil, http.StatusOK, api.Empty{})
+	get()
+	assert.Equal(t, 3, calls)
+	w := a.do(t, "DELETE", "/api/v1alpha1/cache?domain=nosuch", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func ginEngine() *gin.Engine {
 	if os.Getenv(gin.EnvGinMode) == "" { // Don't override an explicit env setting.
 		gin.SetMode(gin.TestMode)
@@ -294,6 +590,36 @@ func TestAPIGraphNeighbors_badRequest(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestAPIGraphNeighbors_accept(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	body := `{"start":{"queries":["mock:a:x"]},"depth":1}`
+	for _, x := range []struct {
+		accept, contentType, want string
+	}{
+		{"", "application/json", `"nodes":`},
+		{"*/*", "application/json", `"nodes":`},
+		{"application/json", "application/json", `"nodes":`},
+		{"text/vnd.graphviz", "text/vnd.graphviz", `"mock:a" -> "mock:b";`},
+		{"text/vnd.mermaid", "text/vnd.mermaid", `n0 --> n1`},
+		{"application/graphml+xml", "application/graphml+xml", `<edge source="mock:a" target="mock:b">`},
+		{"application/vnd.cytoscape+json", "application/vnd.cytoscape+json", `"source": "mock:a"`},
+		{"text/html, text/vnd.mermaid;q=0.9", "text/vnd.mermaid", `flowchart LR`},
+		{"image/png", "application/json", `"nodes":`},
+	} {
+		t.Run(x.accept, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1alpha1/graphs/neighbors", strings.NewReader(body))
+			if x.accept != "" {
+				req.Header.Set("Accept", x.accept)
+			}
+			a.Router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Contains(t, w.Header().Get("Content-Type"), x.contentType)
+			assert.Contains(t, w.Body.String(), x.want)
+		})
+	}
+}
+
 func TestMultiSession_QueryIsolation(t *testing.T) {
 	// Each session gets a separate engine with different store data.
 	// Verify that REST requests with different auth tokens get different results.
@@ -457,3 +783,311 @@ func TestTraverseStart_errors(t *testing.T) {
 	_, err = TraverseStart(e, api.Start{Queries: []string{"mock:a:x", "mock:b:y"}})
 	assert.ErrorContains(t, err, "expected class mock:a in query mock:b:y")
 }
+
+func TestAPIGoalsJob(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	goals := api.Goals{
+		Start: api.Start{Class: "mock:a", Objects: []json.RawMessage{[]byte(`"x"`)}},
+		Goals: []string{"mock:b"},
+	}
+	rr := a.do(t, "POST", "/api/v1alpha1/jobs/goals", goals)
+	require.Equal(t, http.StatusAccepted, rr.Code, rr.Body.String())
+	var job api.Job
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+	require.NotEmpty(t, job.Id)
+	url := "/api/v1alpha1/jobs/" + job.Id
+
+	require.Eventually(t, func() bool {
+		rr := a.do(t, "GET", url, nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		job = api.Job{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+		return job.Status != api.JobStatusRunning
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, api.JobStatusCompleted, job.Status, job.Error)
+	assert.NotNil(t, job.Finished)
+	assert.Equal(t, &api.Graph{
+		Nodes: []api.Node{
+			{Class: "mock:a", Count: ptr.To(1)},
+			{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}},
+		},
+		Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+	}, Normalize(job.Graph))
+
+	rr = a.do(t, "GET", "/api/v1alpha1/jobs", nil)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var jobs api.Jobs
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, job.Id, jobs[0].Id)
+	assert.Nil(t, jobs[0].Graph, "list does not include graphs")
+
+	rr = a.do(t, "DELETE", url, nil)
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	rr = a.do(t, "GET", url, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	rr = a.do(t, "DELETE", url, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+}
+
+func TestAPIGoalsJob_badRequest(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	rr := a.do(t, "POST", "/api/v1alpha1/jobs/goals", api.Goals{Goals: []string{"mock:b"}})
+	assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	assertDo(t, a, "GET", "/api/v1alpha1/jobs", nil, http.StatusOK, api.Jobs{})
+}
+
+func TestProgressGraph(t *testing.T) {
+	e := testEngine(t)
+	a, b := e.Domains()[0].Class("a"), e.Domains()[0].Class("b")
+	var line *graph.Line
+	e.Graph().EachLine(func(l *graph.Line) { line = l })
+	p := newProgressGraph()
+	assert.Equal(t, &api.Graph{Nodes: []api.Node{}}, p.Graph())
+	p.Listen(traverse.Event{Type: traverse.EventNode, Class: a, Count: 1})
+	p.Listen(traverse.Event{Type: traverse.EventLine, Class: b, Line: line, Query: mock.NewQuery(b, "y")})
+	assert.Equal(t, &api.Graph{Nodes: []api.Node{{Class: "mock:a", Count: ptr.To(1)}}}, p.Graph(), "no edge to empty node")
+	p.Listen(traverse.Event{Type: traverse.EventQuery, Class: b, Line: line, Query: mock.NewQuery(b, "y"), Count: 2})
+	p.Listen(traverse.Event{Type: traverse.EventNode, Class: b, Count: 2})
+	assert.Equal(t, &api.Graph{
+		Nodes: []api.Node{
+			{Class: "mock:a", Count: ptr.To(1)},
+			{Class: "mock:b", Count: ptr.To(2), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2)}}},
+		},
+		Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+	}, p.Graph())
+}
+
+func TestAPISearches(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	saved := api.SavedSearch{
+		Description: "a to b",
+		Search: api.Search{Goals: &api.Goals{
+			Start: api.Start{Queries: []string{"mock:a:x"}},
+			Goals: []string{"mock:b"},
+		}},
+		Options: &api.GraphOptions{Rules: ptr.To(true)},
+		Window:  "30m",
+	}
+	url := "/api/v1alpha1/searches/a-to-b"
+	rr := a.do(t, "PUT", url, saved)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var got api.SavedSearch
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, "a-to-b", got.Name)
+	assert.Equal(t, api.SavedSearchScopeSession, got.Scope)
+	assert.Equal(t, url+"/graph", got.Link)
+	require.NotNil(t, got.Updated)
+
+	assertDo(t, a, "GET", url, nil, http.StatusOK, got)
+	assertDo(t, a, "GET", "/api/v1alpha1/searches", nil, http.StatusOK, api.SavedSearches{got})
+	assertDo(t, a, "GET", got.Link, nil, http.StatusOK, api.Graph{
+		Nodes: []api.Node{
+			{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}}},
+			{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}},
+		},
+		Edges: []api.Edge{{
+			Start: "mock:a",
+			Goal:  "mock:b",
+			Rules: []api.Rule{{Name: "a-b", Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}}},
+		}},
+	})
+	// Request options override saved options.
+	assertDo(t, a, "GET", got.Link+"?rules=false", nil, http.StatusOK, api.Graph{
+		Nodes: []api.Node{
+			{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}}},
+			{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}},
+		},
+		Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+	})
+
+	assertDo(t, a, "DELETE", url, nil, http.StatusOK, got)
+	for _, method := range []string{"GET", "DELETE"} {
+		rr = a.do(t, method, url, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+	rr = a.do(t, "GET", url+"/graph", nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	assertDo(t, a, "GET", "/api/v1alpha1/searches", nil, http.StatusOK, api.SavedSearches{})
+}
+
+func TestAPISaveSearch_badRequest(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	search := api.Search{Neighbors: &api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1}}
+	for _, x := range []struct {
+		name  string
+		saved api.SavedSearch
+	}{
+		{"wrong name", api.SavedSearch{Name: "other", Search: search}},
+		{"no search", api.SavedSearch{}},
+		{"both searches", api.SavedSearch{Search: api.Search{Neighbors: search.Neighbors, Goals: &api.Goals{}}}},
+		{"bad query", api.SavedSearch{Search: api.Search{Neighbors: &api.Neighbors{Start: api.Start{Queries: []string{"mock:x"}}}}}},
+		{"bad window", api.SavedSearch{Search: search, Window: "forever"}},
+		{"bad scope", api.SavedSearch{Search: search, Scope: "everywhere"}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			rr := a.do(t, "PUT", "/api/v1alpha1/searches/x", x.saved)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		})
+	}
+	assertDo(t, a, "GET", "/api/v1alpha1/searches", nil, http.StatusOK, api.SavedSearches{})
+}
+
+// newTokenReviewAPI returns a function to make requests to an API with a session per bearer token.
+func newTokenReviewAPI(t *testing.T) func(token, method, url string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	factory := func() (*engine.Engine, error) { return testEngine(t), nil }
+	sessions := session.NewTokenReviewManager(test.FakeTokenReview(), time.Hour, factory)
+	r := ginEngine()
+	r.Use(session.Middleware(sessions))
+	_, err := New(sessions, r)
+	require.NoError(t, err)
+	return func(token, method, url string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var b io.Reader
+		if body != nil {
+			j, err := json.Marshal(body)
+			require.NoError(t, err)
+			b = bytes.NewReader(j)
+		}
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, b)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+}
+
+func TestAPISearches_scope(t *testing.T) {
+	do := newTokenReviewAPI(t)
+	search := func(scope api.SavedSearchScope, depth int) api.SavedSearch {
+		return api.SavedSearch{Scope: scope, Search: api.Search{Neighbors: &api.Neighbors{
+			Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: depth}}}
+	}
+	depth := func(token string) int {
+		t.Helper()
+		rr := do(token, "GET", "/api/v1alpha1/searches/s", nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var s api.SavedSearch
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &s))
+		return s.Search.Neighbors.Depth
+	}
+
+	require.Equal(t, http.StatusOK, do("A", "PUT", "/api/v1alpha1/searches/s", search(api.SavedSearchScopeGlobal, 1)).Code)
+	assert.Equal(t, 1, depth("A"))
+	assert.Equal(t, 1, depth("B"), "global search is visible in other sessions")
+	rr := do("B", "GET", "/api/v1alpha1/searches/s/graph", nil)
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	require.Equal(t, http.StatusOK, do("A", "PUT", "/api/v1alpha1/searches/s", search(api.SavedSearchScopeSession, 2)).Code)
+	assert.Equal(t, 2, depth("A"), "session search hides global search")
+	assert.Equal(t, 1, depth("B"), "session search is not visible in other sessions")
+
+	assert.Equal(t, http.StatusOK, do("A", "DELETE", "/api/v1alpha1/searches/s", nil).Code)
+	assert.Equal(t, 1, depth("A"), "deleting the session search shows the global search")
+}
+
+func TestAPISearches_owner(t *testing.T) {
+	do := newTokenReviewAPI(t)
+	global := api.SavedSearch{Scope: api.SavedSearchScopeGlobal, Owner: "B", Search: api.Search{Neighbors: &api.Neighbors{
+		Start: api.Start{Queries: []string{"mock:a:x"}}}}}
+
+	rr := do("A", "PUT", "/api/v1alpha1/searches/s", global)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var saved api.SavedSearch
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &saved))
+	assert.Equal(t, "A", saved.Owner, "owner is set by the server")
+
+	rr = do("B", "DELETE", "/api/v1alpha1/searches/s", nil)
+	assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+	rr = do("B", "PUT", "/api/v1alpha1/searches/s", global)
+	assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+	assert.Equal(t, http.StatusOK, do("B", "GET", "/api/v1alpha1/searches/s", nil).Code, "global search is still visible")
+
+	assert.Equal(t, http.StatusOK, do("A", "PUT", "/api/v1alpha1/searches/s", global).Code)
+	assert.Equal(t, http.StatusOK, do("A", "DELETE", "/api/v1alpha1/searches/s", nil).Code)
+	assert.Equal(t, http.StatusNotFound, do("B", "GET", "/api/v1alpha1/searches/s", nil).Code)
+}
+
+func TestDiffGraphs(t *testing.T) {
+	qc := func(q string, n int, statuses ...api.StatusCount) api.QueryCount {
+		return api.QueryCount{Query: q, Count: ptr.To(n), Statuses: statuses}
+	}
+	sc := func(s string, n int) api.StatusCount { return api.StatusCount{Status: s, Count: ptr.To(n)} }
+	before := &api.Graph{
+		Nodes: []api.Node{
+			{Class: "k8s:Pod.v1", Count: ptr.To(3), Queries: []api.QueryCount{
+				qc("k8s:Pod.v1:{a}", 2, sc("Running", 2)), qc("k8s:Pod.v1:{b}", 1, sc("Running", 1))}},
+			{Class: "alert:alert", Count: ptr.To(1), Queries: []api.QueryCount{qc("alert:alert:{}", 1)}},
+			{Class: "log:application", Count: ptr.To(5), Queries: []api.QueryCount{qc("log:application:{}", 5)}},
+		},
+		Edges: []api.Edge{{Start: "alert:alert", Goal: "k8s:Pod.v1"}, {Start: "k8s:Pod.v1", Goal: "log:application"}},
+	}
+	after := &api.Graph{
+		Nodes: []api.Node{
+			{Class: "alert:alert", Count: ptr.To(1), Queries: []api.QueryCount{qc("alert:alert:{}", 1)}},
+			{Class: "k8s:Pod.v1", Count: ptr.To(3), Queries: []api.QueryCount{
+				qc("k8s:Pod.v1:{a}", 2, sc("Running", 1), sc("Failed", 1)), qc("k8s:Pod.v1:{c}", 1, sc("Running", 1))}},
+			{Class: "k8s:Event.v1", Count: ptr.To(2)},
+		},
+		Edges: []api.Edge{{Start: "alert:alert", Goal: "k8s:Pod.v1"}, {Start: "k8s:Pod.v1", Goal: "k8s:Event.v1"}},
+	}
+	assert.Equal(t, &api.GraphDiff{
+		AddedNodes:   []api.Node{after.Nodes[2]},
+		RemovedNodes: []api.Node{before.Nodes[2]},
+		ChangedNodes: []api.NodeChange{{
+			Class: "k8s:Pod.v1", Before: 3, After: 3,
+			Queries: []api.QueryChange{
+				{Query: "k8s:Pod.v1:{b}", Before: 1, After: 0},
+				{Query: "k8s:Pod.v1:{c}", Before: 0, After: 1},
+			},
+			Statuses: []api.StatusChange{
+				{Status: "Failed", Before: 0, After: 1},
+				{Status: "Running", Before: 3, After: 2},
+			},
+		}},
+		AddedEdges:   []api.Edge{{Start: "k8s:Pod.v1", Goal: "k8s:Event.v1"}},
+		RemovedEdges: []api.Edge{{Start: "k8s:Pod.v1", Goal: "log:application"}},
+	}, DiffGraphs(before, after))
+	assert.Equal(t, &api.GraphDiff{}, DiffGraphs(after, after))
+}
+
+func TestAPIGraphDiff(t *testing.T) {
+	e := testEngine(t)
+	neighbors := func(depth int) *api.Search {
+		return &api.Search{Neighbors: &api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: depth}}
+	}
+	nodeA := api.Node{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}}}
+	nodeB := api.Node{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}}
+	want := api.GraphDiff{AddedNodes: []api.Node{nodeB}, AddedEdges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}}}
+	t.Run("searches", func(t *testing.T) {
+		assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/diff",
+			api.Diff{Before: api.DiffSource{Search: neighbors(0)}, After: api.DiffSource{Search: neighbors(1)}},
+			http.StatusOK, want)
+	})
+	t.Run("graph and search", func(t *testing.T) {
+		assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/diff",
+			api.Diff{Before: api.DiffSource{Graph: &api.Graph{Nodes: []api.Node{nodeA}}}, After: api.DiffSource{Search: neighbors(1)}},
+			http.StatusOK, want)
+	})
+}
+
+func TestAPIGraphDiff_badRequest(t *testing.T) {
+	a := newTestAPI(t, testEngine(t))
+	for _, x := range []struct {
+		name string
+		body any
+	}{
+		{"not json", `not json`},
+		{"empty", api.Diff{}},
+		{"both", api.Diff{
+			Before: api.DiffSource{Graph: &api.Graph{}, Search: &api.Search{}},
+			After:  api.DiffSource{Graph: &api.Graph{}}}},
+		{"bad search", api.Diff{Before: api.DiffSource{Graph: &api.Graph{}}, After: api.DiffSource{Search: &api.Search{}}}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			w := a.do(t, "POST", "/api/v1alpha1/graphs/diff", x.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		})
+	}
+}
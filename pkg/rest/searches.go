@@ -0,0 +1,225 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rest
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
+	"github.com/korrel8r/korrel8r/pkg/searches"
+	"github.com/korrel8r/korrel8r/pkg/session"
+)
+
+// ListSearches returns the session searches followed by the global searches.
+// (GET /searches)
+func (a *API) ListSearches(c *gin.Context) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	list := api.SavedSearches{} // return [] not null for empty
+	for _, store := range a.searchStores(s) {
+		l, err := store.List(c.Request.Context())
+		if !check(c, http.StatusInternalServerError, err) {
+			return
+		}
+		list = append(list, l...)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetSearch returns a saved search.
+// (GET /searches/{name})
+func (a *API) GetSearch(c *gin.Context, name string) {
+	saved, _ := a.findSearch(c, name)
+	okResponse(c, saved)
+}
+
+// SaveSearch validates and saves a search in the session or global store.
+// Global searches are owned by the session that first saved them, only the owner can replace them.
+// (PUT /searches/{name})
+func (a *API) SaveSearch(c *gin.Context, name string) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	saved := &api.SavedSearch{}
+	if !check(c, http.StatusBadRequest, c.BindJSON(saved)) {
+		return
+	}
+	if saved.Name == "" {
+		saved.Name = name
+	}
+	if saved.Name != name {
+		check(c, http.StatusBadRequest, fmt.Errorf("search name %q does not match path %q", saved.Name, name))
+		return
+	}
+	if !check(c, http.StatusBadRequest, SavedSearchOK(s.Engine, saved)) {
+		return
+	}
+	store := s.Searches
+	saved.Owner = ""
+	if saved.Scope == api.SavedSearchScopeGlobal {
+		store = a.Searches
+		saved.Owner = s.ID
+		a.searchesMu.Lock()
+		defer a.searchesMu.Unlock()
+		old, err := store.Get(c.Request.Context(), name)
+		if err != nil && !errors.Is(err, searches.ErrNotFound) {
+			check(c, http.StatusInternalServerError, err)
+			return
+		}
+		if old != nil && !check(c, http.StatusForbidden, checkOwner(s, old), "saved search %v", name) {
+			return
+		}
+	}
+	saved.Updated = new(time.Now())
+	saved.Link = api.BasePath + "/searches/" + url.PathEscape(name) + "/graph"
+	if !check(c, http.StatusInternalServerError, store.Put(c.Request.Context(), saved)) {
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteSearch deletes the session search with name if there is one, otherwise the global search.
+// Only the owner can delete a global search.
+// (DELETE /searches/{name})
+func (a *API) DeleteSearch(c *gin.Context, name string) {
+	a.searchesMu.Lock()
+	defer a.searchesMu.Unlock()
+	saved, store := a.findSearch(c, name)
+	if c.IsAborted() {
+		return
+	}
+	if store == a.Searches {
+		s, err := a.session(c)
+		if !check(c, http.StatusInternalServerError, err) || !check(c, http.StatusForbidden, checkOwner(s, saved), "saved search %v", name) {
+			return
+		}
+	}
+	err := store.Delete(c.Request.Context(), name)
+	if !check(c, http.StatusNotFound, err, "saved search %v", name) {
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+// RunSearch runs a saved search with a fresh time window.
+// (GET /searches/{name}/graph)
+func (a *API) RunSearch(c *gin.Context, name string, params RunSearchParams) {
+	saved, _ := a.findSearch(c, name)
+	if c.IsAborted() {
+		return
+	}
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	search, err := searches.Fresh(saved, time.Now())
+	if !check(c, http.StatusBadRequest, err, "saved search %v", name) {
+		return
+	}
+	if !check(c, http.StatusBadRequest, SearchOK(s.Engine, &search), "saved search %v", name) {
+		return
+	}
+	opts := graphOptions(saved.Options, params.Options)
+	g, err := SearchGraph(c.Request.Context(), s.Engine, search, opts)
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	okGraph(c, NewGraph(g, opts))
+}
+
+// errNotOwner is returned when a session tries to modify a global search saved by another session.
+var errNotOwner = errors.New("global search belongs to another user")
+
+// checkOwner returns errNotOwner if the global search saved was not saved by session s.
+func checkOwner(s *session.Session, saved *api.SavedSearch) error {
+	if saved.Owner != s.ID {
+		return errNotOwner
+	}
+	return nil
+}
+
+// searchStores returns the stores for saved searches, in lookup order.
+func (a *API) searchStores(s *session.Session) []searches.Store {
+	return []searches.Store{s.Searches, a.Searches}
+}
+
+// findSearch finds a saved search and its store, aborts c if not found.
+func (a *API) findSearch(c *gin.Context, name string) (*api.SavedSearch, searches.Store) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return nil, nil
+	}
+	for _, store := range a.searchStores(s) {
+		saved, err := store.Get(c.Request.Context(), name)
+		if errors.Is(err, searches.ErrNotFound) {
+			continue
+		}
+		if !check(c, http.StatusInternalServerError, err) {
+			return nil, nil
+		}
+		return saved, store
+	}
+	check(c, http.StatusNotFound, searches.ErrNotFound, "%v", name)
+	return nil, nil
+}
+
+// SavedSearchOK validates and normalizes a search to be saved.
+func SavedSearchOK(e *engine.Engine, s *api.SavedSearch) error {
+	switch s.Scope {
+	case "":
+		s.Scope = api.SavedSearchScopeSession
+	case api.SavedSearchScopeSession, api.SavedSearchScopeGlobal:
+	default:
+		return fmt.Errorf("invalid scope: %q", s.Scope)
+	}
+	if s.Name == "" {
+		return errors.New("saved search has no name")
+	}
+	if _, err := searches.Window(s); err != nil {
+		return err
+	}
+	return SearchOK(e, &s.Search)
+}
+
+// SearchGraph runs a validated goals or neighbors search, see [SearchOK].
+func SearchGraph(ctx context.Context, e *engine.Engine, s api.Search, opts *api.GraphOptions) (*graph.Graph, error) {
+	if s.Goals != nil {
+		start, goals, err := goalsStart(e, *s.Goals, opts)
+		if err != nil {
+			return nil, err
+		}
+		return traverse.Goals(ctx, e, start, goals)
+	}
+	start, err := TraverseStart(e, s.Neighbors.Start)
+	if err != nil {
+		return nil, err
+	}
+	start.Explain = ptr.Deref(ptr.Deref(opts).Explain)
+	return traverse.Neighbors(ctx, e, start, s.Neighbors.Depth)
+}
+
+// graphOptions returns saved options with any options that are set in override.
+func graphOptions(saved, override *api.GraphOptions) *api.GraphOptions {
+	o := ptr.Deref(saved)
+	if override != nil {
+		o.Errors = cmp.Or(override.Errors, o.Errors)
+		o.Explain = cmp.Or(override.Explain, o.Explain)
+		o.Results = cmp.Or(override.Results, o.Results)
+		o.Rules = cmp.Or(override.Rules, o.Rules)
+	}
+	return &o
+}
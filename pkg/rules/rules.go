@@ -16,25 +16,44 @@ import (
 
 var bufPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
 
-var _ korrel8r.Rule = &templateRule{}
+var (
+	_ korrel8r.Rule        = &templateRule{}
+	_ korrel8r.Prioritizer = &templateRule{}
+	_ korrel8r.Templater   = &templateRule{}
+)
 
 type templateRule struct {
 	query       *template.Template
 	start, goal []korrel8r.Class
 	domains     *korrel8r.Domains
+	priority    int
 }
 
 // NewTemplateRule returns a korrel8r.Rule that uses a Go template to transform objects to queries.
 // The domains registry is used to parse generated query strings.
 func NewTemplateRule(start, goal []korrel8r.Class, query *template.Template, domains *korrel8r.Domains) korrel8r.Rule {
-	return &templateRule{start: start, goal: goal, query: query, domains: domains}
+	return NewPriorityTemplateRule(start, goal, query, domains, 0)
+}
+
+// NewPriorityTemplateRule is like [NewTemplateRule] with a [korrel8r.Prioritizer] priority.
+func NewPriorityTemplateRule(start, goal []korrel8r.Class, query *template.Template, domains *korrel8r.Domains, priority int) korrel8r.Rule {
+	return &templateRule{start: start, goal: goal, query: query, domains: domains, priority: priority}
 }
 
 func (r *templateRule) Name() string            { return r.query.Name() }
+func (r *templateRule) Priority() int           { return r.priority }
 func (r *templateRule) String() string          { return r.Name() }
 func (r *templateRule) Start() []korrel8r.Class { return r.start }
 func (r *templateRule) Goal() []korrel8r.Class  { return r.goal }
 
+// Template returns the template text, reconstructed from the parsed template.
+func (r *templateRule) Template() string {
+	if r.query.Tree == nil {
+		return ""
+	}
+	return r.query.Root.String()
+}
+
 // Apply the rule by applying the template.
 //
 // Returns (nil, err) if template execution returns a non-nil error.
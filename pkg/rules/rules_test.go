@@ -74,3 +74,11 @@ func TestTemplateRule_Apply_TemplateError(t *testing.T) {
 	_, err := rule.Apply("a string")
 	assert.Error(t, err)
 }
+
+func TestTemplateRule_Template(t *testing.T) {
+	d := mock.NewDomain("test", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	tmpl := template.Must(template.New("test-rule").Parse(`test:b:got-{{.}}`))
+	rule := NewTemplateRule([]korrel8r.Class{a}, []korrel8r.Class{b}, tmpl, testDomains(d))
+	assert.Equal(t, `test:b:got-{{.}}`, korrel8r.RuleTemplate(rule))
+}
@@ -0,0 +1,265 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package searches stores named, saved correlation searches.
+//
+// A [Store] is a pluggable backend for saved searches.
+// [NewMemoryStore] keeps searches in memory, [NewFileStore] keeps them in a JSON file.
+package searches
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/api"
+)
+
+var ErrNotFound = errors.New("saved search not found")
+
+// Store is a backend for saved searches, keyed by name.
+// Values are copied in and out of the store, callers can modify them freely.
+type Store interface {
+	// List returns all searches sorted by name.
+	List(ctx context.Context) ([]api.SavedSearch, error)
+	// Get returns the search with name, or ErrNotFound.
+	Get(ctx context.Context, name string) (*api.SavedSearch, error)
+	// Put creates or replaces the search with the same name.
+	Put(ctx context.Context, s *api.SavedSearch) error
+	// Delete removes the search with name, or returns ErrNotFound.
+	Delete(ctx context.Context, name string) error
+}
+
+// Window returns the duration of the time window for s, 0 means no window.
+// See [api.SavedSearch] Window.
+func Window(s *api.SavedSearch) (time.Duration, error) {
+	if s.Window != "" {
+		d, err := time.ParseDuration(s.Window)
+		if err == nil && d <= 0 {
+			err = errors.New("must be positive")
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q: %w", s.Window, err)
+		}
+		return d, nil
+	}
+	if c := start(&s.Search).Constraint; c != nil && c.Start != nil && c.End != nil {
+		return c.End.Sub(*c.Start), nil
+	}
+	return 0, nil
+}
+
+// Fresh returns a copy of the search in s with a time window that ends at now.
+// The start and end of the saved constraint are replaced, if there is no window they are removed.
+func Fresh(s *api.SavedSearch, now time.Time) (api.Search, error) {
+	window, err := Window(s)
+	if err != nil {
+		return api.Search{}, err
+	}
+	search := s.Search
+	if search.Goals != nil {
+		search.Goals = new(*search.Goals)
+	}
+	if search.Neighbors != nil {
+		search.Neighbors = new(*search.Neighbors)
+	}
+	st := start(&search)
+	c := api.Constraint{}
+	if st.Constraint != nil {
+		c = *st.Constraint
+	}
+	c.Start, c.End = nil, nil
+	if window > 0 {
+		c.Start, c.End = new(now.Add(-window)), new(now)
+	}
+	st.Constraint = &c
+	return search, nil
+}
+
+// start returns the start of the search, or an empty start if the search has none.
+func start(s *api.Search) *api.Start {
+	switch {
+	case s.Goals != nil:
+		return &s.Goals.Start
+	case s.Neighbors != nil:
+		return &s.Neighbors.Start
+	default:
+		return &api.Start{}
+	}
+}
+
+// memoryStore keeps searches as serialized JSON, so stored values are not shared with callers.
+type memoryStore struct {
+	mu       sync.Mutex
+	searches map[string][]byte
+}
+
+// NewMemoryStore returns a Store that keeps searches in memory.
+func NewMemoryStore() Store { return &memoryStore{searches: map[string][]byte{}} }
+
+func (m *memoryStore) List(context.Context) ([]api.SavedSearch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := []api.SavedSearch{}
+	for _, name := range slices.Sorted(maps.Keys(m.searches)) {
+		var s api.SavedSearch
+		if err := json.Unmarshal(m.searches[name], &s); err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func (m *memoryStore) Get(_ context.Context, name string) (*api.SavedSearch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.searches[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var s api.SavedSearch
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (m *memoryStore) Put(_ context.Context, s *api.SavedSearch) error {
+	if s.Name == "" {
+		return errors.New("saved search has no name")
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.searches[s.Name] = b
+	return nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.searches[name]; !ok {
+		return ErrNotFound
+	}
+	delete(m.searches, name)
+	return nil
+}
+
+// fileStore keeps searches in a JSON file containing a list of searches sorted by name.
+// The file is read on every operation, and replaced atomically on every change.
+type fileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore returns a Store that keeps searches in a JSON file.
+// The file is created on the first change if it does not exist.
+func NewFileStore(path string) (Store, error) {
+	s := &fileStore{path: path}
+	if _, err := s.read(); err != nil { // Fail early on a bad file.
+		return nil, err
+	}
+	return s, nil
+}
+
+func (f *fileStore) read() (map[string]api.SavedSearch, error) {
+	searches := map[string]api.SavedSearch{}
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return searches, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []api.SavedSearch
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &list); err != nil {
+			return nil, fmt.Errorf("saved searches %v: %w", f.path, err)
+		}
+	}
+	for _, s := range list {
+		searches[s.Name] = s
+	}
+	return searches, nil
+}
+
+func (f *fileStore) write(searches map[string]api.SavedSearch) error {
+	list := slices.SortedFunc(maps.Values(searches), func(a, b api.SavedSearch) int { return cmp.Compare(a.Name, b.Name) })
+	b, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	_, err = tmp.Write(b)
+	if err := errors.Join(err, tmp.Close()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *fileStore) List(context.Context) ([]api.SavedSearch, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	searches, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return slices.SortedFunc(maps.Values(searches), func(a, b api.SavedSearch) int { return cmp.Compare(a.Name, b.Name) }), nil
+}
+
+func (f *fileStore) Get(_ context.Context, name string) (*api.SavedSearch, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	searches, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	s, ok := searches[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &s, nil
+}
+
+func (f *fileStore) Put(_ context.Context, s *api.SavedSearch) error {
+	if s.Name == "" {
+		return errors.New("saved search has no name")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	searches, err := f.read()
+	if err != nil {
+		return err
+	}
+	searches[s.Name] = *s
+	return f.write(searches)
+}
+
+func (f *fileStore) Delete(_ context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	searches, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := searches[name]; !ok {
+		return ErrNotFound
+	}
+	delete(searches, name)
+	return f.write(searches)
+}
@@ -0,0 +1,118 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package searches
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func neighbors(name string, depth int) *api.SavedSearch {
+	return &api.SavedSearch{Name: name, Search: api.Search{Neighbors: &api.Neighbors{
+		Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: depth}}}
+}
+
+func testStore(t *testing.T, s Store) {
+	t.Helper()
+	ctx := t.Context()
+	list, err := s.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	b, a := neighbors("b", 1), neighbors("a", 2)
+	require.NoError(t, s.Put(ctx, b))
+	require.NoError(t, s.Put(ctx, a))
+	assert.Error(t, s.Put(ctx, neighbors("", 1)))
+	list, err = s.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []api.SavedSearch{*a, *b}, list)
+
+	got, err := s.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, a, got)
+	got.Search.Neighbors.Depth = 99 // Does not modify the stored value.
+	got, err = s.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Search.Neighbors.Depth)
+
+	require.NoError(t, s.Put(ctx, neighbors("a", 3)))
+	got, err = s.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.Search.Neighbors.Depth)
+
+	require.NoError(t, s.Delete(ctx, "a"))
+	_, err = s.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, s.Delete(ctx, "a"), ErrNotFound)
+	list, err = s.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []api.SavedSearch{*b}, list)
+}
+
+func TestMemoryStore(t *testing.T) { testStore(t, NewMemoryStore()) }
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "searches.json")
+	s, err := NewFileStore(path)
+	require.NoError(t, err)
+	testStore(t, s)
+
+	// A new store on the same file sees the saved searches.
+	s2, err := NewFileStore(path)
+	require.NoError(t, err)
+	got, err := s2.Get(t.Context(), "b")
+	require.NoError(t, err)
+	assert.Equal(t, neighbors("b", 1), got)
+}
+
+func TestFileStore_bad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "searches.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err := NewFileStore(path)
+	assert.Error(t, err)
+}
+
+func TestFresh(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-24 * time.Hour)
+	for _, x := range []struct {
+		name       string
+		window     string
+		constraint *api.Constraint
+		want       *api.Constraint
+	}{
+		{"window", "30m", nil, &api.Constraint{Start: ptr.To(now.Add(-30 * time.Minute)), End: ptr.To(now)}},
+		{"saved window", "", &api.Constraint{Start: ptr.To(old.Add(-2 * time.Hour)), End: ptr.To(old), Limit: ptr.To(5)},
+			&api.Constraint{Start: ptr.To(now.Add(-2 * time.Hour)), End: ptr.To(now), Limit: ptr.To(5)}},
+		{"window replaces saved window", "1h", &api.Constraint{Start: ptr.To(old.Add(-2 * time.Hour)), End: ptr.To(old)},
+			&api.Constraint{Start: ptr.To(now.Add(-time.Hour)), End: ptr.To(now)}},
+		{"no window", "", &api.Constraint{End: ptr.To(old), Limit: ptr.To(5)}, &api.Constraint{Limit: ptr.To(5)}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			saved := neighbors("x", 1)
+			saved.Window = x.window
+			saved.Search.Neighbors.Start.Constraint = x.constraint
+			before := *saved.Search.Neighbors
+			search, err := Fresh(saved, now)
+			require.NoError(t, err)
+			assert.Equal(t, x.want, search.Neighbors.Start.Constraint)
+			assert.Equal(t, before, *saved.Search.Neighbors, "saved search is not modified")
+		})
+	}
+}
+
+func TestWindow_invalid(t *testing.T) {
+	for _, w := range []string{"forever", "-1h", "0s"} {
+		saved := neighbors("x", 1)
+		saved.Window = w
+		_, err := Window(saved)
+		assert.Error(t, err, w)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package session
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/api"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrTooManyJobs = errors.New("too many jobs in this session")
+)
+
+// MaxJobs is the maximum number of jobs kept by a session.
+// When the limit is reached the oldest finished job is removed to make room for a new job.
+// If all jobs are running, new jobs are refused with ErrTooManyJobs.
+const MaxJobs = 100
+
+// DefaultJobTimeout is the job timeout if the jobTimeout tuning setting is not set.
+const DefaultJobTimeout = 10 * time.Minute
+
+// Job is an asynchronous operation that belongs to a session.
+type Job struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+	state  api.Job
+	graph  func() *api.Graph
+}
+
+// ID of the job, unique within the session.
+func (j *Job) ID() string { return j.state.Id }
+
+// State returns a copy of the job state, without the graph.
+func (j *Job) State() api.Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state
+}
+
+// Graph returns the current graph for the job, or nil if there is none.
+func (j *Job) Graph() *api.Graph {
+	j.mu.Lock()
+	graph := j.graph
+	j.mu.Unlock()
+	if graph == nil {
+		return nil
+	}
+	return graph()
+}
+
+// SetGraph sets a function to get the current graph for the job.
+// A running job can set a function that returns a partial graph, graph must be safe to call concurrently.
+func (j *Job) SetGraph(graph func() *api.Graph) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.graph = graph
+}
+
+// Cancel the job, does not wait for it to finish. See [Job.Done].
+func (j *Job) Cancel() { j.cancel() }
+
+// Done is closed when the job has finished.
+func (j *Job) Done() <-chan struct{} { return j.done }
+
+// finish the job, ctx is canceled if the job was canceled, runCtx is also canceled if the job timed out.
+func (j *Job) finish(ctx, runCtx context.Context, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.state.Finished = new(time.Now())
+	switch {
+	case ctx.Err() != nil:
+		j.state.Status = api.JobStatusCanceled
+	case runCtx.Err() != nil:
+		j.state.Status = api.JobStatusFailed
+		j.state.Error = context.Cause(runCtx).Error()
+	case err != nil:
+		j.state.Status = api.JobStatusFailed
+		j.state.Error = err.Error()
+	default:
+		j.state.Status = api.JobStatusCompleted
+	}
+	close(j.done)
+}
+
+func (j *Job) running() bool { return j.State().Status == api.JobStatusRunning }
+
+// jobs holds the asynchronous jobs for a session.
+type jobs struct {
+	ctx     context.Context // Canceled when the session is closed.
+	cancel  context.CancelFunc
+	timeout time.Duration // Maximum run time for a job.
+	mu      sync.Mutex
+	jobs    map[string]*Job
+}
+
+func newJobs(timeout time.Duration) *jobs {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jobs{ctx: ctx, cancel: cancel, timeout: timeout, jobs: map[string]*Job{}}
+}
+
+// StartJob calls run in a new goroutine, and returns a Job to track it.
+//
+// The context passed to run has the values of ctx, but not its deadline or cancellation:
+// the job runs until run returns, the job is canceled, the session is closed, or the job times out.
+// If run returns an error or the job times out the job fails, unless it was canceled.
+func (s *jobs) StartJob(ctx context.Context, run func(context.Context, *Job) error) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.jobs) >= MaxJobs {
+		var oldest *Job
+		for _, j := range s.jobs {
+			if !j.running() && (oldest == nil || j.State().Created.Before(oldest.State().Created)) {
+				oldest = j
+			}
+		}
+		if oldest == nil {
+			return nil, ErrTooManyJobs
+		}
+		delete(s.jobs, oldest.ID())
+	}
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(s.ctx, cancel) // Cancel when the session is closed.
+	j := &Job{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		state:  api.Job{Id: rand.Text(), Status: api.JobStatusRunning, Created: time.Now()},
+	}
+	s.jobs[j.ID()] = j
+	runCtx, stopTimeout := context.WithTimeoutCause(ctx, s.timeout, fmt.Errorf("job timed out after %v", s.timeout))
+	go func() {
+		defer stop()
+		defer cancel()
+		defer stopTimeout()
+		err := run(runCtx, j)
+		j.finish(ctx, runCtx, err)
+		log.V(2).Info("Job finished", "job", j.ID(), "status", j.State().Status, "error", err)
+	}()
+	return j, nil
+}
+
+// Job returns the job with id, or ErrJobNotFound.
+func (s *jobs) Job(id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j := s.jobs[id]; j != nil {
+		return j, nil
+	}
+	return nil, ErrJobNotFound
+}
+
+// Jobs returns all the jobs in the session in creation order.
+func (s *jobs) Jobs() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.SortedFunc(maps.Values(s.jobs), func(a, b *Job) int {
+		return cmp.Or(a.State().Created.Compare(b.State().Created), cmp.Compare(a.ID(), b.ID()))
+	})
+}
+
+// DeleteJob cancels the job with id if it is running, and removes it from the session.
+// It does not wait for the job to finish. See [Job.Done].
+func (s *jobs) DeleteJob(id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j := s.jobs[id]
+	if j == nil {
+		return nil, ErrJobNotFound
+	}
+	delete(s.jobs, id)
+	j.Cancel()
+	return j, nil
+}
+
+// closeJobs cancels all jobs, no new jobs can be started.
+func (s *jobs) closeJobs() { s.cancel() }
@@ -0,0 +1,122 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitJob(t *testing.T, j *Job) api.Job {
+	t.Helper()
+	select {
+	case <-j.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timeout waiting for job %v", j.ID())
+	}
+	return j.State()
+}
+
+// blockJob runs until canceled.
+func blockJob(ctx context.Context, _ *Job) error { <-ctx.Done(); return ctx.Err() }
+
+func TestJobs_StartJob(t *testing.T) {
+	s := newJobs(DefaultJobTimeout)
+	graph := &api.Graph{Nodes: []api.Node{{Class: "a:b"}}}
+	j, err := s.StartJob(context.Background(), func(ctx context.Context, j *Job) error {
+		j.SetGraph(func() *api.Graph { return graph })
+		return nil
+	})
+	require.NoError(t, err)
+	state := waitJob(t, j)
+	assert.Equal(t, api.JobStatusCompleted, state.Status)
+	assert.NotNil(t, state.Finished)
+	assert.Same(t, graph, j.Graph())
+	got, err := s.Job(j.ID())
+	require.NoError(t, err)
+	assert.Same(t, j, got)
+	assert.Equal(t, []*Job{j}, s.Jobs())
+}
+
+func TestJobs_failed(t *testing.T) {
+	s := newJobs(DefaultJobTimeout)
+	j, err := s.StartJob(context.Background(), func(context.Context, *Job) error { return errors.New("oops") })
+	require.NoError(t, err)
+	state := waitJob(t, j)
+	assert.Equal(t, api.JobStatusFailed, state.Status)
+	assert.Equal(t, "oops", state.Error)
+}
+
+func TestJobs_timeout(t *testing.T) {
+	s := newJobs(10 * time.Millisecond)
+	j, err := s.StartJob(context.Background(), blockJob)
+	require.NoError(t, err)
+	state := waitJob(t, j)
+	assert.Equal(t, api.JobStatusFailed, state.Status)
+	assert.Equal(t, "job timed out after 10ms", state.Error)
+}
+
+func TestJobs_DeleteJob(t *testing.T) {
+	s := newJobs(DefaultJobTimeout)
+	// Cancellation of the starting context does not cancel the job.
+	ctx, cancel := context.WithCancel(context.Background())
+	j, err := s.StartJob(ctx, blockJob)
+	require.NoError(t, err)
+	cancel()
+	assert.Equal(t, api.JobStatusRunning, j.State().Status)
+
+	deleted, err := s.DeleteJob(j.ID())
+	require.NoError(t, err)
+	assert.Same(t, j, deleted)
+	assert.Equal(t, api.JobStatusCanceled, waitJob(t, j).Status)
+	_, err = s.Job(j.ID())
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	_, err = s.DeleteJob(j.ID())
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestJobs_closeJobs(t *testing.T) {
+	s := newJobs(DefaultJobTimeout)
+	j, err := s.StartJob(context.Background(), blockJob)
+	require.NoError(t, err)
+	s.closeJobs()
+	assert.Equal(t, api.JobStatusCanceled, waitJob(t, j).Status)
+	_, err = s.StartJob(context.Background(), blockJob)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestJobs_MaxJobs(t *testing.T) {
+	s := newJobs(DefaultJobTimeout)
+	defer s.closeJobs()
+	first, err := s.StartJob(context.Background(), func(context.Context, *Job) error { return nil })
+	require.NoError(t, err)
+	waitJob(t, first)
+	for range MaxJobs - 1 {
+		_, err := s.StartJob(context.Background(), blockJob)
+		require.NoError(t, err)
+	}
+	// Oldest finished job is removed to make room.
+	_, err = s.StartJob(context.Background(), blockJob)
+	require.NoError(t, err)
+	_, err = s.Job(first.ID())
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	// All jobs are running.
+	_, err = s.StartJob(context.Background(), blockJob)
+	assert.ErrorIs(t, err, ErrTooManyJobs)
+}
+
+func TestSession_expiredJobs(t *testing.T) {
+	m := testMulti(time.Millisecond)
+	s := getSession(t, m, "key-a")
+	j, err := s.StartJob(context.Background(), blockJob)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	getSession(t, m, "key-b") // Triggers cleanup of expired sessions.
+	assert.Equal(t, api.JobStatusCanceled, waitJob(t, j).Status)
+}
@@ -8,6 +8,7 @@
 package session
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -19,6 +20,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/searches"
 	"github.com/korrel8r/korrel8r/pkg/tokenreview"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 )
@@ -35,12 +37,17 @@ func (e *AuthError) Unwrap() error { return e.Err }
 type Session struct {
 	ID       string // Session ID - a username or hashed authorization token.
 	Engine   *engine.Engine
-	lastUsed atomic.Int64 // UnixNano timestamp for expiration, atomic for lock-free access.
+	Searches searches.Store // Saved searches for this session only.
+	lastUsed atomic.Int64   // UnixNano timestamp for expiration, atomic for lock-free access.
 	*consoleEvents
+	*jobs
 }
 
 func (s *Session) String() string { return s.ID }
 
+// Close the session, cancels all running jobs.
+func (s *Session) Close() { s.closeJobs() }
+
 // FromContext returns the session from ctx. See [WithSession].
 func FromContext(ctx context.Context) *Session {
 	s, _ := ctx.Value(sessionKey{}).(*Session)
@@ -67,7 +74,9 @@ func newSession(e *engine.Engine, id string) *Session {
 	return &Session{
 		ID:            id,
 		Engine:        e,
+		Searches:      searches.NewMemoryStore(),
 		consoleEvents: newConsoleEvents(),
+		jobs:          newJobs(cmp.Or(time.Duration(e.Tuning.JobTimeout), DefaultJobTimeout)),
 	}
 }
 
@@ -170,6 +179,7 @@ func (m *poolManager) maybeCleanup(now int64) {
 		if ent.session != nil && now-ent.session.lastUsed.Load() > int64(m.timeout) {
 			log.V(2).Info("Session expired", "session", ent.session.ID)
 			m.sessions.Delete(key)
+			ent.session.Close()
 		}
 		return true
 	})
@@ -14,6 +14,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -47,10 +48,16 @@ func TestListTools(t *testing.T) {
 			mcpserver.ShowInConsole,
 			mcpserver.CreateNeighborsGraph,
 			mcpserver.CreateGoalsGraph,
+			mcpserver.DiffGraphs,
 			mcpserver.GetObjects,
 			mcpserver.Help,
 			mcpserver.ListDomainClasses,
-			mcpserver.ListDomains})
+			mcpserver.ListDomains,
+			mcpserver.ListSavedSearches,
+			mcpserver.GetSavedSearch,
+			mcpserver.SaveSearch,
+			mcpserver.DeleteSavedSearch,
+			mcpserver.RunSavedSearch})
 }
 
 func TestListDomains(t *testing.T) {
@@ -110,6 +117,243 @@ func TestCreateGoalsGraph(t *testing.T) {
 	require.Equal(t, want, got)
 }
 
+func TestSavedSearches(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t, newEngine(t))
+	call := func(name string, args any) *mcp.CallToolResult {
+		t.Helper()
+		r, err := client.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		require.NoError(t, err)
+		return r
+	}
+	search := func(r *mcp.CallToolResult) (s api.SavedSearch) {
+		t.Helper()
+		require.False(t, r.IsError, r)
+		b, err := json.Marshal(r.StructuredContent)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &s))
+		return s
+	}
+
+	saved := search(call(mcpserver.SaveSearch, mcpserver.SaveSearchParams{
+		Name:   "a-to-b",
+		Window: "1h",
+		Search: api.Search{Goals: &api.Goals{Goals: []string{"mock:b"}, Start: api.Start{Queries: []string{"mock:a:x"}}}},
+	}))
+	assert.Equal(t, "a-to-b", saved.Name)
+	assert.Equal(t, api.SavedSearchScopeSession, saved.Scope)
+	assert.Equal(t, saved, search(call(mcpserver.GetSavedSearch, mcpserver.SearchNameParams{Name: "a-to-b"})))
+
+	r := call(mcpserver.ListSavedSearches, struct{}{})
+	require.False(t, r.IsError, r)
+	assert.Equal(t, map[string]any{"searches": []any{map[string]any{
+		"name":    "a-to-b",
+		"scope":   "session",
+		"window":  "1h",
+		"link":    saved.Link,
+		"updated": saved.Updated.Format(time.RFC3339Nano),
+		"search": map[string]any{"goals": map[string]any{
+			"goals": []any{"mock:b"},
+			"start": map[string]any{"queries": []any{"mock:a:x"}},
+		}},
+	}}}, r.StructuredContent)
+
+	want := `{"edges":[{"goal":"mock:b","start":"mock:a"}],"nodes":[{"class":"mock:a","count":1,"queries":[{"count":1,"query":"mock:a:x"}]},{"class":"mock:b","count":1,"queries":[{"count":1,"query":"mock:b:y"}]}]}`
+	assert.Equal(t, want, graphContent(t, call(mcpserver.RunSavedSearch, mcpserver.SearchNameParams{Name: "a-to-b"})))
+
+	assert.Equal(t, saved, search(call(mcpserver.DeleteSavedSearch, mcpserver.SearchNameParams{Name: "a-to-b"})))
+	assert.True(t, call(mcpserver.RunSavedSearch, mcpserver.SearchNameParams{Name: "a-to-b"}).IsError)
+}
+
+func TestDiffGraphs(t *testing.T) {
+	client := newClient(t, newEngine(t))
+	neighbors := func(depth int) *api.Search {
+		return &api.Search{Neighbors: &api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: depth}}
+	}
+	r, err := client.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      mcpserver.DiffGraphs,
+		Arguments: mcpserver.DiffParams{Before: api.DiffSource{Search: neighbors(0)}, After: api.DiffSource{Search: neighbors(1)}},
+	})
+	require.NoError(t, err)
+	require.False(t, r.IsError, r)
+	assert.Equal(t, map[string]any{
+		"addedNodes": []any{map[string]any{"class": "mock:b", "count": 1.0, "queries": []any{map[string]any{"count": 1.0, "query": "mock:b:y"}}}},
+		"addedEdges": []any{map[string]any{"start": "mock:a", "goal": "mock:b"}},
+	}, r.StructuredContent)
+}
+
+func TestResources(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t, newEngine(t))
+	list, err := client.ListResources(ctx, nil)
+	require.NoError(t, err)
+	var uris []string
+	for _, r := range list.Resources {
+		uris = append(uris, r.URI)
+	}
+	assert.ElementsMatch(t, []string{mcpserver.DomainsURI, mcpserver.RulesURI, mcpserver.GraphsURI}, uris)
+	templates, err := client.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+	uris = nil
+	for _, r := range templates.ResourceTemplates {
+		uris = append(uris, r.URITemplate)
+	}
+	assert.ElementsMatch(t, []string{mcpserver.DomainsURITemplate, mcpserver.GraphsURITemplate}, uris)
+
+	read := func(uri string) string {
+		t.Helper()
+		r, err := client.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+		require.NoError(t, err)
+		require.Len(t, r.Contents, 1)
+		return r.Contents[0].Text
+	}
+	assert.Contains(t, read(mcpserver.DomainsURI+"/mock"), "Mock domain.")
+	assert.JSONEq(t, `[{"name":"a-b","start":["mock:a"],"goal":["mock:b"]}]`, read(mcpserver.RulesURI))
+	assert.JSONEq(t, `[]`, read(mcpserver.GraphsURI))
+
+	r, err := client.CallTool(ctx, &mcp.CallToolParams{
+		Name:      mcpserver.CreateNeighborsGraph,
+		Arguments: mcpserver.NeighborParams{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1},
+	})
+	require.NoError(t, err)
+	require.False(t, r.IsError, r)
+	var graphs []mcpserver.RecentGraph
+	require.NoError(t, json.Unmarshal([]byte(read(mcpserver.GraphsURI)), &graphs))
+	require.Len(t, graphs, 1)
+	assert.Equal(t, mcpserver.CreateNeighborsGraph, graphs[0].Tool)
+	assert.Equal(t, 2, graphs[0].Nodes)
+	assert.Equal(t, graphContent(t, r), read(graphs[0].URI))
+
+	_, err = client.ReadResource(ctx, &mcp.ReadResourceParams{URI: mcpserver.GraphsURI + "/nonesuch"})
+	assert.Error(t, err)
+}
+
+func TestResources_sessions(t *testing.T) {
+	ctx := context.Background()
+	client := mcpserver.NewClientForHandler(newRouter(t, newEngine(t)))
+	srv := httptest.NewServer(mcpserver.NewServer(client, "test", logr.Discard()).HTTPHandler())
+	t.Cleanup(srv.Close)
+	connect := func() *mcp.ClientSession {
+		t.Helper()
+		transport := &mcp.StreamableClientTransport{Endpoint: srv.URL, DisableStandaloneSSE: true}
+		cs, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(ctx, transport, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = cs.Close() })
+		return cs
+	}
+	graphs := func(cs *mcp.ClientSession) []mcpserver.RecentGraph {
+		t.Helper()
+		r, err := cs.ReadResource(ctx, &mcp.ReadResourceParams{URI: mcpserver.GraphsURI})
+		require.NoError(t, err)
+		var graphs []mcpserver.RecentGraph
+		require.NoError(t, json.Unmarshal([]byte(r.Contents[0].Text), &graphs))
+		return graphs
+	}
+	search := func(cs *mcp.ClientSession) {
+		t.Helper()
+		r, err := cs.CallTool(ctx, &mcp.CallToolParams{
+			Name:      mcpserver.CreateNeighborsGraph,
+			Arguments: mcpserver.NeighborParams{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1},
+		})
+		require.NoError(t, err)
+		require.False(t, r.IsError, r)
+	}
+	a, b := connect(), connect()
+	search(a)
+	require.Len(t, graphs(a), 1)
+	assert.Empty(t, graphs(b), "graphs are not visible to other sessions")
+	_, err := b.ReadResource(ctx, &mcp.ReadResourceParams{URI: graphs(a)[0].URI})
+	assert.Error(t, err)
+
+	// Each session has its own limit, other sessions do not remove graphs from a.
+	const searches = 30
+	for range searches {
+		search(b)
+	}
+	assert.Less(t, len(graphs(b)), searches)
+	assert.Len(t, graphs(a), 1)
+}
+
+func TestPrompts(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t, newEngine(t))
+	list, err := client.ListPrompts(ctx, nil)
+	require.NoError(t, err)
+	var names []string
+	for _, p := range list.Prompts {
+		names = append(names, p.Name)
+	}
+	assert.ElementsMatch(t, []string{mcpserver.InvestigatePod, mcpserver.NamespaceChanges}, names)
+
+	get := func(name string, args map[string]string) string {
+		t.Helper()
+		r, err := client.GetPrompt(ctx, &mcp.GetPromptParams{Name: name, Arguments: args})
+		require.NoError(t, err)
+		require.Len(t, r.Messages, 1)
+		return r.Messages[0].Content.(*mcp.TextContent).Text
+	}
+	text := get(mcpserver.InvestigatePod, map[string]string{"namespace": "myapp", "name": "web-0"})
+	assert.Contains(t, text, mcpserver.CreateGoalsGraph)
+	assert.Contains(t, text, `k8s:Pod:{"name":"web-0","namespace":"myapp"}`)
+	text = get(mcpserver.NamespaceChanges, map[string]string{"namespace": "myapp", "window": "30m"})
+	assert.Contains(t, text, mcpserver.DiffGraphs)
+	assert.Contains(t, text, `k8s:Pod:{"namespace":"myapp"}`)
+
+	_, err = client.GetPrompt(ctx, &mcp.GetPromptParams{Name: mcpserver.NamespaceChanges, Arguments: map[string]string{"namespace": "x", "window": "bad"}})
+	assert.Error(t, err)
+}
+
+func TestCreateGraph_progress(t *testing.T) {
+	for _, x := range []struct {
+		tool string
+		args any
+	}{
+		{mcpserver.CreateGoalsGraph, mcpserver.GoalParams{Goals: []string{"mock:b"}, Start: api.Start{Queries: []string{"mock:a:x"}}}},
+		{mcpserver.CreateNeighborsGraph, mcpserver.NeighborParams{Depth: 5, Start: api.Start{Queries: []string{"mock:a:x"}}}},
+	} {
+		t.Run(x.tool, func(t *testing.T) {
+			ctx := context.Background()
+			s := mcpserver.NewServer(mcpserver.NewClientForHandler(newRouter(t, newEngine(t))), "test", logr.Discard())
+			ct, st := mcp.NewInMemoryTransports()
+			ss, err := s.Connect(ctx, st, nil)
+			require.NoError(t, err)
+			var (
+				mu       sync.Mutex
+				messages []string
+			)
+			c := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+				ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+					mu.Lock()
+					defer mu.Unlock()
+					assert.Equal(t, "token", req.Params.ProgressToken)
+					messages = append(messages, req.Params.Message)
+				},
+			})
+			cs, err := c.Connect(ctx, ct, nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = cs.Close(); _ = ss.Wait() })
+
+			params := &mcp.CallToolParams{Name: x.tool, Arguments: x.args}
+			params.SetProgressToken("token")
+			r, err := cs.CallTool(ctx, params)
+			require.NoError(t, err)
+			want := `{"edges":[{"goal":"mock:b","start":"mock:a"}],"nodes":[{"class":"mock:a","count":1,"queries":[{"count":1,"query":"mock:a:x"}]},{"class":"mock:b","count":1,"queries":[{"count":1,"query":"mock:b:y"}]}]}`
+			require.Equal(t, want, graphContent(t, r))
+			assert.EventuallyWithT(t, func(t *assert.CollectT) {
+				mu.Lock()
+				defer mu.Unlock()
+				assert.ElementsMatch(t, []string{
+					"query returned 1 objects: mock:a:x",
+					"found 1 new objects for mock:a",
+					"rule a-b: mock:a -> mock:b",
+					"query returned 1 objects: mock:b:y",
+					"found 1 new objects for mock:b",
+				}, messages)
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
 func TestGetObjects(t *testing.T) {
 	client := newClient(t, newEngine(t))
 	r, err := client.CallTool(context.Background(), &mcp.CallToolParams{